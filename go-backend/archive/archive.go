@@ -0,0 +1,149 @@
+// Package archive exports the whole dataset to a portable archive and
+// restores it into another database, for moving data between environments
+// and handing customers their data.
+//
+// An archive is a zip file with one JSON Lines file per table, holding each
+// record as the API writes it, and a manifest.json listing the tables with
+// their record counts and SHA-256 checksums.
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"everflown-logistics/database"
+	"everflown-logistics/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"gorm.io/plugin/dbresolver"
+)
+
+// Format identifies archives written by Export.
+const Format = "everflown-logistics-export"
+
+// Version is the archive layout Export writes. Import reads archives up to
+// this version.
+const Version = 1
+
+const (
+	manifestFile = "manifest.json"
+	batchSize    = 500
+)
+
+var (
+	// ErrNotEmpty is returned when importing into a database that already
+	// holds records.
+	ErrNotEmpty = errors.New("database is not empty")
+	// ErrInvalidArchive is returned when importing a file that is not an
+	// archive, or one that is damaged or incomplete.
+	ErrInvalidArchive = errors.New("invalid export archive")
+)
+
+func invalid(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidArchive, fmt.Sprintf(format, args...))
+}
+
+// Manifest describes an archive.
+type Manifest struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Tables are listed in import order, parents before the records that
+	// refer to them.
+	Tables []Table `json:"tables"`
+}
+
+// Table describes one table's JSON Lines file.
+type Table struct {
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Count  int    `json:"count"`
+	SHA256 string `json:"sha256"`
+}
+
+func (m Manifest) String() string {
+	parts := make([]string, len(m.Tables))
+	for i, table := range m.Tables {
+		parts[i] = fmt.Sprintf("%d %s", table.Count, strings.ReplaceAll(table.Name, "_", "-"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FileName is the name to save an archive created at t under.
+func FileName(t time.Time) string {
+	return "everflown-export-" + t.UTC().Format("20060102T150405Z") + ".zip"
+}
+
+// tables returns the schema of every model, in import order, followed by
+// the archive tables.
+func tables(db *gorm.DB) ([]*schema.Schema, error) {
+	var schemas []*schema.Schema
+	for _, model := range append(models.All(), models.Archived()...) {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, stmt.Schema)
+	}
+	return schemas, nil
+}
+
+// snapshot runs fn in a read-only transaction, so every table is read as of
+// the same moment. Like other reporting reads it uses the replica when one is
+// configured, falling back to the primary if the replica is unavailable.
+func snapshot(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	opts := &sql.TxOptions{ReadOnly: true}
+	if db.Dialector.Name() == database.Postgres {
+		opts.Isolation = sql.LevelRepeatableRead
+	}
+	tx := db.WithContext(ctx).Clauses(dbresolver.Use(database.Reporting), dbresolver.Read).Begin(opts)
+	if tx.Error != nil && database.IsTransient(tx.Error) && database.HasReplica(db) {
+		slog.Warn("Read replica unavailable, exporting from the primary", "error", tx.Error)
+		tx = db.WithContext(ctx).Begin(opts)
+	}
+	if tx.Error != nil {
+		return tx.Error
+	}
+	defer tx.Rollback()
+	return fn(tx)
+}
+
+// open returns the archive's manifest and files by name, checking that it is
+// an archive this version can read.
+func open(r *zip.Reader) (Manifest, map[string]*zip.File, error) {
+	files := make(map[string]*zip.File, len(r.File))
+	for _, f := range r.File {
+		files[f.Name] = f
+	}
+	var manifest Manifest
+	f, ok := files[manifestFile]
+	if !ok {
+		return manifest, nil, invalid("no %s", manifestFile)
+	}
+	if err := readJSON(f, &manifest); err != nil {
+		return manifest, nil, invalid("reading %s: %v", manifestFile, err)
+	}
+	if manifest.Format != Format {
+		return manifest, nil, invalid("format %q", manifest.Format)
+	}
+	if manifest.Version < 1 || manifest.Version > Version {
+		return manifest, nil, invalid("version %d is not supported (up to %d)", manifest.Version, Version)
+	}
+	return manifest, files, nil
+}
+
+func readJSON(f *zip.File, v interface{}) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return json.NewDecoder(rc).Decode(v)
+}
@@ -0,0 +1,90 @@
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Export writes every record in db to w as an archive and returns its
+// manifest. The tables are read from a single consistent snapshot. If Export
+// fails, what was written to w is not a valid archive.
+func Export(ctx context.Context, db *gorm.DB, w io.Writer) (Manifest, error) {
+	manifest := Manifest{Format: Format, Version: Version, CreatedAt: time.Now().UTC()}
+	zw := zip.NewWriter(w)
+	err := snapshot(ctx, db, func(tx *gorm.DB) error {
+		schemas, err := tables(tx)
+		if err != nil {
+			return err
+		}
+		for _, s := range schemas {
+			table, err := exportTable(tx, zw, s)
+			if err != nil {
+				return err
+			}
+			manifest.Tables = append(manifest.Tables, table)
+		}
+		return nil
+	})
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	f, err := zw.Create(manifestFile)
+	if err != nil {
+		return Manifest{}, err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return Manifest{}, err
+	}
+	return manifest, zw.Close()
+}
+
+// exportTable writes the records of s, in primary key order, to their own
+// file in zw. Fields tagged secret, such as password hashes, are left out.
+func exportTable(tx *gorm.DB, zw *zip.Writer, s *schema.Schema) (Table, error) {
+	table := Table{Name: s.Table, File: s.Table + ".jsonl"}
+	f, err := zw.Create(table.File)
+	if err != nil {
+		return table, err
+	}
+	hash := sha256.New()
+	enc := json.NewEncoder(io.MultiWriter(f, hash))
+	var secrets []*schema.Field
+	for _, field := range s.Fields {
+		if field.Tag.Get("secret") == "true" {
+			secrets = append(secrets, field)
+		}
+	}
+
+	records := reflect.New(reflect.SliceOf(s.ModelType))
+	err = tx.Model(reflect.New(s.ModelType).Interface()).FindInBatches(records.Interface(), batchSize, func(*gorm.DB, int) error {
+		batch := records.Elem()
+		for i := 0; i < batch.Len(); i++ {
+			record := batch.Index(i)
+			for _, field := range secrets {
+				field.ReflectValueOf(tx.Statement.Context, record).SetZero()
+			}
+			if err := enc.Encode(record.Interface()); err != nil {
+				return err
+			}
+		}
+		table.Count += batch.Len()
+		return nil
+	}).Error
+	if err != nil {
+		return table, err
+	}
+	table.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return table, nil
+}
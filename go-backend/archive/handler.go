@@ -0,0 +1,73 @@
+package archive
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+
+	"everflown-logistics/logging"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ExportHandler responds with an archive of every record as a download. The
+// archive is built in a temporary file first, so a failure part way through
+// is still reported as an error.
+func ExportHandler(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		f, err := os.CreateTemp("", "everflown-export-*.zip")
+		if err != nil {
+			serverError(c, err, "Failed to export data")
+			return
+		}
+		defer os.Remove(f.Name())
+		defer f.Close()
+
+		manifest, err := Export(c.Request.Context(), db, f)
+		if err != nil {
+			serverError(c, err, "Failed to export data")
+			return
+		}
+		logging.FromContext(c.Request.Context()).Info("Exported data", "tables", manifest.String())
+		c.FileAttachment(f.Name(), FileName(manifest.CreatedAt))
+	}
+}
+
+// ImportHandler restores the archive in the request body into an empty
+// database and responds with its manifest. It responds 409 if the database
+// holds records and 400 if the body is not a valid archive.
+func ImportHandler(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		f, err := os.CreateTemp("", "everflown-import-*.zip")
+		if err != nil {
+			serverError(c, err, "Failed to import data")
+			return
+		}
+		defer os.Remove(f.Name())
+		defer f.Close()
+
+		size, err := io.Copy(f, c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read the archive"})
+			return
+		}
+		manifest, err := Import(c.Request.Context(), db, f, size)
+		switch {
+		case errors.Is(err, ErrNotEmpty):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, ErrInvalidArchive):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case err != nil:
+			serverError(c, err, "Failed to import data")
+		default:
+			logging.FromContext(c.Request.Context()).Info("Imported data", "tables", manifest.String())
+			c.JSON(http.StatusOK, manifest)
+		}
+	}
+}
+
+func serverError(c *gin.Context, err error, message string) {
+	logging.FromContext(c.Request.Context()).Error(message, "error", err, "route", c.FullPath())
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+}
@@ -0,0 +1,308 @@
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"everflown-logistics/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Import restores the archive in r, of the given size, into db, which must
+// hold no records. Records get new IDs and the references between them are
+// rewritten to match, so relationships are preserved. Users keep their IDs,
+// and archived orders stay archived. Everything is restored in one
+// transaction: if the archive is damaged or incomplete, nothing is imported.
+func Import(ctx context.Context, db *gorm.DB, r io.ReaderAt, size int64) (Manifest, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return Manifest{}, invalid("%v", err)
+	}
+	manifest, files, err := open(zr)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		schemas, err := tables(tx)
+		if err != nil {
+			return err
+		}
+		if err := ensureEmpty(tx, schemas); err != nil {
+			return err
+		}
+
+		byName := make(map[string]*schema.Schema, len(schemas))
+		for _, s := range schemas {
+			byName[s.Table] = s
+		}
+		for _, table := range manifest.Tables {
+			if byName[table.Name] == nil {
+				return invalid("unknown table %q", table.Name)
+			}
+		}
+
+		im := importer{tx: tx, ids: map[string]map[uint]uint{}, live: map[string]*schema.Schema{}, archivedAt: map[string]map[uint]time.Time{}}
+		for _, s := range schemas {
+			if archived := byName[repository.ArchiveTable(s.Table)]; archived != nil {
+				im.live[archived.Table] = s
+			}
+		}
+		for _, s := range schemas {
+			for _, table := range manifest.Tables {
+				if table.Name != s.Table {
+					continue
+				}
+				f, ok := files[table.File]
+				if !ok {
+					return invalid("missing %s", table.File)
+				}
+				if err := im.table(s, table, f); err != nil {
+					return err
+				}
+			}
+		}
+		return im.rearchive(ctx)
+	})
+	if err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
+// ensureEmpty returns ErrNotEmpty, naming the tables, if any table holds records.
+func ensureEmpty(tx *gorm.DB, schemas []*schema.Schema) error {
+	var full []string
+	for _, s := range schemas {
+		var count int64
+		if err := tx.Table(s.Table).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			full = append(full, s.Table)
+		}
+	}
+	if len(full) > 0 {
+		return fmt.Errorf("%w: %s already hold records", ErrNotEmpty, strings.Join(full, ", "))
+	}
+	return nil
+}
+
+// importer restores tables in order, remembering the new ID of every record
+// so that later tables can refer to it.
+//
+// Records from the archive tables take their IDs from the live tables, so
+// they are imported into the live tables first and moved back into the
+// archive tables once every table is restored.
+type importer struct {
+	tx *gorm.DB
+	// ids maps each table's archived IDs to the IDs assigned on import.
+	ids map[string]map[uint]uint
+	// live maps each archive table to the schema of its live table.
+	live map[string]*schema.Schema
+	// archivedAt holds the archive time of each record imported from an
+	// archive table, by table and new ID.
+	archivedAt map[string]map[uint]time.Time
+}
+
+// table restores one table, checking its record count and checksum against
+// the manifest. Problems with the file are reported as ErrInvalidArchive.
+func (im *importer) table(s *schema.Schema, table Table, f *zip.File) error {
+	rc, err := f.Open()
+	if err != nil {
+		return invalid("%s: %v", table.File, err)
+	}
+	defer rc.Close()
+	hash := sha256.New()
+	body := io.TeeReader(rc, hash)
+	dec := json.NewDecoder(body)
+
+	// Archived records are inserted as the live records they embed.
+	target := s
+	live := im.live[s.Table]
+	if live != nil {
+		target = live
+		im.archivedAt[s.Table] = map[uint]time.Time{}
+	}
+	renumber := target.PrioritizedPrimaryField != nil && target.PrioritizedPrimaryField.DataType == schema.Uint
+	if renumber {
+		im.ids[s.Table] = map[uint]uint{}
+	}
+	batch := reflect.New(reflect.SliceOf(target.ModelType)).Elem()
+	var oldIDs []uint
+	var archivedAt []time.Time
+	flush := func() error {
+		if err := im.insert(target, s.Table, batch, oldIDs); err != nil {
+			return fmt.Errorf("%s: %w", table.File, err)
+		}
+		for i, at := range archivedAt {
+			im.archivedAt[s.Table][im.ids[s.Table][oldIDs[i]]] = at
+		}
+		batch, oldIDs, archivedAt = batch.Slice(0, 0), oldIDs[:0], archivedAt[:0]
+		return nil
+	}
+	count := 0
+	for {
+		record := reflect.New(s.ModelType)
+		err := dec.Decode(record.Interface())
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return invalid("%s record %d: %v", table.File, count+1, err)
+		}
+		count++
+		value := record.Elem()
+		if live != nil {
+			archivedAt = append(archivedAt, value.FieldByName("ArchivedAt").Interface().(time.Time))
+			value = value.FieldByName(live.ModelType.Name())
+		}
+		if err := im.remap(s.Table, target, value); err != nil {
+			return invalid("%s record %d: %v", table.File, count, err)
+		}
+		if renumber {
+			id := target.PrioritizedPrimaryField.ReflectValueOf(im.tx.Statement.Context, value)
+			oldIDs = append(oldIDs, uint(id.Uint()))
+			id.SetUint(0)
+		}
+		batch = reflect.Append(batch, value)
+		if batch.Len() == batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if batch.Len() > 0 {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.Copy(io.Discard, body); err != nil {
+		return invalid("%s: %v", table.File, err)
+	}
+	if count != table.Count {
+		return invalid("%s holds %d records, the manifest lists %d", table.File, count, table.Count)
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != table.SHA256 {
+		return invalid("%s checksum is %s, the manifest lists %s", table.File, sum, table.SHA256)
+	}
+	return nil
+}
+
+// remap rewrites the references of a record from table, stored as s, to the
+// IDs their targets were given. Archived records refer to archived parents.
+func (im *importer) remap(table string, s *schema.Schema, record reflect.Value) error {
+	ctx := im.tx.Statement.Context
+	for _, rel := range s.Relationships.BelongsTo {
+		field := rel.References[0].ForeignKey
+		value := field.ReflectValueOf(ctx, record)
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		}
+		old := uint(value.Uint())
+		if old == 0 {
+			continue
+		}
+		parent := rel.FieldSchema.Table
+		if im.live[table] != nil && im.live[repository.ArchiveTable(parent)] != nil {
+			parent = repository.ArchiveTable(parent)
+		}
+		id, ok := im.ids[parent][old]
+		if !ok {
+			return fmt.Errorf("%s refers to %s %d, which is not in the archive", field.DBName, parent, old)
+		}
+		value.SetUint(uint64(id))
+	}
+	return nil
+}
+
+// insert creates a batch of records in s's table and records their new IDs
+// under table. GORM writes a column's declared default in place of a zero
+// value, such as false for a column defaulting to true, so those columns are
+// set again afterwards.
+func (im *importer) insert(s *schema.Schema, table string, batch reflect.Value, oldIDs []uint) error {
+	ctx := im.tx.Statement.Context
+	var zeroed []map[string]interface{}
+	for i := 0; i < batch.Len(); i++ {
+		columns := map[string]interface{}{}
+		for _, field := range s.Fields {
+			if field.DBName == "" || field.DefaultValueInterface == nil {
+				continue
+			}
+			if _, isZero := field.ValueOf(ctx, batch.Index(i)); isZero {
+				columns[field.DBName] = reflect.Zero(field.FieldType).Interface()
+			}
+		}
+		zeroed = append(zeroed, columns)
+	}
+
+	records := reflect.New(batch.Type())
+	records.Elem().Set(batch)
+	if err := im.tx.Omit(clause.Associations).Create(records.Interface()).Error; err != nil {
+		return err
+	}
+
+	primary := s.PrioritizedPrimaryField
+	for i := 0; i < batch.Len(); i++ {
+		id, _ := primary.ValueOf(ctx, batch.Index(i))
+		if len(zeroed[i]) > 0 {
+			if err := im.tx.Table(s.Table).Where(primary.DBName+" = ?", id).UpdateColumns(zeroed[i]).Error; err != nil {
+				return err
+			}
+		}
+		if oldIDs != nil {
+			im.ids[table][oldIDs[i]] = uint(reflect.ValueOf(id).Uint())
+		}
+	}
+	return nil
+}
+
+// rearchive moves the records imported from the archive tables back into
+// them, with their original archive times.
+func (im *importer) rearchive(ctx context.Context) error {
+	orders := im.ids[repository.ArchiveTable("orders")]
+	if len(orders) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(orders))
+	for _, id := range orders {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	archive := repository.New(im.tx).Archive
+	for start := 0; start < len(ids); start += batchSize {
+		end := min(start+batchSize, len(ids))
+		if err := archive.Archive(ctx, ids[start:end], time.Now().UTC()); err != nil {
+			return err
+		}
+	}
+
+	for table, times := range im.archivedAt {
+		byTime := map[time.Time][]uint{}
+		for id, at := range times {
+			byTime[at.UTC()] = append(byTime[at.UTC()], id)
+		}
+		for at, rows := range byTime {
+			if err := im.tx.Table(table).Where("id IN ?", rows).Update("archived_at", at).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
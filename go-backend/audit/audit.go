@@ -0,0 +1,34 @@
+// Package audit carries the identity of whoever is making a change through
+// the request context, so the repositories can attribute the audit log
+// entries they write.
+package audit
+
+import "context"
+
+// System is recorded as the user for changes made without one, such as
+// background jobs.
+const System = "system"
+
+// Actor identifies who made a change, for which tenant, and in which request.
+type Actor struct {
+	UserID    string
+	TenantID  string
+	RequestID string
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying actor.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor carried by ctx. Without one, or without a user,
+// the user is System.
+func ActorFrom(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	if actor.UserID == "" {
+		actor.UserID = System
+	}
+	return actor
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"everflown-logistics/database"
+	"gorm.io/gorm"
+)
+
+// runCommand executes the subcommand in args instead of starting the server
+// and returns the process exit code. migrations is the configured
+// DB_MIGRATIONS mode, enforced before commands that need the schema.
+func runCommand(ctx context.Context, db *gorm.DB, migrations string, args []string) int {
+	defer database.Close(db)
+
+	var err error
+	switch args[0] {
+	case "migrate":
+		err = runMigrate(ctx, db, args[1:], os.Stdout)
+	case "seed":
+		if err = ensureMigrated(ctx, db, migrations); err == nil {
+			err = runSeed(ctx, db, args[1:], os.Stdout)
+		}
+	case "export":
+		if err = ensureMigrated(ctx, db, migrations); err == nil {
+			err = runExport(ctx, db, args[1:], os.Stdout)
+		}
+	case "import":
+		if err = ensureMigrated(ctx, db, migrations); err == nil {
+			err = runImport(ctx, db, args[1:], os.Stdout)
+		}
+	default:
+		err = fmt.Errorf("unknown command %q (available: migrate, seed, export, import)", args[0])
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,325 @@
+// Package config loads the backend's typed configuration from defaults, an
+// optional YAML or TOML file, environment variables and command-line flags,
+// in increasing order of precedence.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"everflown-logistics/database"
+	"everflown-logistics/middleware"
+	"everflown-logistics/services"
+)
+
+// Config is the complete backend configuration. Each field names its file
+// key (yaml), environment variable (env) and, derived from the latter, its
+// flag: PORT becomes -port, HTTP_READ_TIMEOUT becomes -http-read-timeout.
+// Fields tagged secret are redacted when printed.
+type Config struct {
+	Server    ServerConfig    `yaml:"server"`
+	GRPC      GRPCConfig      `yaml:"grpc"`
+	Database  DatabaseConfig  `yaml:"database"`
+	CORS      CORSConfig      `yaml:"cors"`
+	Log       LogConfig       `yaml:"log"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	RateLimit RateLimitConfig `yaml:"rateLimit"`
+	Health    HealthConfig    `yaml:"health"`
+	Archive   ArchiveConfig   `yaml:"archive"`
+	Events    EventsConfig    `yaml:"events"`
+	Webhooks  WebhooksConfig  `yaml:"webhooks"`
+	Stream    StreamConfig    `yaml:"stream"`
+	Company   CompanyConfig   `yaml:"company"`
+}
+
+type ServerConfig struct {
+	// Host defaults to loopback, so only the proxy on the same machine can
+	// reach the backend. Listening elsewhere requires IdentitySecret.
+	Host              string        `yaml:"host" env:"HOST"`
+	Port              int           `yaml:"port" env:"PORT"`
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout" env:"HTTP_READ_HEADER_TIMEOUT"`
+	ReadTimeout       time.Duration `yaml:"readTimeout" env:"HTTP_READ_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"writeTimeout" env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idleTimeout" env:"HTTP_IDLE_TIMEOUT"`
+	ShutdownTimeout   time.Duration `yaml:"shutdownTimeout" env:"SHUTDOWN_TIMEOUT"`
+	TLSCertFile       string        `yaml:"tlsCertFile" env:"TLS_CERT_FILE"`
+	TLSKeyFile        string        `yaml:"tlsKeyFile" env:"TLS_KEY_FILE"`
+	// IdentitySecret is shared with the proxy, which signs the identity
+	// headers it forwards with it. Unsigned identities are then refused.
+	IdentitySecret string `yaml:"identitySecret" env:"IDENTITY_SECRET" secret:"true"`
+	// TrustedProxies are the addresses or CIDR ranges whose X-Forwarded-For
+	// header is believed when working out the client IP.
+	TrustedProxies []string `yaml:"trustedProxies" env:"TRUSTED_PROXIES"`
+}
+
+type GRPCConfig struct {
+	Port int `yaml:"port" env:"GRPC_PORT"`
+}
+
+type DatabaseConfig struct {
+	URL string `yaml:"url" env:"DATABASE_URL" secret:"true"`
+	// ReplicaURL is an optional read replica for reporting queries such as
+	// dashboard stats and exports.
+	ReplicaURL string `yaml:"replicaUrl" env:"DATABASE_REPLICA_URL" secret:"true"`
+	// Migrations is "check" (refuse to start unless fully migrated), "auto"
+	// (apply pending migrations at startup) or "off".
+	Migrations string `yaml:"migrations" env:"DB_MIGRATIONS"`
+
+	MaxOpenConns    int           `yaml:"maxOpenConns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"maxIdleConns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `yaml:"connMaxIdleTime" env:"DB_CONN_MAX_IDLE_TIME"`
+	// ConnectTimeout is how long startup keeps retrying an unreachable database.
+	ConnectTimeout time.Duration `yaml:"connectTimeout" env:"DB_CONNECT_TIMEOUT"`
+	// ReadRetries is how many times a read that failed with a transient
+	// error is retried; 0 disables retries.
+	ReadRetries int `yaml:"readRetries" env:"DB_READ_RETRIES"`
+}
+
+// Options returns the connection pool settings.
+func (c DatabaseConfig) Options() database.Options {
+	return database.Options{
+		MaxOpenConns:    c.MaxOpenConns,
+		MaxIdleConns:    c.MaxIdleConns,
+		ConnMaxLifetime: c.ConnMaxLifetime,
+		ConnMaxIdleTime: c.ConnMaxIdleTime,
+		ConnectTimeout:  c.ConnectTimeout,
+	}
+}
+
+// ReadRetry returns the retry policy for repository reads.
+func (c DatabaseConfig) ReadRetry() database.RetryPolicy {
+	policy := database.DefaultReadRetry
+	policy.Attempts = c.ReadRetries + 1
+	return policy
+}
+
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowedOrigins" env:"CORS_ALLOWED_ORIGINS"`
+}
+
+type LogConfig struct {
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+}
+
+type TracingConfig struct {
+	Exporter    string `yaml:"exporter" env:"OTEL_TRACES_EXPORTER"`
+	ServiceName string `yaml:"serviceName" env:"OTEL_SERVICE_NAME"`
+}
+
+// RateLimitConfig holds per-route-group limits written as "<requests>/<window>" or "off".
+type RateLimitConfig struct {
+	Store string `yaml:"store" env:"RATE_LIMIT_STORE"`
+	API   string `yaml:"api" env:"RATE_LIMIT_API"`
+	Auth  string `yaml:"auth" env:"RATE_LIMIT_AUTH"`
+	PDF   string `yaml:"pdf" env:"RATE_LIMIT_PDF"`
+}
+
+type HealthConfig struct {
+	CacheTTL time.Duration `yaml:"cacheTTL" env:"HEALTH_CACHE_TTL"`
+}
+
+// ArchiveConfig controls the job that moves closed orders to the archive tables.
+type ArchiveConfig struct {
+	// AfterDays is how long after delivery a settled order is archived; 0
+	// disables archiving.
+	AfterDays int           `yaml:"afterDays" env:"ARCHIVE_ORDERS_AFTER_DAYS"`
+	Interval  time.Duration `yaml:"interval" env:"ARCHIVE_INTERVAL"`
+}
+
+// EventsConfig controls delivery of domain events from the outbox.
+type EventsConfig struct {
+	PollInterval time.Duration `yaml:"pollInterval" env:"EVENTS_POLL_INTERVAL"`
+	// MaxAttempts is how often delivery to a subscriber is tried before the
+	// event becomes a dead letter.
+	MaxAttempts int `yaml:"maxAttempts" env:"EVENTS_MAX_ATTEMPTS"`
+	// Retention is how long delivered events are kept in the outbox.
+	Retention time.Duration `yaml:"retention" env:"EVENTS_RETENTION"`
+}
+
+// WebhooksConfig controls sending events to webhook subscriptions.
+type WebhooksConfig struct {
+	// Interval is how often deliveries that are due are sent.
+	Interval time.Duration `yaml:"interval" env:"WEBHOOK_INTERVAL"`
+	Timeout  time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT"`
+	// MaxAttempts is how often a delivery is tried before it is marked failed.
+	MaxAttempts int `yaml:"maxAttempts" env:"WEBHOOK_MAX_ATTEMPTS"`
+	// DisableAfter is how many failed attempts in a row disable a
+	// subscription; 0 never disables one.
+	DisableAfter int `yaml:"disableAfter" env:"WEBHOOK_DISABLE_AFTER"`
+	// Retention is how long finished deliveries are kept in the log.
+	Retention time.Duration `yaml:"retention" env:"WEBHOOK_LOG_RETENTION"`
+	// AllowPrivateNetworks lets subscriptions point at loopback, private and
+	// link-local addresses, for development.
+	AllowPrivateNetworks bool `yaml:"allowPrivateNetworks" env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS"`
+}
+
+// StreamConfig controls the change stream at /api/stream.
+type StreamConfig struct {
+	// PollInterval is how often the audit log is checked for changes to push.
+	PollInterval time.Duration `yaml:"pollInterval" env:"STREAM_POLL_INTERVAL"`
+	// Heartbeat is how often an idle stream gets a comment to keep it open.
+	Heartbeat time.Duration `yaml:"heartbeat" env:"STREAM_HEARTBEAT"`
+}
+
+// CompanyConfig is the branding printed on generated documents.
+type CompanyConfig struct {
+	Name    string `yaml:"name" env:"COMPANY_NAME"`
+	Tagline string `yaml:"tagline" env:"COMPANY_TAGLINE"`
+	Motto   string `yaml:"motto" env:"COMPANY_MOTTO"`
+}
+
+// Default returns the configuration used when nothing overrides it.
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Host:              "127.0.0.1",
+			TrustedProxies:    []string{"127.0.0.1", "::1"},
+			Port:              8080,
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      60 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		GRPC: GRPCConfig{Port: 9090},
+		Database: DatabaseConfig{
+			Migrations:      "check",
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			ConnectTimeout:  time.Minute,
+			ReadRetries:     2,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{"http://localhost:5000", "https://*.replit.app", "https://*.replit.dev"},
+		},
+		Log:     LogConfig{Level: "info"},
+		Tracing: TracingConfig{Exporter: "none", ServiceName: "everflown-logistics"},
+		RateLimit: RateLimitConfig{
+			Store: "memory",
+			API:   "300/1m",
+			Auth:  "10/1m",
+			PDF:   "30/1m",
+		},
+		Health:  HealthConfig{CacheTTL: 5 * time.Second},
+		Archive: ArchiveConfig{Interval: 24 * time.Hour},
+		Events:  EventsConfig{PollInterval: time.Second, MaxAttempts: 10, Retention: 7 * 24 * time.Hour},
+		Webhooks: WebhooksConfig{
+			Interval:     5 * time.Second,
+			Timeout:      10 * time.Second,
+			MaxAttempts:  12,
+			DisableAfter: 50,
+			Retention:    30 * 24 * time.Hour,
+		},
+		Stream: StreamConfig{PollInterval: time.Second, Heartbeat: 15 * time.Second},
+		Company: CompanyConfig{
+			Name:    services.DefaultBranding.Name,
+			Tagline: services.DefaultBranding.Tagline,
+			Motto:   services.DefaultBranding.Motto,
+		},
+	}
+}
+
+// Validate reports every invalid setting at once.
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Server.Port > 0 && c.Server.Port < 65536, "PORT must be between 1 and 65535, got %d", c.Server.Port)
+	check(c.GRPC.Port > 0 && c.GRPC.Port < 65536, "GRPC_PORT must be between 1 and 65535, got %d", c.GRPC.Port)
+	check(c.GRPC.Port != c.Server.Port, "GRPC_PORT must differ from PORT")
+	check(c.Server.ReadHeaderTimeout > 0, "HTTP_READ_HEADER_TIMEOUT must be positive")
+	check(c.Server.ReadTimeout > 0, "HTTP_READ_TIMEOUT must be positive")
+	check(c.Server.WriteTimeout > 0, "HTTP_WRITE_TIMEOUT must be positive")
+	check(c.Server.IdleTimeout > 0, "HTTP_IDLE_TIMEOUT must be positive")
+	check(c.Server.ShutdownTimeout > 0, "SHUTDOWN_TIMEOUT must be positive")
+	check((c.Server.TLSCertFile == "") == (c.Server.TLSKeyFile == ""), "TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	for _, proxy := range c.Server.TrustedProxies {
+		_, _, err := net.ParseCIDR(proxy)
+		check(err == nil || net.ParseIP(proxy) != nil, "TRUSTED_PROXIES must hold IP addresses or CIDR ranges, got %q", proxy)
+	}
+	check(c.Server.IdentitySecret != "" || isLoopback(c.Server.Host), "IDENTITY_SECRET is required unless HOST is a loopback address, got %q", c.Server.Host)
+
+	check(c.Database.URL != "", "DATABASE_URL is required")
+	if c.Database.URL != "" {
+		_, err := database.Dialect(c.Database.URL)
+		check(err == nil, "DATABASE_URL: %v", err)
+	}
+	if c.Database.ReplicaURL != "" {
+		_, err := database.Dialect(c.Database.ReplicaURL)
+		check(err == nil, "DATABASE_REPLICA_URL: %v", err)
+	}
+	check(oneOf(c.Database.Migrations, "check", "auto", "off"), "DB_MIGRATIONS must be check, auto or off, got %q", c.Database.Migrations)
+	check(c.Database.MaxOpenConns > 0, "DB_MAX_OPEN_CONNS must be positive")
+	check(c.Database.MaxIdleConns >= 0 && c.Database.MaxIdleConns <= c.Database.MaxOpenConns, "DB_MAX_IDLE_CONNS must be between 0 and DB_MAX_OPEN_CONNS")
+	check(c.Database.ConnMaxLifetime >= 0, "DB_CONN_MAX_LIFETIME must not be negative")
+	check(c.Database.ConnMaxIdleTime >= 0, "DB_CONN_MAX_IDLE_TIME must not be negative")
+	check(c.Database.ConnectTimeout >= 0, "DB_CONNECT_TIMEOUT must not be negative")
+	check(c.Database.ReadRetries >= 0, "DB_READ_RETRIES must not be negative")
+
+	check(oneOf(c.Log.Level, "debug", "info", "warn", "warning", "error"), "LOG_LEVEL must be debug, info, warn or error, got %q", c.Log.Level)
+	check(oneOf(c.Tracing.Exporter, "none", "otlp", "stdout", "console"), "OTEL_TRACES_EXPORTER must be none, otlp or stdout, got %q", c.Tracing.Exporter)
+
+	check(oneOf(c.RateLimit.Store, "memory", "postgres"), "RATE_LIMIT_STORE must be memory or postgres, got %q", c.RateLimit.Store)
+	for _, group := range []struct{ env, limit string }{
+		{"RATE_LIMIT_API", c.RateLimit.API},
+		{"RATE_LIMIT_AUTH", c.RateLimit.Auth},
+		{"RATE_LIMIT_PDF", c.RateLimit.PDF},
+	} {
+		if group.limit == "off" {
+			continue
+		}
+		if _, err := middleware.ParseLimit(group.limit); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", group.env, err))
+		}
+	}
+
+	check(c.Health.CacheTTL >= 0, "HEALTH_CACHE_TTL must not be negative")
+	check(c.Archive.AfterDays >= 0, "ARCHIVE_ORDERS_AFTER_DAYS must not be negative")
+	check(c.Archive.Interval > 0, "ARCHIVE_INTERVAL must be positive")
+	check(c.Events.PollInterval > 0, "EVENTS_POLL_INTERVAL must be positive")
+	check(c.Events.MaxAttempts > 0, "EVENTS_MAX_ATTEMPTS must be positive")
+	check(c.Events.Retention >= 0, "EVENTS_RETENTION must not be negative")
+	check(c.Webhooks.Interval > 0, "WEBHOOK_INTERVAL must be positive")
+	check(c.Webhooks.Timeout > 0, "WEBHOOK_TIMEOUT must be positive")
+	check(c.Webhooks.MaxAttempts > 0, "WEBHOOK_MAX_ATTEMPTS must be positive")
+	check(c.Webhooks.DisableAfter >= 0, "WEBHOOK_DISABLE_AFTER must not be negative")
+	check(c.Webhooks.Retention >= 0, "WEBHOOK_LOG_RETENTION must not be negative")
+	check(c.Stream.PollInterval > 0, "STREAM_POLL_INTERVAL must be positive")
+	check(c.Stream.Heartbeat > 0, "STREAM_HEARTBEAT must be positive")
+	check(c.Company.Name != "", "COMPANY_NAME must not be empty")
+
+	return errors.Join(errs...)
+}
+
+// Branding returns the company branding for generated documents.
+func (c CompanyConfig) Branding() services.Branding {
+	return services.Branding{Name: c.Name, Tagline: c.Tagline, Motto: c.Motto}
+}
+
+// isLoopback reports whether host only accepts connections from the same
+// machine.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func oneOf(v string, allowed ...string) bool {
+	for _, a := range allowed {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv names the environment variable pointing at the config file; the
+// -config flag takes precedence over it.
+const FileEnv = "CONFIG_FILE"
+
+// Load builds the configuration from defaults, the config file, the
+// environment and args (command-line flags, without the program name), then
+// validates it. lookupEnv is usually os.LookupEnv. The arguments after the
+// flags, such as a subcommand, are returned. For -h it prints the flags and
+// returns flag.ErrHelp.
+func Load(args []string, lookupEnv func(string) (string, bool)) (Config, []string, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("everflown-logistics", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	configFile := fs.String("config", "", "path to a YAML or TOML config file (env "+FileEnv+")")
+	flagValues := registerFlags(fs, reflect.ValueOf(&cfg).Elem())
+	if err := fs.Parse(args); err != nil {
+		return cfg, nil, err
+	}
+
+	path := *configFile
+	if path == "" {
+		path, _ = lookupEnv(FileEnv)
+	}
+	if path != "" {
+		if err := loadFile(path, &cfg); err != nil {
+			return cfg, nil, err
+		}
+	}
+
+	if err := applyEnv(reflect.ValueOf(&cfg).Elem(), lookupEnv); err != nil {
+		return cfg, nil, err
+	}
+
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		if field, ok := flagValues[f.Name]; ok {
+			if err := setField(field, f.Value.String()); err != nil {
+				flagErr = errors.Join(flagErr, fmt.Errorf("-%s: %w", f.Name, err))
+			}
+		}
+	})
+	if flagErr != nil {
+		return cfg, nil, flagErr
+	}
+
+	return cfg, fs.Args(), cfg.Validate()
+}
+
+// loadFile overlays the YAML (.yaml, .yml) or TOML (.toml) file at path onto cfg.
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+	case ".toml":
+		// Decode TOML generically and re-encode it as YAML, so both formats
+		// share the yaml tags and yaml.v3's duration parsing.
+		var doc map[string]interface{}
+		if err := toml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+		if data, err = yaml.Marshal(doc); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("config file %s: unsupported format, use .yaml, .yml or .toml", path)
+	}
+
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv sets every field whose env variable is present.
+func applyEnv(v reflect.Value, lookupEnv func(string) (string, bool)) error {
+	var errs []error
+	walkFields(v, func(field reflect.Value, sf reflect.StructField) {
+		name := sf.Tag.Get("env")
+		raw, ok := lookupEnv(name)
+		if !ok || raw == "" {
+			return
+		}
+		if err := setField(field, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// registerFlags defines a string flag for every env-tagged field and returns
+// the fields by flag name. Values are applied after the environment, and
+// only for flags given on the command line.
+func registerFlags(fs *flag.FlagSet, v reflect.Value) map[string]reflect.Value {
+	fields := make(map[string]reflect.Value)
+	walkFields(v, func(field reflect.Value, sf reflect.StructField) {
+		env := sf.Tag.Get("env")
+		name := flagName(env)
+		fs.String(name, "", "overrides "+env)
+		fields[name] = field
+	})
+	return fields
+}
+
+func flagName(env string) string {
+	return strings.ReplaceAll(strings.ToLower(env), "_", "-")
+}
+
+// walkFields calls fn for every env-tagged leaf field of the struct v.
+func walkFields(v reflect.Value, fn func(reflect.Value, reflect.StructField)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		field := v.Field(i)
+		if sf.Type.Kind() == reflect.Struct {
+			walkFields(field, fn)
+			continue
+		}
+		if sf.Tag.Get("env") != "" {
+			fn(field, sf)
+		}
+	}
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setField parses raw into field according to the field's type.
+func setField(field reflect.Value, raw string) error {
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		field.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported config field type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"net/url"
+	"reflect"
+)
+
+const redacted = "REDACTED"
+
+// Redacted returns the configuration as a nested map keyed like the config
+// file, with secret values masked, for printing at startup.
+func (c Config) Redacted() map[string]interface{} {
+	return redactStruct(reflect.ValueOf(c))
+}
+
+func redactStruct(v reflect.Value) map[string]interface{} {
+	out := make(map[string]interface{})
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		key := sf.Tag.Get("yaml")
+		field := v.Field(i)
+
+		switch {
+		case sf.Type.Kind() == reflect.Struct:
+			out[key] = redactStruct(field)
+		case field.Type() == durationType:
+			out[key] = field.Interface().(interface{ String() string }).String()
+		case sf.Tag.Get("secret") == "true":
+			out[key] = redactSecret(field.String())
+		default:
+			out[key] = field.Interface()
+		}
+	}
+	return out
+}
+
+// redactSecret masks a secret. URLs keep everything but the password so the
+// effective host and database are still visible.
+func redactSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return redacted
+	}
+	if _, hasPassword := u.User.Password(); hasPassword {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	q := u.Query()
+	for _, key := range []string{"password", "sslpassword"} {
+		if q.Has(key) {
+			q.Set(key, redacted)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -1,30 +1,164 @@
 package database
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
-	"os"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
 
+	"everflown-logistics/metrics"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
-var DB *gorm.DB
+// Dialect names as reported by gorm.Dialector.Name.
+const (
+	Postgres = "postgres"
+	SQLite   = "sqlite"
+)
+
+// sqlitePrefix marks a SQLite DSN, as in "sqlite:everflown.db" or
+// "sqlite::memory:". Everything after it is the file path.
+const sqlitePrefix = "sqlite:"
 
-func Connect() {
-	dsn := os.Getenv("DATABASE_URL")
-	if dsn == "" {
-		log.Fatal("DATABASE_URL environment variable is required")
+// Dialect reports which database a DSN refers to. Postgres DSNs may be URLs
+// (postgres:// or postgresql://) or libpq key=value strings.
+func Dialect(dsn string) (string, error) {
+	if strings.HasPrefix(dsn, sqlitePrefix) {
+		return SQLite, nil
+	}
+	if scheme, _, ok := strings.Cut(dsn, "://"); ok && scheme != "postgres" && scheme != "postgresql" {
+		return "", fmt.Errorf("unsupported database scheme %q; use postgres:// or sqlite:", scheme)
 	}
+	return Postgres, nil
+}
 
+// Options tunes the connection pool and startup behaviour. Zero values keep
+// the database/sql defaults. SQLite always uses a single connection.
+type Options struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	// ConnectTimeout is how long Connect keeps retrying a database that is
+	// unreachable or still starting. Zero means a single attempt.
+	ConnectTimeout time.Duration
+}
+
+// Connect opens the database at dsn, retrying transient failures with
+// backoff until opts.ConnectTimeout has passed. The returned handle is
+// passed to the repositories and other components that need it; there is
+// no global.
+func Connect(ctx context.Context, dsn string, opts Options) (*gorm.DB, error) {
+	dialect, err := Dialect(dsn)
+	if err != nil {
+		return nil, err
+	}
+	db, err := connect(ctx, dialect, dsn, opts)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Database connected successfully (%s)", dialect)
+	return db, nil
+}
+
+// connect opens dsn with retries and applies the pool options.
+func connect(ctx context.Context, dialect, dsn string, opts Options) (*gorm.DB, error) {
+	deadline := time.Now().Add(opts.ConnectTimeout)
+	var db *gorm.DB
 	var err error
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
+	for attempt := 1; ; attempt++ {
+		db, err = open(dialect, dsn)
+		if err == nil {
+			break
+		}
+		wait := connectBackoff.delay(attempt)
+		if !IsTransient(err) || time.Now().Add(wait).After(deadline) {
+			return nil, err
+		}
+		slog.Warn("Database unavailable, retrying", "attempt", attempt, "retry_in", wait.Round(time.Millisecond).String(), "error", err)
+		metrics.DBRetries.WithLabelValues("connect").Inc()
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	if dialect == SQLite {
+		// SQLite allows one writer at a time, and every connection to
+		// :memory: gets its own database, which is lost when it closes.
+		// Keep a single connection open for the life of the pool.
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetMaxIdleConns(1)
+		sqlDB.SetConnMaxLifetime(0)
+		sqlDB.SetConnMaxIdleTime(0)
+	} else {
+		if opts.MaxOpenConns > 0 {
+			sqlDB.SetMaxOpenConns(opts.MaxOpenConns)
+		}
+		if opts.MaxIdleConns > 0 {
+			sqlDB.SetMaxIdleConns(opts.MaxIdleConns)
+		}
+		if opts.ConnMaxLifetime > 0 {
+			sqlDB.SetConnMaxLifetime(opts.ConnMaxLifetime)
+		}
+		if opts.ConnMaxIdleTime > 0 {
+			sqlDB.SetConnMaxIdleTime(opts.ConnMaxIdleTime)
+		}
+	}
+	return db, nil
+}
+
+// open makes one connection attempt, releasing the pool if the initial ping fails.
+func open(dialect, dsn string) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+	switch dialect {
+	case SQLite:
+		dialector = sqlite.Open(sqliteDSN(strings.TrimPrefix(dsn, sqlitePrefix)))
+	default:
+		dialector = postgres.Open(dsn)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent), // Reduce verbosity
 	})
-
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		if db != nil {
+			if sqlDB, dbErr := db.DB(); dbErr == nil {
+				sqlDB.Close()
+			}
+		}
+		return nil, err
+	}
+	return db, nil
+}
+
+// sqliteDSN enables foreign keys and waits for locks instead of failing,
+// unless the path already sets its own parameters.
+func sqliteDSN(path string) string {
+	path = strings.TrimPrefix(path, "//")
+	if strings.Contains(path, "?") {
+		return path
 	}
+	params := url.Values{"_foreign_keys": {"on"}, "_busy_timeout": {"5000"}}
+	return path + "?" + params.Encode()
+}
 
-	log.Println("Database connected successfully (using existing schema)")
-}
\ No newline at end of file
+// Close closes the connection pool, and the read replica's if one is in use,
+// once in-flight queries have finished.
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return errors.Join(closeReplicas(db, sqlDB), sqlDB.Close())
+}
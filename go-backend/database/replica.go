@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"everflown-logistics/metrics"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// Reporting names the resolver group for heavy reads such as dashboard
+// stats and exports. Queries opt in with
+// db.Clauses(dbresolver.Use(database.Reporting)); all other queries, and
+// every statement inside a transaction, run on the primary.
+const Reporting = "reporting"
+
+// resolverName is the name dbresolver registers itself under.
+const resolverName = "gorm:db_resolver"
+
+// UseReplica connects to the read replica at dsn, with the same retries and
+// pool options as the primary, and routes Reporting queries on db to it.
+func UseReplica(ctx context.Context, db *gorm.DB, dsn string, opts Options) error {
+	dialect, err := Dialect(dsn)
+	if err != nil {
+		return err
+	}
+	if primary := db.Dialector.Name(); dialect != primary {
+		return fmt.Errorf("replica is %s but the primary is %s", dialect, primary)
+	}
+
+	replica, err := connect(ctx, dialect, dsn, opts)
+	if err != nil {
+		return err
+	}
+	pool, err := replica.DB()
+	if err != nil {
+		return err
+	}
+
+	// Hand dbresolver the pool that was just configured rather than a DSN,
+	// so it does not open a second one.
+	var dialector gorm.Dialector
+	if dialect == SQLite {
+		dialector = &sqlite.Dialector{Conn: pool}
+	} else {
+		dialector = postgres.New(postgres.Config{Conn: pool})
+	}
+	resolver := dbresolver.Register(dbresolver.Config{Replicas: []gorm.Dialector{dialector}}, Reporting)
+	if err := db.Use(resolver); err != nil {
+		pool.Close()
+		return err
+	}
+	if err := metrics.RegisterDBStats(pool, dialect+"_replica"); err != nil {
+		return err
+	}
+
+	log.Printf("Read replica connected (%s); reporting queries use it", dialect)
+	return nil
+}
+
+// HasReplica reports whether db routes Reporting queries to a replica.
+func HasReplica(db *gorm.DB) bool {
+	_, ok := db.Config.Plugins[resolverName]
+	return ok
+}
+
+// closeReplicas closes every pool registered with dbresolver except primary.
+func closeReplicas(db *gorm.DB, primary *sql.DB) error {
+	resolver, ok := db.Config.Plugins[resolverName].(*dbresolver.DBResolver)
+	if !ok {
+		return nil
+	}
+	var errs []error
+	resolver.Call(func(pool gorm.ConnPool) error {
+		if replica, ok := pool.(*sql.DB); ok && replica != primary {
+			errs = append(errs, replica.Close())
+		}
+		return nil
+	})
+	return errors.Join(errs...)
+}
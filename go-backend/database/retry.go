@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"everflown-logistics/metrics"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+)
+
+// RetryPolicy retries operations that fail with a transient error, waiting
+// with exponential backoff and jitter between attempts.
+type RetryPolicy struct {
+	// Attempts is the total number of tries; below 2 disables retries.
+	Attempts int
+	// Backoff is the delay before the first retry. It doubles after each
+	// retry, up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultReadRetry is applied to idempotent repository reads.
+var DefaultReadRetry = RetryPolicy{Attempts: 3, Backoff: 50 * time.Millisecond, MaxBackoff: time.Second}
+
+// connectBackoff paces connection attempts at startup.
+var connectBackoff = RetryPolicy{Backoff: 500 * time.Millisecond, MaxBackoff: 10 * time.Second}
+
+// Do calls fn until it succeeds, fails with a permanent error, runs out of
+// attempts or ctx is done. Only use it for operations that are safe to repeat.
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.Attempts || !IsTransient(err) {
+			return err
+		}
+		metrics.DBRetries.WithLabelValues("query").Inc()
+		if err := sleep(ctx, p.delay(attempt)); err != nil {
+			return err
+		}
+	}
+}
+
+// delay returns the wait before the given retry (1 for the first), drawn
+// from the upper half of the exponential backoff so retries spread out.
+func (p RetryPolicy) delay(retry int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// transientPostgresCodes are SQLSTATEs worth retrying: the server is
+// starting, shutting down or out of connections, or the statement lost a
+// serialization conflict.
+var transientPostgresCodes = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"53300": true, // too_many_connections
+	"57P01": true, // admin_shutdown
+	"57P02": true, // crash_shutdown
+	"57P03": true, // cannot_connect_now
+}
+
+// IsTransient reports whether err is likely to go away if the operation is
+// retried: lost or refused connections, a busy SQLite file, or Postgres
+// errors such as a server that is still starting. Cancellation, bad
+// credentials and query errors are permanent.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return strings.HasPrefix(pgErr.Code, "08") || transientPostgresCodes[pgErr.Code]
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.As(err, &netErr) ||
+		pgconn.SafeToRetry(err)
+}
@@ -0,0 +1,191 @@
+// Package dates provides the calendar dates and instants stored on records.
+// Both parse leniently, because the Node.js backend kept them as free-form
+// text.
+package dates
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+	// Embed the zone database so appointment time zones resolve in
+	// containers without /usr/share/zoneinfo.
+	_ "time/tzdata"
+)
+
+// ISO is the canonical date layout, used for JSON and storage.
+const ISO = "2006-01-02"
+
+// layouts are tried in order by Parse. Layouts without a UTC offset come last
+// so an explicit offset always wins.
+var layouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00", // mattn/go-sqlite3
+	"2006-01-02 15:04:05-07",              // Postgres text output
+	time.RFC1123Z,
+	time.RFC1123,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04",
+	ISO,
+	"2006/01/02",
+	"01/02/2006 15:04",
+	"01/02/2006",
+	"1/2/2006",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"2 Jan 2006",
+}
+
+// parse tries each layout in turn. zoned reports whether the text carried a
+// UTC offset or zone; if not, the result is a wall-clock time in UTC.
+func parse(s string) (t time.Time, zoned bool, err error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, strings.Contains(layout, "07") || strings.Contains(layout, "MST"), nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("unrecognized date %q", s)
+}
+
+// Date is a calendar day with no time zone, such as a pickup or due date. It
+// is stored as DATE and written to JSON as "2006-01-02". The zero value means
+// no date and is stored as NULL.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the day t falls on in its own location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// Today returns the current date in loc.
+func Today(loc *time.Location) Date {
+	return DateOf(time.Now().In(loc))
+}
+
+// ParseDate reads a date in any of the common layouts. A timestamp is
+// reduced to the day it names in its own offset, not in UTC.
+func ParseDate(s string) (Date, error) {
+	t, _, err := parse(s)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+// MustParseDate is ParseDate for literals known to be valid. It panics on error.
+func MustParseDate(s string) Date {
+	d, err := ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// In returns midnight at the start of d in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns the date n days after d; n may be negative.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// Compare returns -1, 0 or +1 as d is before, equal to or after other.
+func (d Date) Compare(other Date) int {
+	return d.In(time.UTC).Compare(other.In(time.UTC))
+}
+
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+// Format lays out d using the time package's reference layout.
+func (d Date) Format(layout string) string {
+	return d.In(time.UTC).Format(layout)
+}
+
+func (d Date) String() string {
+	return d.Format(ISO)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON accepts any layout ParseDate does. null and "" leave the
+// zero date.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" || s == `""` {
+		*d = Date{}
+		return nil
+	}
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return fmt.Errorf("date must be a string, got %s", s)
+	}
+	parsed, err := ParseDate(s[1 : len(s)-1])
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Scan reads a DATE column, or text left behind by older versions.
+func (d *Date) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		*d = DateOf(v)
+	case string:
+		return d.scanString(v)
+	case []byte:
+		return d.scanString(string(v))
+	case nil:
+		*d = Date{}
+	default:
+		return fmt.Errorf("dates: cannot scan %T into Date", src)
+	}
+	return nil
+}
+
+func (d *Date) scanString(s string) error {
+	if strings.TrimSpace(s) == "" {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Value writes the ISO date, which sorts correctly even where it is stored as
+// text.
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.String(), nil
+}
@@ -0,0 +1,163 @@
+package dates
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Timestamp is an instant, such as a pickup or delivery appointment. It is
+// stored as TIMESTAMPTZ and written to JSON as RFC 3339.
+//
+// Input without a UTC offset ("2025-07-01T08:00") is a wall-clock time whose
+// zone isn't known yet; In places it in the appointment's time zone. Until
+// then it is treated as UTC.
+type Timestamp struct {
+	t     time.Time
+	local bool
+}
+
+// At returns the timestamp for t.
+func At(t time.Time) Timestamp {
+	return Timestamp{t: t}
+}
+
+// Now returns the current time, truncated to the microsecond precision the
+// databases keep.
+func Now() Timestamp {
+	return At(time.Now().Truncate(time.Microsecond))
+}
+
+// ParseTimestamp reads an instant in any of the layouts ParseDate accepts.
+func ParseTimestamp(s string) (Timestamp, error) {
+	t, zoned, err := parse(s)
+	if err != nil {
+		return Timestamp{}, err
+	}
+	return Timestamp{t: t, local: !zoned}, nil
+}
+
+// MustParseTimestamp is ParseTimestamp for literals known to be valid. It
+// panics on error.
+func MustParseTimestamp(s string) Timestamp {
+	ts, err := ParseTimestamp(s)
+	if err != nil {
+		panic(err)
+	}
+	return ts
+}
+
+// Time returns the instant.
+func (ts Timestamp) Time() time.Time {
+	return ts.t
+}
+
+func (ts Timestamp) IsZero() bool {
+	return ts.t.IsZero()
+}
+
+// IsLocal reports whether the timestamp was given without a UTC offset and
+// has not been placed in a time zone yet.
+func (ts Timestamp) IsLocal() bool {
+	return ts.local
+}
+
+// In places a local timestamp's wall-clock time in loc, and converts any
+// other timestamp to loc without changing the instant.
+func (ts Timestamp) In(loc *time.Location) Timestamp {
+	if ts.local {
+		y, mo, d := ts.t.Date()
+		h, mi, s := ts.t.Clock()
+		return At(time.Date(y, mo, d, h, mi, s, ts.t.Nanosecond(), loc))
+	}
+	return At(ts.t.In(loc))
+}
+
+func (ts Timestamp) Before(other Timestamp) bool {
+	return ts.t.Before(other.t)
+}
+
+func (ts Timestamp) After(other Timestamp) bool {
+	return ts.t.After(other.t)
+}
+
+// Sub returns the time from other to ts.
+func (ts Timestamp) Sub(other Timestamp) time.Duration {
+	return ts.t.Sub(other.t)
+}
+
+// Format lays out the timestamp in its own location.
+func (ts Timestamp) Format(layout string) string {
+	return ts.t.Format(layout)
+}
+
+func (ts Timestamp) String() string {
+	return ts.t.Format(time.RFC3339)
+}
+
+func (ts Timestamp) MarshalJSON() ([]byte, error) {
+	if ts.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + ts.t.Format(time.RFC3339Nano) + `"`), nil
+}
+
+// UnmarshalJSON accepts any layout ParseTimestamp does. null and "" leave the
+// zero timestamp.
+func (ts *Timestamp) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" || s == `""` {
+		*ts = Timestamp{}
+		return nil
+	}
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return fmt.Errorf("timestamp must be a string, got %s", s)
+	}
+	parsed, err := ParseTimestamp(s[1 : len(s)-1])
+	if err != nil {
+		return err
+	}
+	*ts = parsed
+	return nil
+}
+
+// Scan reads a TIMESTAMPTZ column, or text left behind by older versions.
+// Stored text without an offset is taken as UTC.
+func (ts *Timestamp) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		*ts = At(v.UTC())
+	case string:
+		return ts.scanString(v)
+	case []byte:
+		return ts.scanString(string(v))
+	case nil:
+		*ts = Timestamp{}
+	default:
+		return fmt.Errorf("dates: cannot scan %T into Timestamp", src)
+	}
+	return nil
+}
+
+func (ts *Timestamp) scanString(s string) error {
+	if strings.TrimSpace(s) == "" {
+		*ts = Timestamp{}
+		return nil
+	}
+	parsed, err := ParseTimestamp(s)
+	if err != nil {
+		return err
+	}
+	*ts = At(parsed.t.UTC())
+	return nil
+}
+
+// Value stores the instant in UTC. A local timestamp that was never placed in
+// a time zone is stored as if it were UTC.
+func (ts Timestamp) Value() (driver.Value, error) {
+	if ts.IsZero() {
+		return nil, nil
+	}
+	return ts.t.UTC(), nil
+}
@@ -0,0 +1,194 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"everflown-logistics/metrics"
+	"everflown-logistics/repository"
+)
+
+const (
+	// batchSize is how many events a subscriber is handed per claim.
+	batchSize = 100
+	// lease is how long a dispatcher holds a subscriber's cursor while
+	// delivering a batch; handlers must finish well within it.
+	lease = time.Minute
+	// maxBackoff caps the wait before retrying a failed delivery.
+	maxBackoff = 5 * time.Minute
+)
+
+// Handler handles one event. Events may be delivered more than once, so
+// handlers must be idempotent, for example by keying on the event ID.
+type Handler func(ctx context.Context, event Event) error
+
+// Options configures a Dispatcher.
+type Options struct {
+	// PollInterval is how often the outbox is checked for new events.
+	PollInterval time.Duration
+	// MaxAttempts is how many times delivery of an event to a subscriber is
+	// tried before it is moved to the dead letters.
+	MaxAttempts int
+}
+
+type subscriber struct {
+	name   string
+	types  map[string]bool
+	handle Handler
+}
+
+// Dispatcher delivers the events in the outbox to subscribers, in order and
+// at least once. Each subscriber's handling of each event is recorded, so one
+// failing subscriber doesn't hold up the others, and an event whose
+// transaction commits after later ones is still delivered, just late.
+// Several instances may run against the same database; a lease makes sure
+// only one of them delivers to a given subscriber at a time.
+type Dispatcher struct {
+	outbox      repository.OutboxRepository
+	opts        Options
+	owner       string
+	subscribers []subscriber
+}
+
+// NewDispatcher returns a Dispatcher reading from outbox.
+func NewDispatcher(outbox repository.OutboxRepository, opts Options) *Dispatcher {
+	return &Dispatcher{outbox: outbox, opts: opts, owner: newOwner()}
+}
+
+// Subscribe registers handle under name, which identifies the subscriber's
+// place in the outbox across restarts, for the given event types, or for all
+// of them if none are given. Subscribe before calling Run.
+func (d *Dispatcher) Subscribe(name string, handle Handler, types ...string) {
+	s := subscriber{name: name, handle: handle}
+	if len(types) > 0 {
+		s.types = make(map[string]bool, len(types))
+		for _, t := range types {
+			s.types[t] = true
+		}
+	}
+	d.subscribers = append(d.subscribers, s)
+}
+
+// Run delivers events every PollInterval until ctx is cancelled. A new
+// subscriber starts with the events still in the outbox.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	if err := d.Register(ctx); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := d.Deliver(ctx); err != nil && ctx.Err() == nil {
+			slog.Warn("Event delivery failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Register creates the cursors of subscribers seen for the first time.
+func (d *Dispatcher) Register(ctx context.Context) error {
+	for _, s := range d.subscribers {
+		if err := d.outbox.Register(ctx, s.name); err != nil {
+			return fmt.Errorf("registering event subscriber %s: %w", s.name, err)
+		}
+	}
+	return nil
+}
+
+// Deliver hands every subscriber the events it hasn't handled yet.
+func (d *Dispatcher) Deliver(ctx context.Context) error {
+	var errs []error
+	for _, s := range d.subscribers {
+		if err := d.deliver(ctx, s); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deliver works through a subscriber's pending events in batches while it
+// holds the subscriber's lease. A failed event is retried before any later
+// one; after MaxAttempts it becomes a dead letter and the subscriber moves on.
+func (d *Dispatcher) deliver(ctx context.Context, s subscriber) error {
+	for {
+		now := time.Now()
+		ok, err := d.outbox.Claim(ctx, s.name, d.owner, now, now.Add(lease))
+		if err != nil || !ok {
+			return err
+		}
+		batch, err := d.outbox.Pending(ctx, s.name, batchSize)
+		if err != nil {
+			return errors.Join(err, d.outbox.Release(ctx, s.name, d.owner, time.Time{}))
+		}
+
+		var handled []uint
+		var retryAt time.Time
+		var errs []error
+		for _, e := range batch {
+			event := fromOutbox(e.OutboxEvent)
+			if s.types != nil && !s.types[event.Type] {
+				handled = append(handled, event.ID)
+				continue
+			}
+			err := s.handle(ctx, event)
+			if err == nil {
+				metrics.EventDeliveries.WithLabelValues(s.name, event.Type, "delivered").Inc()
+				handled = append(handled, event.ID)
+				continue
+			}
+			attempts := e.Attempts + 1
+			if attempts < d.opts.MaxAttempts {
+				metrics.EventDeliveries.WithLabelValues(s.name, event.Type, "failed").Inc()
+				slog.Warn("Event handler failed, will retry", "subscriber", s.name, "event_id", event.ID,
+					"type", event.Type, "attempt", attempts, "error", err)
+				errs = append(errs, d.outbox.Failed(ctx, s.name, event.ID, attempts, err.Error()))
+				retryAt = time.Now().Add(backoff(attempts))
+				break
+			}
+			metrics.EventDeliveries.WithLabelValues(s.name, event.Type, "dead_letter").Inc()
+			slog.Error("Event handler failed, moved to dead letters", "subscriber", s.name, "event_id", event.ID,
+				"type", event.Type, "attempts", attempts, "error", err)
+			if err := d.outbox.GiveUp(ctx, s.name, event.ID, attempts, err.Error(), time.Now()); err != nil {
+				// Stop here so the event is neither lost nor overtaken.
+				errs = append(errs, err)
+				break
+			}
+		}
+
+		errs = append(errs, d.outbox.Handled(ctx, s.name, time.Now(), handled...))
+		errs = append(errs, d.outbox.Release(ctx, s.name, d.owner, retryAt))
+		if err := errors.Join(errs...); err != nil {
+			return err
+		}
+		if !retryAt.IsZero() || len(batch) < batchSize || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// backoff doubles the wait after each failed attempt, from one second up to
+// maxBackoff.
+func backoff(attempts int) time.Duration {
+	if attempts > 16 {
+		return maxBackoff
+	}
+	return min(time.Second<<(attempts-1), maxBackoff)
+}
+
+// newOwner identifies this process as a lease holder.
+func newOwner() string {
+	host, _ := os.Hostname()
+	var b [4]byte
+	_, _ = rand.Read(b[:])
+	return host + "-" + hex.EncodeToString(b[:])
+}
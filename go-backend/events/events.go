@@ -0,0 +1,96 @@
+// Package events records domain events in the transactional outbox and
+// delivers them to subscribers, such as notifications, webhooks and
+// automation, once the change that raised them has committed.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"everflown-logistics/audit"
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+)
+
+// Event types.
+const (
+	OrderCreated          = "order.created"
+	DispatchStatusChanged = "dispatch.status_changed"
+	QuoteAccepted         = "quote.accepted"
+	InvoicePaid           = "invoice.paid"
+)
+
+// Types lists every event type.
+var Types = []string{OrderCreated, DispatchStatusChanged, QuoteAccepted, InvoicePaid}
+
+// OrderCreatedData is the payload of OrderCreated.
+type OrderCreatedData struct {
+	Order models.Order `json:"order"`
+}
+
+// DispatchStatusChangedData is the payload of DispatchStatusChanged.
+type DispatchStatusChangedData struct {
+	Dispatch models.Dispatch `json:"dispatch"`
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+}
+
+// QuoteAcceptedData is the payload of QuoteAccepted.
+type QuoteAcceptedData struct {
+	Quote models.Quote `json:"quote"`
+}
+
+// InvoicePaidData is the payload of InvoicePaid.
+type InvoicePaidData struct {
+	Invoice models.Invoice `json:"invoice"`
+}
+
+// Event is a domain event as delivered to subscribers. IDs increase in the
+// order the events were recorded.
+type Event struct {
+	ID         uint            `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	UserID     string          `json:"userId"`
+	RequestID  string          `json:"requestId,omitempty"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Decode unmarshals the event's data into v, one of the *Data types.
+func (e Event) Decode(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+func fromOutbox(e models.OutboxEvent) Event {
+	return Event{
+		ID:         e.ID,
+		Type:       e.Type,
+		OccurredAt: e.OccurredAt,
+		UserID:     e.UserID,
+		RequestID:  e.RequestID,
+		Data:       json.RawMessage(e.Payload),
+	}
+}
+
+// Record writes an event of eventType with data to outbox, attributed to the
+// actor in ctx. Pass the outbox of repositories bound to the transaction
+// making the change. A nil outbox, as in repositories built on test fakes,
+// records nothing.
+func Record(ctx context.Context, outbox repository.OutboxRepository, eventType string, data interface{}) error {
+	if outbox == nil {
+		return nil
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	actor := audit.ActorFrom(ctx)
+	return outbox.Append(ctx, models.OutboxEvent{
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		UserID:     actor.UserID,
+		RequestID:  actor.RequestID,
+		Payload:    string(payload),
+	})
+}
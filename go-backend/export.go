@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"everflown-logistics/archive"
+	"gorm.io/gorm"
+)
+
+// runExport executes the export subcommand, writing the archive to the file
+// named by -o and a summary to out.
+func runExport(ctx context.Context, db *gorm.DB, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(out)
+	path := flags.String("o", archive.FileName(time.Now()), "file to write the archive to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+
+	f, err := os.OpenFile(*path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	manifest, err := archive.Export(ctx, db, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(*path)
+		return err
+	}
+	fmt.Fprintf(out, "Exported %s to %s\n", manifest, *path)
+	return nil
+}
+
+// runImport executes the import subcommand, restoring the archive named in
+// args into an empty database.
+func runImport(ctx context.Context, db *gorm.DB, args []string, out io.Writer) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: import FILE")
+	}
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	manifest, err := archive.Import(ctx, db, f, info.Size())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Imported %s (exported %s)\n", manifest, manifest.CreatedAt.Format(time.RFC3339))
+	return nil
+}
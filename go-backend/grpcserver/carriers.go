@@ -0,0 +1,69 @@
+package grpcserver
+
+import (
+	"context"
+
+	"everflown-logistics/proto/logisticspb"
+	"everflown-logistics/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type carrierServer struct {
+	logisticspb.UnimplementedCarrierServiceServer
+	carriers *services.CarrierService
+}
+
+func (s *carrierServer) ListCarriers(ctx context.Context, _ *logisticspb.ListCarriersRequest) (*logisticspb.ListCarriersResponse, error) {
+	carriers, err := s.carriers.List(ctx)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	resp := &logisticspb.ListCarriersResponse{Carriers: make([]*logisticspb.Carrier, 0, len(carriers))}
+	for i := range carriers {
+		resp.Carriers = append(resp.Carriers, carrierToProto(&carriers[i]))
+	}
+	return resp, nil
+}
+
+func (s *carrierServer) GetCarrier(ctx context.Context, req *logisticspb.IDRequest) (*logisticspb.Carrier, error) {
+	carrier, err := s.carriers.Get(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return carrierToProto(carrier), nil
+}
+
+func (s *carrierServer) CreateCarrier(ctx context.Context, req *logisticspb.Carrier) (*logisticspb.Carrier, error) {
+	carrier, err := carrierFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+	carrier.ID = 0
+	if err := s.carriers.Create(ctx, carrier); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return carrierToProto(carrier), nil
+}
+
+func (s *carrierServer) UpdateCarrier(ctx context.Context, req *logisticspb.UpdateCarrierRequest) (*logisticspb.Carrier, error) {
+	if req.GetCarrier() == nil {
+		return nil, status.Error(codes.InvalidArgument, "carrier is required")
+	}
+	changes, err := carrierFromProto(req.GetCarrier())
+	if err != nil {
+		return nil, err
+	}
+	carrier, err := s.carriers.Update(ctx, uint(req.GetId()), changes)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return carrierToProto(carrier), nil
+}
+
+func (s *carrierServer) DeleteCarrier(ctx context.Context, req *logisticspb.IDRequest) (*logisticspb.DeleteResponse, error) {
+	if err := s.carriers.Delete(ctx, uint(req.GetId())); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &logisticspb.DeleteResponse{}, nil
+}
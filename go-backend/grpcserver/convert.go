@@ -0,0 +1,266 @@
+package grpcserver
+
+import (
+	"time"
+
+	"everflown-logistics/dates"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"everflown-logistics/proto/logisticspb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func orderToProto(o *models.Order) *logisticspb.Order {
+	return &logisticspb.Order{
+		Id:                  uint32(o.ID),
+		OrderNumber:         o.OrderNumber,
+		CustomerId:          uint32Ptr(o.CustomerID),
+		CustomerName:        o.CustomerName,
+		LeadId:              uint32Ptr(o.LeadID),
+		OriginCompany:       o.OriginCompany,
+		OriginAddress:       o.OriginAddress,
+		OriginCity:          o.OriginCity,
+		OriginState:         o.OriginState,
+		OriginZipCode:       o.OriginZipCode,
+		DestinationCompany:  o.DestinationCompany,
+		DestinationAddress:  o.DestinationAddress,
+		DestinationCity:     o.DestinationCity,
+		DestinationState:    o.DestinationState,
+		DestinationZipCode:  o.DestinationZipCode,
+		PickupDate:          o.PickupDate.String(),
+		DeliveryDate:        dateProto(o.DeliveryDate),
+		EquipmentType:       o.EquipmentType,
+		Weight:              o.Weight,
+		Commodity:           o.Commodity,
+		CustomerRate:        o.CustomerRate.Float64(),
+		CustomerRateCents:   o.CustomerRate.Cents(),
+		Currency:            o.Currency,
+		Status:              o.Status,
+		SpecialInstructions: o.SpecialInstructions,
+		CreatedAt:           timestampProto(o.CreatedAt),
+		UpdatedAt:           timestampProto(o.UpdatedAt),
+	}
+}
+
+func orderFromProto(p *logisticspb.Order) (*models.Order, error) {
+	var parse protoParser
+	order := &models.Order{
+		ID:                  uint(p.GetId()),
+		OrderNumber:         p.GetOrderNumber(),
+		CustomerID:          uintPtr(p.CustomerId),
+		CustomerName:        p.CustomerName,
+		LeadID:              uintPtr(p.LeadId),
+		OriginCompany:       p.OriginCompany,
+		OriginAddress:       p.GetOriginAddress(),
+		OriginCity:          p.GetOriginCity(),
+		OriginState:         p.GetOriginState(),
+		OriginZipCode:       p.GetOriginZipCode(),
+		DestinationCompany:  p.DestinationCompany,
+		DestinationAddress:  p.GetDestinationAddress(),
+		DestinationCity:     p.GetDestinationCity(),
+		DestinationState:    p.GetDestinationState(),
+		DestinationZipCode:  p.GetDestinationZipCode(),
+		PickupDate:          parse.date("pickup_date", p.GetPickupDate()),
+		DeliveryDate:        parse.optionalDate("delivery_date", p.DeliveryDate),
+		EquipmentType:       p.GetEquipmentType(),
+		Weight:              p.Weight,
+		Commodity:           p.Commodity,
+		CustomerRate:        amountFromProto(p.GetCustomerRateCents(), p.GetCustomerRate()),
+		Currency:            p.GetCurrency(),
+		Status:              p.GetStatus(),
+		SpecialInstructions: p.SpecialInstructions,
+	}
+	return order, parse.err
+}
+
+func dispatchToProto(d *models.Dispatch) *logisticspb.Dispatch {
+	return &logisticspb.Dispatch{
+		Id:                     uint32(d.ID),
+		OrderId:                uint32(d.OrderID),
+		CarrierId:              uint32(d.CarrierID),
+		CarrierRate:            d.CarrierRate.Float64(),
+		CarrierRateCents:       d.CarrierRate.Cents(),
+		Currency:               d.Currency,
+		DriverName:             d.DriverName,
+		DriverPhone:            d.DriverPhone,
+		TruckNumber:            d.TruckNumber,
+		TrailerNumber:          d.TrailerNumber,
+		Status:                 d.Status,
+		RateConfirmationSent:   d.RateConfirmationSent,
+		RateConfirmationSigned: d.RateConfirmationSigned,
+		EstimatedPickupTime:    timestampString(d.EstimatedPickupTime),
+		ActualPickupTime:       timestampString(d.ActualPickupTime),
+		EstimatedDeliveryTime:  timestampString(d.EstimatedDeliveryTime),
+		ActualDeliveryTime:     timestampString(d.ActualDeliveryTime),
+		PickupTimeZone:         d.PickupTimeZone,
+		DeliveryTimeZone:       d.DeliveryTimeZone,
+		Notes:                  d.Notes,
+		CreatedAt:              timestampProto(d.CreatedAt),
+		UpdatedAt:              timestampProto(d.UpdatedAt),
+	}
+}
+
+func dispatchFromProto(p *logisticspb.Dispatch) (*models.Dispatch, error) {
+	var parse protoParser
+	dispatch := &models.Dispatch{
+		ID:                     uint(p.GetId()),
+		OrderID:                uint(p.GetOrderId()),
+		CarrierID:              uint(p.GetCarrierId()),
+		CarrierRate:            amountFromProto(p.GetCarrierRateCents(), p.GetCarrierRate()),
+		Currency:               p.GetCurrency(),
+		DriverName:             p.DriverName,
+		DriverPhone:            p.DriverPhone,
+		TruckNumber:            p.TruckNumber,
+		TrailerNumber:          p.TrailerNumber,
+		Status:                 p.GetStatus(),
+		RateConfirmationSent:   p.GetRateConfirmationSent(),
+		RateConfirmationSigned: p.GetRateConfirmationSigned(),
+		EstimatedPickupTime:    parse.timestamp("estimated_pickup_time", p.EstimatedPickupTime),
+		ActualPickupTime:       parse.timestamp("actual_pickup_time", p.ActualPickupTime),
+		EstimatedDeliveryTime:  parse.timestamp("estimated_delivery_time", p.EstimatedDeliveryTime),
+		ActualDeliveryTime:     parse.timestamp("actual_delivery_time", p.ActualDeliveryTime),
+		PickupTimeZone:         p.PickupTimeZone,
+		DeliveryTimeZone:       p.DeliveryTimeZone,
+		Notes:                  p.Notes,
+	}
+	return dispatch, parse.err
+}
+
+func carrierToProto(c *models.Carrier) *logisticspb.Carrier {
+	return &logisticspb.Carrier{
+		Id:                uint32(c.ID),
+		CompanyName:       c.CompanyName,
+		ContactPerson:     c.ContactPerson,
+		Email:             c.Email,
+		Phone:             c.Phone,
+		Address:           c.Address,
+		City:              c.City,
+		State:             c.State,
+		ZipCode:           c.ZipCode,
+		McNumber:          c.MCNumber,
+		DotNumber:         c.DOTNumber,
+		InsuranceExpiry:   dateProto(c.InsuranceExpiry),
+		W9OnFile:          c.W9OnFile,
+		PerformanceRating: c.PerformanceRating,
+		PreferredLanes:    c.PreferredLanes,
+		EquipmentTypes:    c.EquipmentTypes,
+		Notes:             c.Notes,
+		IsActive:          c.IsActive,
+		CreatedAt:         timestampProto(c.CreatedAt),
+		UpdatedAt:         timestampProto(c.UpdatedAt),
+	}
+}
+
+func carrierFromProto(p *logisticspb.Carrier) (*models.Carrier, error) {
+	var parse protoParser
+	carrier := &models.Carrier{
+		ID:                uint(p.GetId()),
+		CompanyName:       p.GetCompanyName(),
+		ContactPerson:     p.GetContactPerson(),
+		Email:             p.GetEmail(),
+		Phone:             p.GetPhone(),
+		Address:           p.Address,
+		City:              p.City,
+		State:             p.State,
+		ZipCode:           p.ZipCode,
+		MCNumber:          p.McNumber,
+		DOTNumber:         p.DotNumber,
+		InsuranceExpiry:   parse.optionalDate("insurance_expiry", p.InsuranceExpiry),
+		W9OnFile:          p.GetW9OnFile(),
+		PerformanceRating: p.GetPerformanceRating(),
+		PreferredLanes:    p.PreferredLanes,
+		EquipmentTypes:    p.EquipmentTypes,
+		Notes:             p.Notes,
+		IsActive:          p.GetIsActive(),
+	}
+	return carrier, parse.err
+}
+
+func uint32Ptr(v *uint) *uint32 {
+	if v == nil {
+		return nil
+	}
+	u := uint32(*v)
+	return &u
+}
+
+func uintPtr(v *uint32) *uint {
+	if v == nil {
+		return nil
+	}
+	u := uint(*v)
+	return &u
+}
+
+// amountFromProto prefers the exact cents field, falling back to the
+// deprecated floating-point one for older clients.
+func amountFromProto(cents int64, legacy float64) money.Amount {
+	if cents != 0 {
+		return money.FromCents(cents)
+	}
+	return money.FromFloat(legacy)
+}
+
+// protoParser converts the string date fields of a message, keeping the
+// first malformed one as an InvalidArgument error.
+type protoParser struct {
+	err error
+}
+
+func (p *protoParser) date(field, value string) dates.Date {
+	if value == "" {
+		return dates.Date{}
+	}
+	d, err := dates.ParseDate(value)
+	p.fail(field, err)
+	return d
+}
+
+func (p *protoParser) optionalDate(field string, value *string) *dates.Date {
+	if value == nil {
+		return nil
+	}
+	d := p.date(field, *value)
+	return &d
+}
+
+func (p *protoParser) timestamp(field string, value *string) *dates.Timestamp {
+	if value == nil || *value == "" {
+		return nil
+	}
+	ts, err := dates.ParseTimestamp(*value)
+	p.fail(field, err)
+	return &ts
+}
+
+func (p *protoParser) fail(field string, err error) {
+	if err != nil && p.err == nil {
+		p.err = status.Errorf(codes.InvalidArgument, "%s: %v", field, err)
+	}
+}
+
+func dateProto(d *dates.Date) *string {
+	if d == nil || d.IsZero() {
+		return nil
+	}
+	s := d.String()
+	return &s
+}
+
+func timestampString(ts *dates.Timestamp) *string {
+	if ts == nil || ts.IsZero() {
+		return nil
+	}
+	s := ts.String()
+	return &s
+}
+
+// timestampProto leaves unset times out of the message rather than sending the zero time.
+func timestampProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,126 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"everflown-logistics/proto/logisticspb"
+	"everflown-logistics/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type dispatchServer struct {
+	logisticspb.UnimplementedDispatchServiceServer
+	dispatches *services.DispatchService
+}
+
+func (s *dispatchServer) ListDispatches(ctx context.Context, _ *logisticspb.ListDispatchesRequest) (*logisticspb.ListDispatchesResponse, error) {
+	dispatches, err := s.dispatches.List(ctx)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	resp := &logisticspb.ListDispatchesResponse{Dispatches: make([]*logisticspb.Dispatch, 0, len(dispatches))}
+	for i := range dispatches {
+		resp.Dispatches = append(resp.Dispatches, dispatchToProto(&dispatches[i]))
+	}
+	return resp, nil
+}
+
+func (s *dispatchServer) GetDispatch(ctx context.Context, req *logisticspb.IDRequest) (*logisticspb.Dispatch, error) {
+	dispatch, err := s.dispatches.Get(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return dispatchToProto(dispatch), nil
+}
+
+func (s *dispatchServer) CreateDispatch(ctx context.Context, req *logisticspb.Dispatch) (*logisticspb.Dispatch, error) {
+	dispatch, err := dispatchFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+	dispatch.ID = 0
+	if err := s.dispatches.Create(ctx, dispatch); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return dispatchToProto(dispatch), nil
+}
+
+func (s *dispatchServer) UpdateDispatch(ctx context.Context, req *logisticspb.UpdateDispatchRequest) (*logisticspb.Dispatch, error) {
+	if req.GetDispatch() == nil {
+		return nil, status.Error(codes.InvalidArgument, "dispatch is required")
+	}
+	changes, err := dispatchFromProto(req.GetDispatch())
+	if err != nil {
+		return nil, err
+	}
+	dispatch, err := s.dispatches.Update(ctx, uint(req.GetId()), changes)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return dispatchToProto(dispatch), nil
+}
+
+func (s *dispatchServer) DeleteDispatch(ctx context.Context, req *logisticspb.IDRequest) (*logisticspb.DeleteResponse, error) {
+	if err := s.dispatches.Delete(ctx, uint(req.GetId())); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &logisticspb.DeleteResponse{}, nil
+}
+
+// WatchDispatches streams dispatch changes until the client disconnects. Like
+// the change stream, callers need a role that may see dispatches, only see
+// changes made for their tenant, and those acting for a customer only see
+// its dispatches. If the caller falls too far
+// behind the stream ends with Unavailable, and it should watch again.
+func (s *dispatchServer) WatchDispatches(req *logisticspb.WatchDispatchesRequest, stream logisticspb.DispatchService_WatchDispatchesServer) error {
+	ctx := stream.Context()
+	claims := claimsFrom(ctx)
+	var customerID *uint
+	if claims.CustomerID != "" {
+		id, _ := strconv.ParseUint(claims.CustomerID, 10, 64)
+		customer := uint(id)
+		customerID = &customer
+	}
+	if !services.StreamFilterFor(claims.Role, claims.TenantID, customerID).Entities["dispatches"] {
+		return status.Error(codes.PermissionDenied, "role may not watch dispatches")
+	}
+	events, err := s.dispatches.Watch(ctx, claims.TenantID, customerID)
+	if errors.Is(err, services.ErrStreamClosed) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil {
+		return toStatus(ctx, err)
+	}
+	for event := range events {
+		if req.OrderId != nil && uint(req.GetOrderId()) != event.Dispatch.OrderID {
+			continue
+		}
+		msg := &logisticspb.DispatchEvent{
+			Type:     dispatchEventType(event.Type),
+			Dispatch: dispatchToProto(&event.Dispatch),
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return status.Error(codes.Unavailable, "watch fell behind or the server is shutting down")
+}
+
+func dispatchEventType(t string) logisticspb.DispatchEvent_Type {
+	switch t {
+	case services.DispatchCreated:
+		return logisticspb.DispatchEvent_TYPE_CREATED
+	case services.DispatchUpdated:
+		return logisticspb.DispatchEvent_TYPE_UPDATED
+	case services.DispatchDeleted:
+		return logisticspb.DispatchEvent_TYPE_DELETED
+	default:
+		return logisticspb.DispatchEvent_TYPE_UNSPECIFIED
+	}
+}
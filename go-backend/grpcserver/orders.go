@@ -0,0 +1,69 @@
+package grpcserver
+
+import (
+	"context"
+
+	"everflown-logistics/proto/logisticspb"
+	"everflown-logistics/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type orderServer struct {
+	logisticspb.UnimplementedOrderServiceServer
+	orders *services.OrderService
+}
+
+func (s *orderServer) ListOrders(ctx context.Context, _ *logisticspb.ListOrdersRequest) (*logisticspb.ListOrdersResponse, error) {
+	orders, err := s.orders.List(ctx)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	resp := &logisticspb.ListOrdersResponse{Orders: make([]*logisticspb.Order, 0, len(orders))}
+	for i := range orders {
+		resp.Orders = append(resp.Orders, orderToProto(&orders[i]))
+	}
+	return resp, nil
+}
+
+func (s *orderServer) GetOrder(ctx context.Context, req *logisticspb.IDRequest) (*logisticspb.Order, error) {
+	order, err := s.orders.Get(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return orderToProto(order), nil
+}
+
+func (s *orderServer) CreateOrder(ctx context.Context, req *logisticspb.Order) (*logisticspb.Order, error) {
+	order, err := orderFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+	order.ID = 0
+	if err := s.orders.Create(ctx, order); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return orderToProto(order), nil
+}
+
+func (s *orderServer) UpdateOrder(ctx context.Context, req *logisticspb.UpdateOrderRequest) (*logisticspb.Order, error) {
+	if req.GetOrder() == nil {
+		return nil, status.Error(codes.InvalidArgument, "order is required")
+	}
+	changes, err := orderFromProto(req.GetOrder())
+	if err != nil {
+		return nil, err
+	}
+	order, err := s.orders.Update(ctx, uint(req.GetId()), changes)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return orderToProto(order), nil
+}
+
+func (s *orderServer) DeleteOrder(ctx context.Context, req *logisticspb.IDRequest) (*logisticspb.DeleteResponse, error) {
+	if err := s.orders.Delete(ctx, uint(req.GetId())); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &logisticspb.DeleteResponse{}, nil
+}
@@ -0,0 +1,131 @@
+// Package grpcserver exposes the order, dispatch and carrier services over gRPC.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"everflown-logistics/audit"
+	"everflown-logistics/logging"
+	"everflown-logistics/middleware"
+	"everflown-logistics/proto/logisticspb"
+	"everflown-logistics/services"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// New returns a gRPC server with all logistics services registered. Callers
+// are identified like HTTP requests (see middleware.Identity): by the
+// x-user-id, x-user-role, x-tenant-id and x-customer-id metadata, which must
+// be signed in x-identity-signature when identitySecret is set.
+func New(svc *services.Services, identitySecret string, opts ...grpc.ServerOption) *grpc.Server {
+	identity := identityInterceptor{secret: identitySecret}
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(identity.unary),
+		grpc.ChainStreamInterceptor(identity.stream),
+	}, opts...)
+	s := grpc.NewServer(opts...)
+	logisticspb.RegisterOrderServiceServer(s, &orderServer{orders: svc.Orders})
+	logisticspb.RegisterDispatchServiceServer(s, &dispatchServer{dispatches: svc.Dispatches})
+	logisticspb.RegisterCarrierServiceServer(s, &carrierServer{carriers: svc.Carriers})
+	return s
+}
+
+type claimsKey struct{}
+
+// claimsFrom returns the caller's verified identity.
+func claimsFrom(ctx context.Context) middleware.Claims {
+	claims, _ := ctx.Value(claimsKey{}).(middleware.Claims)
+	return claims
+}
+
+// identityInterceptor checks the caller's identity and attributes the call's
+// changes in the audit log to its user and tenant, and to the request named
+// by x-request-id.
+type identityInterceptor struct {
+	secret string
+}
+
+func (i identityInterceptor) unary(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := i.identify(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (i identityInterceptor) stream(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := i.identify(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, identifiedStream{ServerStream: ss, ctx: ctx})
+}
+
+func (i identityInterceptor) identify(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if v := md.Get(key); len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	claims := middleware.Claims{
+		UserID:     first("x-user-id"),
+		Role:       first("x-user-role"),
+		TenantID:   first("x-tenant-id"),
+		CustomerID: first("x-customer-id"),
+	}
+	signature := first("x-identity-signature")
+	if i.secret != "" && (claims != middleware.Claims{} || signature != "") {
+		if err := middleware.VerifyIdentity(i.secret, signature, claims, time.Now()); err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+	}
+	if claims.CustomerID != "" {
+		if id, err := strconv.ParseUint(claims.CustomerID, 10, 64); err != nil || id == 0 {
+			return nil, status.Error(codes.Unauthenticated, "x-customer-id must be a customer ID")
+		}
+	}
+	actor := audit.Actor{UserID: claims.UserID, TenantID: claims.TenantID, RequestID: first("x-request-id")}
+	ctx = context.WithValue(ctx, claimsKey{}, claims)
+	return audit.WithActor(ctx, actor), nil
+}
+
+// identifiedStream is a server stream carrying the caller's identity in its
+// context.
+type identifiedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s identifiedStream) Context() context.Context {
+	return s.ctx
+}
+
+// toStatus converts a service error into a gRPC status error. Unexpected
+// errors are logged and reported as Internal without their details.
+func toStatus(ctx context.Context, err error) error {
+	var validationErr *services.ValidationError
+	var dependentsErr *services.DependentsError
+	switch {
+	case errors.As(err, &validationErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, services.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, services.ErrInvalidReference):
+		return status.Error(codes.InvalidArgument, services.ErrInvalidReference.Error())
+	case errors.Is(err, services.ErrConflict):
+		return status.Error(codes.AlreadyExists, services.ErrConflict.Error())
+	case errors.As(err, &dependentsErr):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		method, _ := grpc.Method(ctx)
+		logging.FromContext(ctx).Error("internal error", "error", err, "method", method)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
@@ -1,51 +1,78 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
-	"everflown-logistics/database"
+	"everflown-logistics/dates"
+	"everflown-logistics/logging"
+	"everflown-logistics/middleware"
 	"everflown-logistics/models"
+	"everflown-logistics/services"
 	"github.com/gin-gonic/gin"
 )
 
-// Helper function to create string pointers
-func stringPtr(s string) *string {
-	return &s
+// Handler serves the REST API. It only translates between HTTP and the
+// services, which own the business rules; tests can build the services on
+// fake repositories or an isolated database.
+type Handler struct {
+	svc *services.Services
 }
 
-// Simple hash for demo - in production use bcrypt
-func hashPassword(password string) (string, error) {
-	return password + "_hashed", nil
+// New returns a Handler using svc, which it shares with the gRPC server.
+func New(svc *services.Services) *Handler {
+	return &Handler{svc: svc}
 }
 
-// Simple verify for demo - in production use bcrypt
-func verifyPassword(password, hashedPassword string) bool {
-	return password+"_hashed" == hashedPassword
+// parseID reads the :id path parameter, writing a 400 response when it is not a valid ID.
+func parseID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return 0, false
+	}
+	return uint(id), true
 }
 
-// Auth handlers
-func GetCurrentUser(c *gin.Context) {
-	// Create default admin user for authentication
-	hashedPassword, _ := hashPassword("admin")
-
-	user := models.User{
-		ID:        "admin-1750604677654",
-		Username:  "admin",
-		Password:  hashedPassword,
-		Email:     "admin@everflown.com",
-		FirstName: stringPtr("System"),
-		LastName:  stringPtr("Administrator"),
-		Role:      "admin",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+// respondServiceError writes a 400 for validation failures and references to
+// missing records, a 404 for missing records, a 409 listing the dependents
+// that block a delete and a 500 with message otherwise.
+func respondServiceError(c *gin.Context, err error, message string) {
+	var validationErr *services.ValidationError
+	var dependentsErr *services.DependentsError
+	switch {
+	case errors.As(err, &validationErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error(), "field": validationErr.Field})
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, services.ErrInvalidReference):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A referenced record does not exist"})
+	case errors.Is(err, services.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": "A record with the same number already exists"})
+	case errors.As(err, &dependentsErr):
+		c.JSON(http.StatusConflict, gin.H{"error": dependentsErr.Error(), "dependents": dependentsErr.Dependents})
+	default:
+		serverError(c, err, message)
 	}
+}
 
-	c.JSON(http.StatusOK, user)
+// serverError logs err against the request and responds with a 500 carrying message.
+func serverError(c *gin.Context, err error, message string) {
+	logging.FromContext(c.Request.Context()).Error(message, "error", err, "route", c.FullPath(), "id", c.Param("id"))
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+}
+
+// Auth handlers
+func (h *Handler) GetCurrentUser(c *gin.Context) {
+	c.JSON(http.StatusOK, services.DefaultAdmin())
 }
 
-func Login(c *gin.Context) {
+func (h *Handler) Login(c *gin.Context) {
 	var loginData struct {
 		Username string `json:"username" binding:"required"`
 		Password string `json:"password" binding:"required"`
@@ -56,39 +83,20 @@ func Login(c *gin.Context) {
 		return
 	}
 
-	// For admin login
-	if loginData.Username == "admin" && loginData.Password == "admin" {
-		hashedPassword, _ := hashPassword("admin")
-		user := models.User{
-			ID:        "admin-1750604677654",
-			Username:  "admin",
-			Password:  hashedPassword,
-			Email:     "admin@everflown.com",
-			FirstName: stringPtr("System"),
-			LastName:  stringPtr("Administrator"),
-			Role:      "admin",
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		}
-		c.JSON(http.StatusOK, user)
-		return
-	}
-
-	var user models.User
-	if err := database.DB.Where("username = ?", loginData.Username).First(&user).Error; err != nil {
+	user, err := h.svc.Users.Login(c.Request.Context(), loginData.Username, loginData.Password)
+	if errors.Is(err, services.ErrInvalidCredentials) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
 		return
 	}
-
-	if !verifyPassword(loginData.Password, user.Password) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+	if err != nil {
+		serverError(c, err, "Failed to log in")
 		return
 	}
 
 	c.JSON(http.StatusOK, user)
 }
 
-func Register(c *gin.Context) {
+func (h *Handler) Register(c *gin.Context) {
 	var userData struct {
 		Username  string  `json:"username" binding:"required"`
 		Password  string  `json:"password" binding:"required"`
@@ -103,82 +111,50 @@ func Register(c *gin.Context) {
 		return
 	}
 
-	// Check if user already exists
-	var existingUser models.User
-	if err := database.DB.Where("username = ?", userData.Username).First(&existingUser).Error; err == nil {
+	user, err := h.svc.Users.Register(c.Request.Context(), services.RegisterInput(userData))
+	if errors.Is(err, services.ErrUsernameTaken) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Username already exists"})
 		return
 	}
-
-	hashedPassword, err := hashPassword(userData.Password)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
-		return
-	}
-
-	if userData.Role == "" {
-		userData.Role = "user"
-	}
-
-	user := models.User{
-		ID:        "user-" + strconv.FormatInt(time.Now().UnixNano()/1000, 10),
-		Username:  userData.Username,
-		Password:  hashedPassword,
-		Email:     userData.Email,
-		FirstName: userData.FirstName,
-		LastName:  userData.LastName,
-		Role:      userData.Role,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-
-	if err := database.DB.Create(&user).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
+		respondServiceError(c, err, "Failed to create user")
 		return
 	}
 
 	c.JSON(http.StatusCreated, user)
 }
 
-func Logout(c *gin.Context) {
+func (h *Handler) Logout(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
 }
 
-func GetUsers(c *gin.Context) {
-	var users []models.User
-	if err := database.DB.Find(&users).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
+func (h *Handler) GetUsers(c *gin.Context) {
+	users, err := h.svc.Users.List(c.Request.Context())
+	if err != nil {
+		serverError(c, err, "Failed to fetch users")
 		return
 	}
 	c.JSON(http.StatusOK, users)
 }
 
-func UpdateUser(c *gin.Context) {
-	id := c.Param("id")
+func (h *Handler) UpdateUser(c *gin.Context) {
 	var userData models.User
-
 	if err := c.ShouldBindJSON(&userData); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	userData.UpdatedAt = time.Now()
-
-	if err := database.DB.Model(&models.User{}).Where("id = ?", id).Updates(&userData).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user"})
+	user, err := h.svc.Users.Update(c.Request.Context(), c.Param("id"), &userData)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update user")
 		return
 	}
-
-	var updatedUser models.User
-	database.DB.Where("id = ?", id).First(&updatedUser)
-	c.JSON(http.StatusOK, updatedUser)
+	c.JSON(http.StatusOK, user)
 }
 
-func DeleteUser(c *gin.Context) {
-	id := c.Param("id")
-
-	if err := database.DB.Delete(&models.User{}, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete user"})
+func (h *Handler) DeleteUser(c *gin.Context) {
+	if err := h.svc.Users.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		respondServiceError(c, err, "Failed to delete user")
 		return
 	}
 
@@ -186,297 +162,996 @@ func DeleteUser(c *gin.Context) {
 }
 
 // Dashboard handlers
-func GetDashboardStats(c *gin.Context) {
-	var activeOrders, inTransit, pendingQuotes, totalRevenue int64
-	var avgDeliveryTime float64
-
-	database.DB.Model(&models.Order{}).Where("status IN ?", []string{"dispatched", "in_transit", "needs_truck"}).Count(&activeOrders)
-	database.DB.Model(&models.Order{}).Where("status = ?", "in_transit").Count(&inTransit)
-	database.DB.Model(&models.Quote{}).Where("status = ?", "pending").Count(&pendingQuotes)
-	database.DB.Model(&models.Invoice{}).Where("type = ? AND status = ?", "customer", "paid").Select("COALESCE(SUM(CAST(amount AS DECIMAL)), 0)").Scan(&totalRevenue)
-
-	// Calculate average delivery time (mock calculation)
-	avgDeliveryTime = 3.2
-
-	stats := gin.H{
-		"activeOrders":    activeOrders,
-		"inTransit":       inTransit,
-		"pendingQuotes":   pendingQuotes,
-		"totalRevenue":    totalRevenue,
-		"avgDeliveryTime": avgDeliveryTime,
+func (h *Handler) GetDashboardStats(c *gin.Context) {
+	stats, err := h.svc.Dashboard.Stats(c.Request.Context())
+	if err != nil {
+		serverError(c, err, "Failed to fetch dashboard stats")
+		return
 	}
-
 	c.JSON(http.StatusOK, stats)
 }
 
 // Lead handlers
-func GetLeads(c *gin.Context) {
-	var leads []models.Lead
-	if err := database.DB.Find(&leads).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch leads"})
+func (h *Handler) GetLeads(c *gin.Context) {
+	leads, err := h.svc.Leads.List(c.Request.Context())
+	if err != nil {
+		serverError(c, err, "Failed to fetch leads")
 		return
 	}
 	c.JSON(http.StatusOK, leads)
 }
 
-func CreateLead(c *gin.Context) {
+func (h *Handler) CreateLead(c *gin.Context) {
 	var lead models.Lead
 	if err := c.ShouldBindJSON(&lead); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	lead.CreatedAt = time.Now()
-	lead.UpdatedAt = time.Now()
-
-	if err := database.DB.Create(&lead).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create lead"})
+	if err := h.svc.Leads.Create(c.Request.Context(), &lead); err != nil {
+		respondServiceError(c, err, "Failed to create lead")
 		return
 	}
 
 	c.JSON(http.StatusCreated, lead)
 }
 
-func UpdateLead(c *gin.Context) {
-	id := c.Param("id")
-	var leadData models.Lead
+func (h *Handler) UpdateLead(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
 
+	var leadData models.Lead
 	if err := c.ShouldBindJSON(&leadData); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	leadData.UpdatedAt = time.Now()
-
-	if err := database.DB.Model(&models.Lead{}).Where("id = ?", id).Updates(&leadData).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update lead"})
+	lead, err := h.svc.Leads.Update(c.Request.Context(), id, &leadData)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update lead")
 		return
 	}
 
-	var updatedLead models.Lead
-	database.DB.Where("id = ?", id).First(&updatedLead)
-	c.JSON(http.StatusOK, updatedLead)
+	c.JSON(http.StatusOK, lead)
 }
 
-func DeleteLead(c *gin.Context) {
-	id := c.Param("id")
+func (h *Handler) DeleteLead(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
 
-	if err := database.DB.Delete(&models.Lead{}, id).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete lead"})
+	if err := h.svc.Leads.Delete(c.Request.Context(), id); err != nil {
+		respondServiceError(c, err, "Failed to delete lead")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Lead deleted successfully"})
 }
 
-// Basic stub handlers for other entities
-func GetCustomers(c *gin.Context) {
-	var customers []models.Customer
-	database.DB.Find(&customers)
+func (h *Handler) GetCustomers(c *gin.Context) {
+	customers, err := h.svc.Customers.List(c.Request.Context())
+	if err != nil {
+		serverError(c, err, "Failed to fetch customers")
+		return
+	}
 	c.JSON(http.StatusOK, customers)
 }
 
-func CreateCustomer(c *gin.Context) {
+func (h *Handler) CreateCustomer(c *gin.Context) {
 	var customer models.Customer
 	if err := c.ShouldBindJSON(&customer); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	database.DB.Create(&customer)
+
+	if err := h.svc.Customers.Create(c.Request.Context(), &customer); err != nil {
+		respondServiceError(c, err, "Failed to create customer")
+		return
+	}
+
 	c.JSON(http.StatusCreated, customer)
 }
 
-func UpdateCustomer(c *gin.Context) {
-	id := c.Param("id")
-	var customer models.Customer
-	c.ShouldBindJSON(&customer)
-	database.DB.Model(&customer).Where("id = ?", id).Updates(&customer)
+func (h *Handler) UpdateCustomer(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var customerData models.Customer
+	if err := c.ShouldBindJSON(&customerData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customer, err := h.svc.Customers.Update(c.Request.Context(), id, &customerData)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update customer")
+		return
+	}
+
 	c.JSON(http.StatusOK, customer)
 }
 
-func DeleteCustomer(c *gin.Context) {
-	id := c.Param("id")
-	database.DB.Delete(&models.Customer{}, id)
+func (h *Handler) DeleteCustomer(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Customers.Delete(c.Request.Context(), id); err != nil {
+		respondServiceError(c, err, "Failed to delete customer")
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Customer deleted"})
 }
 
-func GetCarriers(c *gin.Context) {
-	var carriers []models.Carrier
-	database.DB.Find(&carriers)
+func (h *Handler) GetCarriers(c *gin.Context) {
+	carriers, err := h.svc.Carriers.List(c.Request.Context())
+	if err != nil {
+		serverError(c, err, "Failed to fetch carriers")
+		return
+	}
 	c.JSON(http.StatusOK, carriers)
 }
 
-func CreateCarrier(c *gin.Context) {
+func (h *Handler) CreateCarrier(c *gin.Context) {
 	var carrier models.Carrier
-	c.ShouldBindJSON(&carrier)
-	database.DB.Create(&carrier)
+	if err := c.ShouldBindJSON(&carrier); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.Carriers.Create(c.Request.Context(), &carrier); err != nil {
+		respondServiceError(c, err, "Failed to create carrier")
+		return
+	}
+
 	c.JSON(http.StatusCreated, carrier)
 }
 
-func UpdateCarrier(c *gin.Context) {
-	id := c.Param("id")
-	var carrier models.Carrier
-	c.ShouldBindJSON(&carrier)
-	database.DB.Model(&carrier).Where("id = ?", id).Updates(&carrier)
+func (h *Handler) UpdateCarrier(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var carrierData models.Carrier
+	if err := c.ShouldBindJSON(&carrierData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	carrier, err := h.svc.Carriers.Update(c.Request.Context(), id, &carrierData)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update carrier")
+		return
+	}
+
 	c.JSON(http.StatusOK, carrier)
 }
 
-func DeleteCarrier(c *gin.Context) {
-	id := c.Param("id")
-	database.DB.Delete(&models.Carrier{}, id)
+func (h *Handler) DeleteCarrier(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Carriers.Delete(c.Request.Context(), id); err != nil {
+		respondServiceError(c, err, "Failed to delete carrier")
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Carrier deleted"})
 }
 
-func GetOrders(c *gin.Context) {
-	var orders []models.Order
-	database.DB.Find(&orders)
+func (h *Handler) GetOrders(c *gin.Context) {
+	orders, err := h.svc.Orders.List(c.Request.Context())
+	if err != nil {
+		serverError(c, err, "Failed to fetch orders")
+		return
+	}
 	c.JSON(http.StatusOK, orders)
 }
 
-func CreateOrder(c *gin.Context) {
+func (h *Handler) CreateOrder(c *gin.Context) {
 	var order models.Order
-	c.ShouldBindJSON(&order)
-	database.DB.Create(&order)
+	if err := c.ShouldBindJSON(&order); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.Orders.Create(c.Request.Context(), &order); err != nil {
+		respondServiceError(c, err, "Failed to create order")
+		return
+	}
+
 	c.JSON(http.StatusCreated, order)
 }
 
-func UpdateOrder(c *gin.Context) {
-	id := c.Param("id")
-	var order models.Order
-	c.ShouldBindJSON(&order)
-	database.DB.Model(&order).Where("id = ?", id).Updates(&order)
+func (h *Handler) UpdateOrder(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var orderData models.Order
+	if err := c.ShouldBindJSON(&orderData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.svc.Orders.Update(c.Request.Context(), id, &orderData)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update order")
+		return
+	}
+
 	c.JSON(http.StatusOK, order)
 }
 
-func DeleteOrder(c *gin.Context) {
-	id := c.Param("id")
-	database.DB.Delete(&models.Order{}, id)
+func (h *Handler) DeleteOrder(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Orders.Delete(c.Request.Context(), id); err != nil {
+		respondServiceError(c, err, "Failed to delete order")
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Order deleted"})
 }
 
-func GetDispatches(c *gin.Context) {
-	var dispatches []models.Dispatch
-	database.DB.Find(&dispatches)
+func (h *Handler) GetDispatches(c *gin.Context) {
+	dispatches, err := h.svc.Dispatches.List(c.Request.Context())
+	if err != nil {
+		serverError(c, err, "Failed to fetch dispatches")
+		return
+	}
 	c.JSON(http.StatusOK, dispatches)
 }
 
-func CreateDispatch(c *gin.Context) {
+func (h *Handler) CreateDispatch(c *gin.Context) {
 	var dispatch models.Dispatch
-	c.ShouldBindJSON(&dispatch)
-	database.DB.Create(&dispatch)
+	if err := c.ShouldBindJSON(&dispatch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.Dispatches.Create(c.Request.Context(), &dispatch); err != nil {
+		respondServiceError(c, err, "Failed to create dispatch")
+		return
+	}
+
 	c.JSON(http.StatusCreated, dispatch)
 }
 
-func UpdateDispatch(c *gin.Context) {
-	id := c.Param("id")
-	var dispatch models.Dispatch
-	c.ShouldBindJSON(&dispatch)
-	database.DB.Model(&dispatch).Where("id = ?", id).Updates(&dispatch)
+func (h *Handler) UpdateDispatch(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var dispatchData models.Dispatch
+	if err := c.ShouldBindJSON(&dispatchData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dispatch, err := h.svc.Dispatches.Update(c.Request.Context(), id, &dispatchData)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update dispatch")
+		return
+	}
+
 	c.JSON(http.StatusOK, dispatch)
 }
 
-func DeleteDispatch(c *gin.Context) {
-	id := c.Param("id")
-	database.DB.Delete(&models.Dispatch{}, id)
+func (h *Handler) DeleteDispatch(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Dispatches.Delete(c.Request.Context(), id); err != nil {
+		respondServiceError(c, err, "Failed to delete dispatch")
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Dispatch deleted"})
 }
 
-func GetQuotes(c *gin.Context) {
-	var quotes []models.Quote
-	database.DB.Find(&quotes)
+func (h *Handler) GetQuotes(c *gin.Context) {
+	quotes, err := h.svc.Quotes.List(c.Request.Context())
+	if err != nil {
+		serverError(c, err, "Failed to fetch quotes")
+		return
+	}
 	c.JSON(http.StatusOK, quotes)
 }
 
-func CreateQuote(c *gin.Context) {
+func (h *Handler) CreateQuote(c *gin.Context) {
 	var quote models.Quote
-	c.ShouldBindJSON(&quote)
-	database.DB.Create(&quote)
+	if err := c.ShouldBindJSON(&quote); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.Quotes.Create(c.Request.Context(), &quote); err != nil {
+		respondServiceError(c, err, "Failed to create quote")
+		return
+	}
+
 	c.JSON(http.StatusCreated, quote)
 }
 
-func UpdateQuote(c *gin.Context) {
-	id := c.Param("id")
-	var quote models.Quote
-	c.ShouldBindJSON(&quote)
-	database.DB.Model(&quote).Where("id = ?", id).Updates(&quote)
+func (h *Handler) UpdateQuote(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var quoteData models.Quote
+	if err := c.ShouldBindJSON(&quoteData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	quote, err := h.svc.Quotes.Update(c.Request.Context(), id, &quoteData)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update quote")
+		return
+	}
+
 	c.JSON(http.StatusOK, quote)
 }
 
-func DeleteQuote(c *gin.Context) {
-	id := c.Param("id")
-	database.DB.Delete(&models.Quote{}, id)
+func (h *Handler) DeleteQuote(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Quotes.Delete(c.Request.Context(), id); err != nil {
+		respondServiceError(c, err, "Failed to delete quote")
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Quote deleted"})
 }
 
-func GetInvoices(c *gin.Context) {
-	var invoices []models.Invoice
-	database.DB.Find(&invoices)
+func (h *Handler) GetInvoices(c *gin.Context) {
+	invoices, err := h.svc.Invoices.List(c.Request.Context())
+	if err != nil {
+		serverError(c, err, "Failed to fetch invoices")
+		return
+	}
 	c.JSON(http.StatusOK, invoices)
 }
 
-func CreateInvoice(c *gin.Context) {
+func (h *Handler) CreateInvoice(c *gin.Context) {
 	var invoice models.Invoice
-	c.ShouldBindJSON(&invoice)
-	database.DB.Create(&invoice)
+	if err := c.ShouldBindJSON(&invoice); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.Invoices.Create(c.Request.Context(), &invoice); err != nil {
+		respondServiceError(c, err, "Failed to create invoice")
+		return
+	}
+
 	c.JSON(http.StatusCreated, invoice)
 }
 
-func UpdateInvoice(c *gin.Context) {
-	id := c.Param("id")
-	var invoice models.Invoice
-	c.ShouldBindJSON(&invoice)
-	database.DB.Model(&invoice).Where("id = ?", id).Updates(&invoice)
+func (h *Handler) UpdateInvoice(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var invoiceData models.Invoice
+	if err := c.ShouldBindJSON(&invoiceData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	invoice, err := h.svc.Invoices.Update(c.Request.Context(), id, &invoiceData)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update invoice")
+		return
+	}
+
 	c.JSON(http.StatusOK, invoice)
 }
 
-func DeleteInvoice(c *gin.Context) {
-	id := c.Param("id")
-	database.DB.Delete(&models.Invoice{}, id)
+func (h *Handler) DeleteInvoice(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Invoices.Delete(c.Request.Context(), id); err != nil {
+		respondServiceError(c, err, "Failed to delete invoice")
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Invoice deleted"})
 }
 
-func GetFollowUps(c *gin.Context) {
-	var followUps []models.FollowUp
-	database.DB.Find(&followUps)
+func (h *Handler) GetFollowUps(c *gin.Context) {
+	followUps, err := h.svc.FollowUps.List(c.Request.Context())
+	if err != nil {
+		serverError(c, err, "Failed to fetch follow-ups")
+		return
+	}
 	c.JSON(http.StatusOK, followUps)
 }
 
-func GetUrgentFollowUps(c *gin.Context) {
-	var followUps []models.FollowUp
-	database.DB.Where("priority = ? AND completed = ?", "high", false).Find(&followUps)
+func (h *Handler) GetUrgentFollowUps(c *gin.Context) {
+	followUps, err := h.svc.FollowUps.ListUrgent(c.Request.Context())
+	if err != nil {
+		serverError(c, err, "Failed to fetch follow-ups")
+		return
+	}
 	c.JSON(http.StatusOK, followUps)
 }
 
-func CreateFollowUp(c *gin.Context) {
+func (h *Handler) CreateFollowUp(c *gin.Context) {
 	var followUp models.FollowUp
-	c.ShouldBindJSON(&followUp)
-	database.DB.Create(&followUp)
+	if err := c.ShouldBindJSON(&followUp); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.FollowUps.Create(c.Request.Context(), &followUp); err != nil {
+		respondServiceError(c, err, "Failed to create follow-up")
+		return
+	}
+
 	c.JSON(http.StatusCreated, followUp)
 }
 
-func UpdateFollowUp(c *gin.Context) {
-	id := c.Param("id")
-	var followUp models.FollowUp
-	c.ShouldBindJSON(&followUp)
-	database.DB.Model(&followUp).Where("id = ?", id).Updates(&followUp)
+func (h *Handler) UpdateFollowUp(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var followUpData models.FollowUp
+	if err := c.ShouldBindJSON(&followUpData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	followUp, err := h.svc.FollowUps.Update(c.Request.Context(), id, &followUpData)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update follow-up")
+		return
+	}
+
 	c.JSON(http.StatusOK, followUp)
 }
 
-func DeleteFollowUp(c *gin.Context) {
-	id := c.Param("id")
-	database.DB.Delete(&models.FollowUp{}, id)
+func (h *Handler) DeleteFollowUp(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.FollowUps.Delete(c.Request.Context(), id); err != nil {
+		respondServiceError(c, err, "Failed to delete follow-up")
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "FollowUp deleted"})
 }
 
-// Placeholder PDF handlers
-func GenerateQuotePDF(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "PDF generation not implemented yet"})
+// History handlers
+func (h *Handler) GetOrderHistory(c *gin.Context) {
+	h.respondHistory(c, "orders", "Failed to fetch order history")
+}
+
+func (h *Handler) GetDispatchHistory(c *gin.Context) {
+	h.respondHistory(c, "dispatches", "Failed to fetch dispatch history")
+}
+
+func (h *Handler) GetQuoteHistory(c *gin.Context) {
+	h.respondHistory(c, "quotes", "Failed to fetch quote history")
+}
+
+func (h *Handler) GetInvoiceHistory(c *gin.Context) {
+	h.respondHistory(c, "invoices", "Failed to fetch invoice history")
+}
+
+// respondHistory writes the change history of the record of table named by
+// the :id path parameter.
+func (h *Handler) respondHistory(c *gin.Context, table, message string) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.svc.Audit.History(c.Request.Context(), table, id)
+	if err != nil {
+		respondServiceError(c, err, message)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// Archive handlers
+func (h *Handler) GetArchivedOrders(c *gin.Context) {
+	orders, err := h.svc.Archive.List(c.Request.Context())
+	if err != nil {
+		serverError(c, err, "Failed to fetch archived orders")
+		return
+	}
+	c.JSON(http.StatusOK, orders)
+}
+
+func (h *Handler) GetArchivedOrder(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.svc.Archive.Get(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err, "Failed to fetch archived order")
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+func (h *Handler) RestoreArchivedOrder(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Archive.Restore(c.Request.Context(), id); err != nil {
+		respondServiceError(c, err, "Failed to restore order")
+		return
+	}
+
+	order, err := h.svc.Orders.Get(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err, "Failed to fetch restored order")
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+// GetAuditLog lists audit log entries, newest first, filtered by the entity,
+// entityId, userId, from and to (YYYY-MM-DD, inclusive) query parameters.
+// beforeId and limit page through the results.
+func (h *Handler) GetAuditLog(c *gin.Context) {
+	filter := services.AuditFilter{
+		Entity:   c.Query("entity"),
+		EntityID: c.Query("entityId"),
+		UserID:   c.Query("userId"),
+	}
+	for _, p := range []struct {
+		name string
+		date *dates.Date
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		if v := c.Query(p.name); v != "" {
+			d, err := dates.ParseDate(v)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": p.name + " must be a date (YYYY-MM-DD)", "field": p.name})
+				return
+			}
+			*p.date = d
+		}
+	}
+	if v := c.Query("beforeId"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "beforeId must be an ID", "field": "beforeId"})
+			return
+		}
+		filter.BeforeID = uint(id)
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive number", "field": "limit"})
+			return
+		}
+		filter.Limit = limit
+	}
+
+	entries, err := h.svc.Audit.List(c.Request.Context(), filter)
+	if err != nil {
+		respondServiceError(c, err, "Failed to fetch audit log")
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+// GetEventDeadLetters lists the domain events subscribers gave up on, newest
+// first. beforeId and limit page through them.
+func (h *Handler) GetEventDeadLetters(c *gin.Context) {
+	var beforeID uint
+	if v := c.Query("beforeId"); v != "" {
+		before, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "beforeId must be an ID", "field": "beforeId"})
+			return
+		}
+		beforeID = uint(before)
+	}
+	var limit int
+	if v := c.Query("limit"); v != "" {
+		var err error
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive number", "field": "limit"})
+			return
+		}
+	}
+
+	letters, err := h.svc.Events.DeadLetters(c.Request.Context(), beforeID, limit)
+	if err != nil {
+		respondServiceError(c, err, "Failed to fetch dead letters")
+		return
+	}
+	c.JSON(http.StatusOK, letters)
+}
+
+// RetryEventDeadLetter hands a dead letter's event to its subscriber again.
+func (h *Handler) RetryEventDeadLetter(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	letter, err := h.svc.Events.RetryDeadLetter(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err, "Failed to retry dead letter")
+		return
+	}
+	c.JSON(http.StatusAccepted, letter)
+}
+
+// Webhook handlers
+func (h *Handler) GetWebhooks(c *gin.Context) {
+	subscriptions, err := h.svc.Webhooks.List(c.Request.Context())
+	if err != nil {
+		serverError(c, err, "Failed to fetch webhooks")
+		return
+	}
+	c.JSON(http.StatusOK, subscriptions)
 }
 
-func GenerateInvoicePDF(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "PDF generation not implemented yet"})
+func (h *Handler) GetWebhook(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.svc.Webhooks.Get(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err, "Failed to fetch webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
 }
 
-func GetDispatchRateConfirmation(c *gin.Context) {
+// CreateWebhook adds a subscription. The response carries its signing
+// secret, which is not returned again.
+func (h *Handler) CreateWebhook(c *gin.Context) {
+	var subscription models.WebhookSubscription
+	if err := c.ShouldBindJSON(&subscription); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.Webhooks.Create(c.Request.Context(), &subscription); err != nil {
+		respondServiceError(c, err, "Failed to create webhook")
+		return
+	}
+
+	c.JSON(http.StatusCreated, subscription)
+}
+
+func (h *Handler) UpdateWebhook(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var subscriptionData models.WebhookSubscription
+	if err := c.ShouldBindJSON(&subscriptionData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	subscription, err := h.svc.Webhooks.Update(c.Request.Context(), id, &subscriptionData)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+func (h *Handler) DeleteWebhook(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Webhooks.Delete(c.Request.Context(), id); err != nil {
+		respondServiceError(c, err, "Failed to delete webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted"})
+}
+
+func (h *Handler) EnableWebhook(c *gin.Context) {
+	h.setWebhookActive(c, h.svc.Webhooks.Enable)
+}
+
+func (h *Handler) DisableWebhook(c *gin.Context) {
+	h.setWebhookActive(c, h.svc.Webhooks.Disable)
+}
+
+func (h *Handler) setWebhookActive(c *gin.Context, set func(context.Context, uint) (*models.WebhookSubscription, error)) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	subscription, err := set(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// GetWebhookDeliveries lists a subscription's deliveries, newest first.
+// beforeId and limit page through them.
+func (h *Handler) GetWebhookDeliveries(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+	var beforeID uint
+	if v := c.Query("beforeId"); v != "" {
+		before, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "beforeId must be an ID", "field": "beforeId"})
+			return
+		}
+		beforeID = uint(before)
+	}
+	var limit int
+	if v := c.Query("limit"); v != "" {
+		var err error
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive number", "field": "limit"})
+			return
+		}
+	}
+
+	deliveries, err := h.svc.Webhooks.Deliveries(c.Request.Context(), id, beforeID, limit)
+	if err != nil {
+		respondServiceError(c, err, "Failed to fetch webhook deliveries")
+		return
+	}
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// RedeliverWebhook queues a delivery to be sent again right away.
+func (h *Handler) RedeliverWebhook(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+	deliveryID, err := strconv.ParseUint(c.Param("deliveryId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	delivery, err := h.svc.Webhooks.Redeliver(c.Request.Context(), id, uint(deliveryID))
+	if err != nil {
+		respondServiceError(c, err, "Failed to redeliver webhook")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+// Stream returns the handler for the change stream, which pushes changes to
+// orders, dispatches and follow-ups as Server-Sent Events, limited to those
+// the user's role may see and made for the user's tenant and, for a user
+// acting for a customer, to the customer's records. Each event ID is a position in the stream; a client
+// reconnecting with Last-Event-ID, or the lastEventId query parameter, first
+// gets the changes it missed, and may get some it already had again. A
+// comment is sent every heartbeat so proxies keep the connection open.
+func (h *Handler) Stream(heartbeat time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var customerID *uint
+		if id, ok := c.Get(middleware.CustomerIDKey); ok {
+			customer := id.(uint)
+			customerID = &customer
+		}
+		filter := services.StreamFilterFor(c.GetString(middleware.UserRoleKey), c.GetString(middleware.TenantIDKey), customerID)
+		if len(filter.Entities) == 0 {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			return
+		}
+		resumeFrom := c.GetHeader("Last-Event-ID")
+		if resumeFrom == "" {
+			resumeFrom = c.Query("lastEventId")
+		}
+		var lastEventID uint
+		if resumeFrom != "" {
+			id, err := strconv.ParseUint(resumeFrom, 10, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Last-Event-ID must be an event ID", "field": "lastEventId"})
+				return
+			}
+			lastEventID = uint(id)
+		}
+
+		ctx := c.Request.Context()
+		var sub *services.StreamSubscription
+		var err error
+		if resumeFrom != "" {
+			sub, err = h.svc.Stream.Resume(ctx, filter, lastEventID)
+		} else {
+			sub, err = h.svc.Stream.Subscribe(ctx, filter)
+		}
+		if errors.Is(err, services.ErrStreamClosed) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Server is shutting down"})
+			return
+		}
+		if err != nil {
+			serverError(c, err, "Failed to open change stream")
+			return
+		}
+		defer h.svc.Stream.Unsubscribe(sub)
+
+		// The stream outlives the server's write timeout.
+		_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+
+		w := c.Writer
+		last := lastEventID
+		send := func(event services.StreamEvent) {
+			data, err := json.Marshal(event)
+			if err != nil {
+				logging.FromContext(ctx).Error("Failed to encode stream event", "error", err)
+				return
+			}
+			fmt.Fprintf(w, "id: %d\nevent: change\ndata: %s\n\n", event.Position, data)
+			last = event.Position
+		}
+
+		fmt.Fprint(w, "retry: 3000\n\n")
+		if sub.Reset {
+			// Too much was missed to replay; the client reloads and continues from here.
+			fmt.Fprintf(w, "id: %d\nevent: reset\ndata: {}\n\n", sub.Head)
+			last = sub.Head
+		}
+		for _, event := range sub.Backlog {
+			send(event)
+		}
+		// Give the client a place to resume from, even if nothing changes
+		// before it reconnects.
+		last = max(last, sub.Head)
+		fmt.Fprintf(w, "id: %d\nevent: ready\ndata: {}\n\n", last)
+		w.Flush()
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-sub.Events:
+				if !ok {
+					// Dropped for falling behind, or shutting down; the client
+					// reconnects and resumes.
+					return
+				}
+				send(event)
+			case <-ticker.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			}
+			w.Flush()
+		}
+	}
+}
+
+// PDF handlers
+func (h *Handler) GenerateQuotePDF(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	quote, err := h.svc.Quotes.GetWithParties(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err, "Failed to fetch quote")
+		return
+	}
+
+	// Quotes for existing customers have no lead; address them to the customer instead.
+	var lead models.Lead
+	switch {
+	case quote.Lead != nil:
+		lead = *quote.Lead
+	case quote.Customer != nil:
+		lead = models.Lead{
+			CompanyName:   quote.Customer.CompanyName,
+			ContactPerson: quote.Customer.ContactPerson,
+			Email:         quote.Customer.Email,
+			Phone:         quote.Customer.Phone,
+		}
+	}
+
+	pdf, err := h.svc.PDF.GenerateQuotePDF(c.Request.Context(), *quote, lead)
+	if err != nil {
+		serverError(c, err, "Failed to generate quote PDF")
+		return
+	}
+
+	c.Header("Content-Disposition", "inline; filename=quote-"+quote.QuoteNumber+".pdf")
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+func (h *Handler) GenerateInvoicePDF(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	invoice, err := h.svc.Invoices.GetWithParties(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err, "Failed to fetch invoice")
+		return
+	}
+
+	var order models.Order
+	if invoice.Order != nil {
+		order = *invoice.Order
+	}
+	var customer models.Customer
+	if invoice.Customer != nil {
+		customer = *invoice.Customer
+	}
+
+	pdf, err := h.svc.PDF.GenerateInvoicePDF(c.Request.Context(), *invoice, order, customer)
+	if err != nil {
+		serverError(c, err, "Failed to generate invoice PDF")
+		return
+	}
+
+	c.Header("Content-Disposition", "inline; filename=invoice-"+invoice.InvoiceNumber+".pdf")
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+func (h *Handler) GetDispatchRateConfirmation(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Rate confirmation not implemented yet"})
-}
\ No newline at end of file
+}
@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"everflown-logistics/migrations"
+	"gorm.io/gorm"
+)
+
+// DatabaseCheck pings the database connection pool.
+func DatabaseCheck(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// SchemaCheck verifies that the tables for models exist.
+func SchemaCheck(db *gorm.DB, models ...interface{}) CheckFunc {
+	return func(ctx context.Context) error {
+		migrator := db.WithContext(ctx).Migrator()
+		var missing []string
+		for _, model := range models {
+			if !migrator.HasTable(model) {
+				stmt := &gorm.Statement{DB: db}
+				if err := stmt.Parse(model); err != nil {
+					return err
+				}
+				missing = append(missing, stmt.Schema.Table)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("missing tables: %s", strings.Join(missing, ", "))
+		}
+		return nil
+	}
+}
+
+// MigrationCheck verifies that every migration known to migrator is applied
+// and none failed.
+func MigrationCheck(migrator *migrations.Migrator) CheckFunc {
+	return func(ctx context.Context) error {
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return status.Check()
+	}
+}
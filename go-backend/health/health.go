@@ -0,0 +1,149 @@
+// Package health implements the liveness and readiness probes.
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// CheckFunc reports whether a dependency is usable.
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult is the outcome of a single check.
+type CheckResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the readiness response body.
+type Report struct {
+	Status    string                 `json:"status"`
+	Checks    map[string]CheckResult `json:"checks"`
+	CheckedAt time.Time              `json:"checkedAt"`
+}
+
+type namedCheck struct {
+	name  string
+	check CheckFunc
+}
+
+// Checker runs registered dependency checks and tracks the status reported
+// by background workers. Results are cached for ttl so frequent probes from
+// several sources do not hammer the database.
+type Checker struct {
+	ttl     time.Duration
+	timeout time.Duration
+
+	// runMu serialises check runs; mu guards the fields below it.
+	runMu    sync.Mutex
+	mu       sync.Mutex
+	checks   []namedCheck
+	statuses map[string]error
+	cached   *Report
+}
+
+// NewChecker returns a Checker caching results for ttl.
+func NewChecker(ttl time.Duration) *Checker {
+	return &Checker{
+		ttl:      ttl,
+		timeout:  3 * time.Second,
+		statuses: make(map[string]error),
+	}
+}
+
+// Register adds a check that must pass for the service to be ready.
+func (c *Checker) Register(name string, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+	c.cached = nil
+}
+
+// SetStatus records the state of a background worker or other component
+// that reports its own health; a nil err means it is running normally.
+func (c *Checker) SetStatus(name string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.statuses[name] = err
+	c.cached = nil
+}
+
+// Check returns the readiness report, running the checks only if the cached
+// report has expired. Concurrent callers wait for a single run.
+func (c *Checker) Check(ctx context.Context) Report {
+	c.runMu.Lock()
+	defer c.runMu.Unlock()
+
+	c.mu.Lock()
+	if c.cached != nil && time.Since(c.cached.CheckedAt) < c.ttl {
+		report := *c.cached
+		c.mu.Unlock()
+		return report
+	}
+	checks := append([]namedCheck(nil), c.checks...)
+	statuses := make(map[string]error, len(c.statuses))
+	for name, err := range c.statuses {
+		statuses[name] = err
+	}
+	c.mu.Unlock()
+
+	results := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, nc := range checks {
+		wg.Add(1)
+		go func(i int, check CheckFunc) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+			results[i] = check(checkCtx)
+		}(i, nc.check)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]CheckResult), CheckedAt: time.Now()}
+	record := func(name string, err error) {
+		if err != nil {
+			report.Status = StatusDown
+			report.Checks[name] = CheckResult{Status: StatusDown, Error: err.Error()}
+			return
+		}
+		report.Checks[name] = CheckResult{Status: StatusUp}
+	}
+	for i, nc := range checks {
+		record(nc.name, results[i])
+	}
+	for name, err := range statuses {
+		record(name, err)
+	}
+
+	c.mu.Lock()
+	c.cached = &report
+	c.mu.Unlock()
+	return report
+}
+
+// LiveHandler reports that the process is running and able to serve
+// requests. It never touches dependencies, so a database outage does not get
+// the pod restarted.
+func LiveHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": StatusUp})
+}
+
+// ReadyHandler responds 200 when every check passes and 503 otherwise.
+func (c *Checker) ReadyHandler(ctx *gin.Context) {
+	report := c.Check(ctx.Request.Context())
+	status := http.StatusOK
+	if report.Status != StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	ctx.JSON(status, report)
+}
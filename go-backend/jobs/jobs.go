@@ -0,0 +1,98 @@
+// Package jobs runs the backend's background work and drains it on shutdown.
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"everflown-logistics/health"
+)
+
+// Runner starts background jobs under a shared context and waits for them
+// to finish when shut down. Each job's last result is reported to the
+// health checker under the job's name.
+type Runner struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	// runs is given to runs of Every jobs. It outlives ctx until the
+	// shutdown deadline, so a run in progress can finish.
+	runs    context.Context
+	abort   context.CancelFunc
+	wg      sync.WaitGroup
+	checker *health.Checker
+}
+
+// NewRunner returns a Runner reporting job status to checker, which may be nil.
+func NewRunner(checker *health.Checker) *Runner {
+	ctx, cancel := context.WithCancel(context.Background())
+	runs, abort := context.WithCancel(context.Background())
+	return &Runner{ctx: ctx, cancel: cancel, runs: runs, abort: abort, checker: checker}
+}
+
+// Go runs fn until it returns or the runner shuts down. fn must return
+// promptly once ctx is cancelled.
+func (r *Runner) Go(name string, fn func(ctx context.Context) error) {
+	r.report(name, nil)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		err := fn(r.ctx)
+		if err != nil && r.ctx.Err() == nil {
+			slog.Error("Background job stopped", "job", name, "error", err)
+			r.report(name, err)
+		}
+	}()
+}
+
+// Every runs fn every interval until the runner shuts down. A run in
+// progress is allowed to finish during shutdown; its ctx is only cancelled
+// once the deadline given to Shutdown passes.
+func (r *Runner) Every(name string, interval time.Duration, fn func(ctx context.Context) error) {
+	r.Go(name, func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				if ctx.Err() != nil {
+					return nil
+				}
+				err := fn(r.runs)
+				if err != nil {
+					slog.Warn("Background job failed", "job", name, "error", err)
+				}
+				r.report(name, err)
+			}
+		}
+	})
+}
+
+// Shutdown cancels all jobs and waits for them to return, or for ctx to
+// expire, when runs still in progress are cancelled too.
+func (r *Runner) Shutdown(ctx context.Context) error {
+	r.cancel()
+	defer r.abort()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Runner) report(name string, err error) {
+	if r.checker != nil {
+		r.checker.SetStatus(name, err)
+	}
+}
@@ -1,115 +1,430 @@
 package main
 
 import (
+        "context"
+        "errors"
+        "flag"
         "log"
+        "log/slog"
+        "net"
+        "net/http"
+        "os"
+        "os/signal"
+        "strconv"
+        "syscall"
+        "time"
 
+        "everflown-logistics/archive"
+        "everflown-logistics/config"
         "everflown-logistics/database"
+        "everflown-logistics/events"
+        "everflown-logistics/grpcserver"
         "everflown-logistics/handlers"
+        "everflown-logistics/health"
+        "everflown-logistics/jobs"
+        "everflown-logistics/logging"
+        "everflown-logistics/metrics"
+        "everflown-logistics/middleware"
+        "everflown-logistics/models"
+        "everflown-logistics/repository"
+        "everflown-logistics/services"
+        "everflown-logistics/tracing"
         "github.com/gin-contrib/cors"
         "github.com/gin-gonic/gin"
         "github.com/joho/godotenv"
+        "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+        "google.golang.org/grpc"
+        "gorm.io/gorm"
 )
 
 func main() {
         // Load environment variables
-        if err := godotenv.Load(); err != nil {
+        envErr := godotenv.Load()
+
+        // Typed configuration: defaults < config file < environment < flags
+        cfg, args, err := config.Load(os.Args[1:], os.LookupEnv)
+        if errors.Is(err, flag.ErrHelp) {
+                os.Exit(0)
+        }
+
+        // Structured JSON logs; the standard log package is routed through slog too
+        logging.Setup(os.Stdout, cfg.Log.Level)
+        if envErr != nil {
                 log.Println("No .env file found, using system environment variables")
         }
+        if err != nil {
+                log.Fatal("Invalid configuration: ", err)
+        }
+        slog.Info("Effective configuration", "config", cfg.Redacted())
+
+        // OpenTelemetry tracing, exported per tracing.exporter (otlp, stdout or none)
+        shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing.Exporter, cfg.Tracing.ServiceName)
+        if err != nil {
+                log.Fatal("Failed to set up tracing:", err)
+        }
+
+        // Connect to database, waiting up to DB_CONNECT_TIMEOUT for it to come up
+        db, err := database.Connect(context.Background(), cfg.Database.URL, cfg.Database.Options())
+        if err != nil {
+                log.Fatal("Failed to connect to database:", err)
+        }
+
+        // Reporting reads, including exports, go to the replica when one is
+        // configured. Everything works without it, so a replica that is down
+        // gets one attempt and is not fatal.
+        if cfg.Database.ReplicaURL != "" {
+                replicaOptions := cfg.Database.Options()
+                replicaOptions.ConnectTimeout = 0
+                if err := database.UseReplica(context.Background(), db, cfg.Database.ReplicaURL, replicaOptions); err != nil {
+                        slog.Warn("Read replica unavailable, reporting queries will use the primary", "error", err)
+                }
+        }
+
+        // Subcommands such as "migrate up" or "seed" run against the database and exit
+        if len(args) > 0 {
+                os.Exit(runCommand(context.Background(), db, cfg.Database.Migrations, args))
+        }
+
+        // Refuse to serve a schema with pending or failed migrations (see DB_MIGRATIONS)
+        if err := ensureMigrated(context.Background(), db, cfg.Database.Migrations); err != nil {
+                log.Fatal("Database schema is not ready: ", err)
+        }
+
+        if err := db.Use(&tracing.GormPlugin{}); err != nil {
+                log.Fatal("Failed to register database tracing:", err)
+        }
+
+        // Query timings, connection pool stats and dispatch counts for /metrics
+        if err := db.Use(&metrics.GormPlugin{}); err != nil {
+                log.Fatal("Failed to register database metrics:", err)
+        }
+        if err := metrics.RegisterDispatchStatusCollector(db); err != nil {
+                log.Fatal("Failed to register dispatch metrics:", err)
+        }
+
+        // Readiness checks, cached so frequent probes don't hammer the database
+        checker := health.NewChecker(cfg.Health.CacheTTL)
+        checker.Register("database", health.DatabaseCheck(db))
+        checker.Register("schema", health.SchemaCheck(db, models.Tables()...))
+        if cfg.Database.Migrations != "off" {
+                migrator, err := newMigrator(db)
+                if err != nil {
+                        log.Fatal("Failed to load schema migrations:", err)
+                }
+                checker.Register("migrations", health.MigrationCheck(migrator))
+        }
+
+        // Background jobs, drained on shutdown
+        runner := jobs.NewRunner(checker)
+
+        // Domain services shared by the HTTP and gRPC APIs
+        repos := repository.New(db).WithReadRetry(cfg.Database.ReadRetry())
+        svc := services.New(repos, cfg.Company.Branding())
+        svc.Webhooks.AllowPrivateNetworks = cfg.Webhooks.AllowPrivateNetworks
+        h := handlers.New(svc)
+
+        // Deliver domain events from the outbox to subscribers, and drop them
+        // once every subscriber has them and they are past retention
+        dispatcher := events.NewDispatcher(repos.Outbox, events.Options{
+                PollInterval: cfg.Events.PollInterval,
+                MaxAttempts:  cfg.Events.MaxAttempts,
+        })
+        dispatcher.Subscribe("webhooks", svc.Webhooks.QueueEvent)
+        runner.Go("event_dispatcher", dispatcher.Run)
+        runner.Every("outbox_pruner", time.Hour, func(ctx context.Context) error {
+                _, err := repos.Outbox.Prune(ctx, time.Now().Add(-cfg.Events.Retention))
+                return err
+        })
+
+        // Send queued webhook deliveries, retrying failures with backoff, and
+        // drop finished ones once they are past retention
+        webhookPolicy := services.WebhookPolicy{
+                Timeout:      cfg.Webhooks.Timeout,
+                MaxAttempts:  cfg.Webhooks.MaxAttempts,
+                DisableAfter: cfg.Webhooks.DisableAfter,
+        }
+        runner.Every("webhook_sender", cfg.Webhooks.Interval, func(ctx context.Context) error {
+                _, err := svc.Webhooks.SendDue(ctx, webhookPolicy)
+                return err
+        })
+        runner.Every("webhook_log_pruner", time.Hour, func(ctx context.Context) error {
+                _, err := repos.Webhooks.PruneDeliveries(ctx, time.Now().Add(-cfg.Webhooks.Retention))
+                return err
+        })
+
+        // Follow the audit log for changes to push to /api/stream
+        runner.Every("stream_poller", cfg.Stream.PollInterval, svc.Stream.Poll)
+
+        // Move settled orders to the archive tables once they are old enough
+        if cfg.Archive.AfterDays > 0 {
+                runner.Every("order_archiver", cfg.Archive.Interval, func(ctx context.Context) error {
+                        archived, err := svc.Archive.ArchiveClosedOrders(ctx, cfg.Archive.AfterDays)
+                        if archived > 0 {
+                                slog.Info("Archived closed orders", "orders", archived)
+                        }
+                        return err
+                })
+        }
 
-        // Connect to database
-        database.Connect()
+        // gRPC server for internal consumers such as the routing service, on
+        // the same host as the HTTP server and trusting the same identities
+        grpcAddr := net.JoinHostPort(cfg.Server.Host, strconv.Itoa(cfg.GRPC.Port))
+        lis, err := net.Listen("tcp", grpcAddr)
+        if err != nil {
+                log.Fatal("Failed to listen for gRPC:", err)
+        }
+        grpcServer := grpcserver.New(svc, cfg.Server.IdentitySecret)
+        checker.SetStatus("grpc_server", errors.New("starting"))
+        go func() {
+                log.Printf("gRPC server starting on %s", grpcAddr)
+                checker.SetStatus("grpc_server", nil)
+                if err := grpcServer.Serve(lis); err != nil {
+                        log.Println("gRPC server stopped:", err)
+                        checker.SetStatus("grpc_server", err)
+                }
+        }()
 
-        // Set up Gin router
-        r := gin.Default()
+        // Set up Gin router with request IDs and structured request logs
+        r := gin.New()
+        // Client IPs, used for rate limits and logs, only come from X-Forwarded-For set by a trusted proxy
+        if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+                log.Fatal("Invalid trusted proxies:", err)
+        }
+        r.Use(
+                middleware.RequestID(),
+                middleware.Identity(cfg.Server.IdentitySecret),
+                otelgin.Middleware(tracing.ServiceName, otelgin.WithFilter(tracing.SkipProbes)),
+                middleware.Logger(),
+                middleware.Recovery(),
+                middleware.Metrics(),
+        )
 
         // CORS middleware
-        config := cors.DefaultConfig()
-        config.AllowOrigins = []string{"http://localhost:5000", "https://*.replit.app", "https://*.replit.dev"}
-        config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-        config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
-        config.AllowCredentials = true
-        r.Use(cors.New(config))
+        corsConfig := cors.DefaultConfig()
+        corsConfig.AllowOrigins = cfg.CORS.AllowedOrigins
+        corsConfig.AllowWildcard = true
+        corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+        corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", middleware.RequestIDHeader}
+        corsConfig.ExposeHeaders = []string{middleware.RequestIDHeader, "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After"}
+        corsConfig.AllowCredentials = true
+        r.Use(cors.New(corsConfig))
+
+        // Rate limiting per route group
+        limiter := newRateLimiter(cfg.RateLimit, db, checker, runner)
 
         // API routes
-        api := r.Group("/api")
+        api := r.Group("/api", limiter.Middleware("api"))
         {
                 // Auth routes
-                api.GET("/user", handlers.GetCurrentUser)
-                api.POST("/login", handlers.Login)
-                api.POST("/register", handlers.Register)
-                api.POST("/logout", handlers.Logout)
-                api.GET("/users", handlers.GetUsers)
-                api.PUT("/users/:id", handlers.UpdateUser)
-                api.DELETE("/users/:id", handlers.DeleteUser)
+                auth := api.Group("", limiter.Middleware("auth"))
+                api.GET("/user", h.GetCurrentUser)
+                auth.POST("/login", h.Login)
+                auth.POST("/register", h.Register)
+                api.POST("/logout", h.Logout)
+                api.GET("/users", h.GetUsers)
+                api.PUT("/users/:id", h.UpdateUser)
+                api.DELETE("/users/:id", h.DeleteUser)
+
+                // Live changes to orders, dispatches and follow-ups
+                api.GET("/stream", h.Stream(cfg.Stream.Heartbeat))
 
                 // Dashboard routes
-                api.GET("/dashboard/stats", handlers.GetDashboardStats)
+                api.GET("/dashboard/stats", h.GetDashboardStats)
 
                 // Lead routes
-                api.GET("/leads", handlers.GetLeads)
-                api.POST("/leads", handlers.CreateLead)
-                api.PUT("/leads/:id", handlers.UpdateLead)
-                api.DELETE("/leads/:id", handlers.DeleteLead)
+                api.GET("/leads", h.GetLeads)
+                api.POST("/leads", h.CreateLead)
+                api.PUT("/leads/:id", h.UpdateLead)
+                api.DELETE("/leads/:id", h.DeleteLead)
 
                 // Customer routes
-                api.GET("/customers", handlers.GetCustomers)
-                api.POST("/customers", handlers.CreateCustomer)
-                api.PUT("/customers/:id", handlers.UpdateCustomer)
-                api.DELETE("/customers/:id", handlers.DeleteCustomer)
+                api.GET("/customers", h.GetCustomers)
+                api.POST("/customers", h.CreateCustomer)
+                api.PUT("/customers/:id", h.UpdateCustomer)
+                api.DELETE("/customers/:id", h.DeleteCustomer)
 
                 // Carrier routes
-                api.GET("/carriers", handlers.GetCarriers)
-                api.POST("/carriers", handlers.CreateCarrier)
-                api.PUT("/carriers/:id", handlers.UpdateCarrier)
-                api.DELETE("/carriers/:id", handlers.DeleteCarrier)
+                api.GET("/carriers", h.GetCarriers)
+                api.POST("/carriers", h.CreateCarrier)
+                api.PUT("/carriers/:id", h.UpdateCarrier)
+                api.DELETE("/carriers/:id", h.DeleteCarrier)
 
                 // Order routes
-                api.GET("/orders", handlers.GetOrders)
-                api.POST("/orders", handlers.CreateOrder)
-                api.PUT("/orders/:id", handlers.UpdateOrder)
-                api.DELETE("/orders/:id", handlers.DeleteOrder)
+                api.GET("/orders", h.GetOrders)
+                api.POST("/orders", h.CreateOrder)
+                api.PUT("/orders/:id", h.UpdateOrder)
+                api.DELETE("/orders/:id", h.DeleteOrder)
+                api.GET("/orders/:id/history", h.GetOrderHistory)
 
                 // Dispatch routes
-                api.GET("/dispatches", handlers.GetDispatches)
-                api.POST("/dispatches", handlers.CreateDispatch)
-                api.PUT("/dispatches/:id", handlers.UpdateDispatch)
-                api.DELETE("/dispatches/:id", handlers.DeleteDispatch)
-                api.GET("/dispatches/:id/rate-confirmation", handlers.GetDispatchRateConfirmation)
+                api.GET("/dispatches", h.GetDispatches)
+                api.POST("/dispatches", h.CreateDispatch)
+                api.PUT("/dispatches/:id", h.UpdateDispatch)
+                api.DELETE("/dispatches/:id", h.DeleteDispatch)
+                api.GET("/dispatches/:id/history", h.GetDispatchHistory)
 
                 // Quote routes
-                api.GET("/quotes", handlers.GetQuotes)
-                api.POST("/quotes", handlers.CreateQuote)
-                api.PUT("/quotes/:id", handlers.UpdateQuote)
-                api.DELETE("/quotes/:id", handlers.DeleteQuote)
+                api.GET("/quotes", h.GetQuotes)
+                api.POST("/quotes", h.CreateQuote)
+                api.PUT("/quotes/:id", h.UpdateQuote)
+                api.DELETE("/quotes/:id", h.DeleteQuote)
+                api.GET("/quotes/:id/history", h.GetQuoteHistory)
 
                 // Invoice routes
-                api.GET("/invoices", handlers.GetInvoices)
-                api.POST("/invoices", handlers.CreateInvoice)
-                api.PUT("/invoices/:id", handlers.UpdateInvoice)
-                api.DELETE("/invoices/:id", handlers.DeleteInvoice)
+                api.GET("/invoices", h.GetInvoices)
+                api.POST("/invoices", h.CreateInvoice)
+                api.PUT("/invoices/:id", h.UpdateInvoice)
+                api.DELETE("/invoices/:id", h.DeleteInvoice)
+                api.GET("/invoices/:id/history", h.GetInvoiceHistory)
 
                 // Follow-up routes
-                api.GET("/followups", handlers.GetFollowUps)
-                api.GET("/followups/urgent", handlers.GetUrgentFollowUps)
-                api.POST("/followups", handlers.CreateFollowUp)
-                api.PUT("/followups/:id", handlers.UpdateFollowUp)
-                api.DELETE("/followups/:id", handlers.DeleteFollowUp)
+                api.GET("/followups", h.GetFollowUps)
+                api.GET("/followups/urgent", h.GetUrgentFollowUps)
+                api.POST("/followups", h.CreateFollowUp)
+                api.PUT("/followups/:id", h.UpdateFollowUp)
+                api.DELETE("/followups/:id", h.DeleteFollowUp)
+
+                // Archived order routes (read-only)
+                api.GET("/archive/orders", h.GetArchivedOrders)
+                api.GET("/archive/orders/:id", h.GetArchivedOrder)
 
                 // PDF generation routes
-                api.GET("/invoices/:id/pdf", handlers.GenerateInvoicePDF)
-                api.GET("/quotes/:id/pdf", handlers.GenerateQuotePDF)
+                pdf := api.Group("", limiter.Middleware("pdf"))
+                pdf.GET("/invoices/:id/pdf", h.GenerateInvoicePDF)
+                pdf.GET("/quotes/:id/pdf", h.GenerateQuotePDF)
+                pdf.GET("/dispatches/:id/rate-confirmation", h.GetDispatchRateConfirmation)
+
+                // Admin routes
+                admin := api.Group("/admin", middleware.RequireRole("admin"))
+                admin.GET("/export", archive.ExportHandler(db))
+                admin.POST("/import", archive.ImportHandler(db))
+                admin.POST("/archive/orders/:id/restore", h.RestoreArchivedOrder)
+                admin.GET("/audit", h.GetAuditLog)
+                admin.GET("/events/dead-letters", h.GetEventDeadLetters)
+                admin.POST("/events/dead-letters/:id/retry", h.RetryEventDeadLetter)
+
+                // Webhook subscriptions and their delivery log
+                admin.GET("/webhooks", h.GetWebhooks)
+                admin.POST("/webhooks", h.CreateWebhook)
+                admin.GET("/webhooks/:id", h.GetWebhook)
+                admin.PUT("/webhooks/:id", h.UpdateWebhook)
+                admin.DELETE("/webhooks/:id", h.DeleteWebhook)
+                admin.POST("/webhooks/:id/enable", h.EnableWebhook)
+                admin.POST("/webhooks/:id/disable", h.DisableWebhook)
+                admin.GET("/webhooks/:id/deliveries", h.GetWebhookDeliveries)
+                admin.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", h.RedeliverWebhook)
         }
 
-        // Health check route
-        r.GET("/health", func(c *gin.Context) {
-                c.JSON(200, gin.H{"status": "ok", "message": "Go backend is running"})
-        })
+        // Prometheus metrics
+        r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+        // Health check routes
+        r.GET("/health", health.LiveHandler)
+        r.GET("/health/live", health.LiveHandler)
+        r.GET("/health/ready", checker.ReadyHandler)
+
+        // HTTP server, listening on host:port (default 127.0.0.1:8080; the Node.js proxy uses 5000)
+        srv := &http.Server{
+                Addr:              net.JoinHostPort(cfg.Server.Host, strconv.Itoa(cfg.Server.Port)),
+                Handler:           r,
+                ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+                ReadTimeout:       cfg.Server.ReadTimeout,
+                WriteTimeout:      cfg.Server.WriteTimeout,
+                IdleTimeout:       cfg.Server.IdleTimeout,
+        }
+        // Open change streams would otherwise hold up shutdown
+        srv.RegisterOnShutdown(svc.Stream.Close)
+        certFile, keyFile := cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile
+
+        serverErr := make(chan error, 1)
+        go func() {
+                var err error
+                if certFile != "" {
+                        log.Printf("Go backend server starting on %s (TLS)", srv.Addr)
+                        err = srv.ListenAndServeTLS(certFile, keyFile)
+                } else {
+                        log.Printf("Go backend server starting on %s", srv.Addr)
+                        err = srv.ListenAndServe()
+                }
+                if !errors.Is(err, http.ErrServerClosed) {
+                        serverErr <- err
+                }
+        }()
 
-        // Use port 8080 for Go backend (Node.js proxy uses 5000)
-        port := "8080"
+        // Wait for SIGINT/SIGTERM, then drain before exiting
+        ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+        defer stop()
+        select {
+        case <-ctx.Done():
+                log.Println("Shutdown signal received, draining requests")
+        case err := <-serverErr:
+                log.Println("HTTP server failed:", err)
+        }
+
+        shutdown(cfg.Server.ShutdownTimeout, checker, srv, grpcServer, runner, shutdownTracing, db)
+}
+
+// shutdown stops the servers and background jobs in dependency order, all
+// within timeout, and closes the database pool last so draining work can
+// still reach it.
+func shutdown(timeout time.Duration, checker *health.Checker, srv *http.Server, grpcServer *grpc.Server, runner *jobs.Runner, shutdownTracing func(context.Context) error, db *gorm.DB) {
+        ctx, cancel := context.WithTimeout(context.Background(), timeout)
+        defer cancel()
+
+        // Fail readiness first so load balancers stop routing new traffic here.
+        checker.SetStatus("shutdown", errors.New("shutting down"))
+
+        if err := srv.Shutdown(ctx); err != nil {
+                log.Println("HTTP server did not drain cleanly:", err)
+        }
+
+        grpcStopped := make(chan struct{})
+        go func() {
+                grpcServer.GracefulStop()
+                close(grpcStopped)
+        }()
+        select {
+        case <-grpcStopped:
+        case <-ctx.Done():
+                log.Println("gRPC server did not drain in time, closing open streams")
+                grpcServer.Stop()
+        }
 
-        log.Printf("Go backend server starting on port %s", port)
-        if err := r.Run(":" + port); err != nil {
-                log.Fatal("Failed to start server:", err)
+        if err := runner.Shutdown(ctx); err != nil {
+                log.Println("Background jobs did not finish in time:", err)
         }
-}
\ No newline at end of file
+        if err := shutdownTracing(ctx); err != nil {
+                log.Println("Failed to flush traces:", err)
+        }
+        if err := database.Close(db); err != nil {
+                log.Println("Failed to close database:", err)
+        }
+        log.Println("Shutdown complete")
+}
+
+// newRateLimiter builds the per-group limiters; a group set to "off" is not limited.
+func newRateLimiter(cfg config.RateLimitConfig, db *gorm.DB, checker *health.Checker, runner *jobs.Runner) *middleware.RateLimiter {
+        limits := make(map[string]middleware.Limit)
+        for group, value := range map[string]string{"api": cfg.API, "auth": cfg.Auth, "pdf": cfg.PDF} {
+                if value == "off" {
+                        continue
+                }
+                limit, err := middleware.ParseLimit(value)
+                if err != nil {
+                        log.Fatal("Invalid rate limit for ", group, ": ", err)
+                }
+                limits[group] = limit
+        }
+
+        var store middleware.RateLimitStore = middleware.NewMemoryRateLimitStore()
+        if cfg.Store == "postgres" {
+                pgStore := middleware.NewPostgresRateLimitStore(db)
+                checker.Register("rate_limit_store", health.SchemaCheck(db, &middleware.RateLimitBucket{}))
+                runner.Every("rate_limit_pruner", time.Hour, func(ctx context.Context) error {
+                        return pgStore.Prune(ctx, time.Now().Add(-24*time.Hour))
+                })
+                store = pgStore
+        }
+
+        return middleware.NewRateLimiter(store, limits)
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+const startTimeKey = "metrics:start_time"
+
+// GormPlugin times every GORM operation and exports connection pool stats.
+// Register it with db.Use(&metrics.GormPlugin{}).
+type GormPlugin struct{}
+
+func (p *GormPlugin) Name() string {
+	return "metrics"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if err := RegisterDBStats(sqlDB, db.Dialector.Name()); err != nil {
+		return err
+	}
+
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("metrics:before_create", startTimer),
+		cb.Create().After("gorm:create").Register("metrics:after_create", observe("create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", startTimer),
+		cb.Query().After("gorm:query").Register("metrics:after_query", observe("query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", startTimer),
+		cb.Update().After("gorm:update").Register("metrics:after_update", observe("update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", startTimer),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", observe("delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", startTimer),
+		cb.Row().After("gorm:row").Register("metrics:after_row", observe("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", startTimer),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", observe("raw")),
+	)
+}
+
+// RegisterDBStats exports the connection pool stats of sqlDB as go_sql_*
+// with the given db_name label. Registering a name twice is a no-op.
+func RegisterDBStats(sqlDB *sql.DB, name string) error {
+	if err := Registry.Register(collectors.NewDBStatsCollector(sqlDB, name)); err != nil {
+		var already prometheus.AlreadyRegisteredError
+		if !errors.As(err, &already) {
+			return err
+		}
+	}
+	return nil
+}
+
+func startTimer(db *gorm.DB) {
+	db.InstanceSet(startTimeKey, time.Now())
+}
+
+// observe returns a callback recording the duration of operation since startTimer.
+func observe(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(startTimeKey)
+		if !ok {
+			return
+		}
+		start, ok := v.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		DBQueryDuration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			DBQueryErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
+
+// dispatchStatusCollector reports the current number of dispatches in each status at scrape time.
+type dispatchStatusCollector struct {
+	db   *gorm.DB
+	desc *prometheus.Desc
+}
+
+// RegisterDispatchStatusCollector exports a dispatches gauge, labelled by status, read from db.
+func RegisterDispatchStatusCollector(db *gorm.DB) error {
+	return Registry.Register(&dispatchStatusCollector{
+		db: db,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "dispatches"),
+			"Dispatches currently in each status.",
+			[]string{"status"}, nil,
+		),
+	})
+}
+
+func (c *dispatchStatusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *dispatchStatusCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err := c.db.WithContext(ctx).Table("dispatches").Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error
+	if err != nil {
+		slog.Warn("Failed to collect dispatch status metrics", "error", err)
+		return
+	}
+	for _, row := range rows {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(row.Count), row.Status)
+	}
+}
@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"everflown-logistics/audit"
+	"everflown-logistics/logging"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// RequestIDHeader carries the request ID in both directions.
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDKey is the gin context key holding the request ID.
+	RequestIDKey = "requestID"
+	// TenantIDKey is the gin context key holding the tenant ID.
+	TenantIDKey = "tenantID"
+	// UserRoleKey is the gin context key holding the user's role.
+	UserRoleKey = "userRole"
+	// CustomerIDKey is the gin context key holding the customer a user acts
+	// for, as a uint.
+	CustomerIDKey = "customerID"
+
+	// Identity headers set by the Node.js proxy from the user's session.
+	UserIDHeader     = "X-User-ID"
+	TenantIDHeader   = "X-Tenant-ID"
+	UserRoleHeader   = "X-User-Role"
+	CustomerIDHeader = "X-Customer-ID"
+)
+
+// RequestID assigns every request an ID, reusing a well-formed incoming
+// X-Request-ID so IDs can be followed across services.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > 128 {
+			id = newRequestID()
+		}
+		c.Set(RequestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// Identity records the user, role, tenant and customer forwarded by the
+// proxy, and attributes the request's changes to the user and tenant in the
+// audit log.
+//
+// With a secret, the headers are only believed when the proxy signed them
+// with it (see SignIdentity); requests carrying identity headers without a
+// valid signature are refused with a 401. Without one, the headers are
+// trusted as is, which is only safe while the backend listens on loopback.
+func Identity(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims := Claims{
+			UserID:     c.GetHeader(UserIDHeader),
+			Role:       c.GetHeader(UserRoleHeader),
+			TenantID:   c.GetHeader(TenantIDHeader),
+			CustomerID: c.GetHeader(CustomerIDHeader),
+		}
+		signature := c.GetHeader(IdentitySignatureHeader)
+		if secret != "" && (!claims.empty() || signature != "") {
+			if err := VerifyIdentity(secret, signature, claims, time.Now()); err != nil {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+				return
+			}
+		}
+
+		if claims.UserID != "" {
+			c.Set(UserIDKey, claims.UserID)
+		}
+		if claims.TenantID != "" {
+			c.Set(TenantIDKey, claims.TenantID)
+		}
+		if claims.Role != "" {
+			c.Set(UserRoleKey, claims.Role)
+		}
+		if claims.CustomerID != "" {
+			customerID, err := strconv.ParseUint(claims.CustomerID, 10, 64)
+			if err != nil || customerID == 0 {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+				return
+			}
+			c.Set(CustomerIDKey, uint(customerID))
+		}
+		actor := audit.Actor{UserID: claims.UserID, TenantID: claims.TenantID, RequestID: c.GetString(RequestIDKey)}
+		c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), actor))
+		c.Next()
+	}
+}
+
+// Logger attaches a request-scoped logger to the request context and writes
+// one structured line per request once it completes. It must run after
+// RequestID and Identity, and after the tracing middleware so log lines
+// carry the trace ID.
+func Logger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		attrs := []any{slog.String("request_id", c.GetString(RequestIDKey))}
+		if userID := c.GetString(UserIDKey); userID != "" {
+			attrs = append(attrs, slog.String("user_id", userID))
+		}
+		if tenantID := c.GetString(TenantIDKey); tenantID != "" {
+			attrs = append(attrs, slog.String("tenant_id", tenantID))
+		}
+		if sc := trace.SpanContextFromContext(c.Request.Context()); sc.IsValid() {
+			attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()))
+		}
+		logger := slog.Default().With(attrs...)
+		c.Request = c.Request.WithContext(logging.WithLogger(c.Request.Context(), logger))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		fields := []any{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("bytes", c.Writer.Size()),
+		}
+		if len(c.Errors) > 0 {
+			fields = append(fields, slog.String("errors", c.Errors.String()))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		logger.Log(c.Request.Context(), level, "request completed", fields...)
+	}
+}
+
+// Recovery turns panics into 500 responses and logs them with the request's context.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(c *gin.Context, err any) {
+		logging.FromContext(c.Request.Context()).Error("panic recovered", slog.Any("panic", err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	})
+}
+
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,152 @@
+// Package middleware contains the Gin middleware shared by all API routes.
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"everflown-logistics/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// UserIDKey is the gin context key under which authentication stores the user ID.
+const UserIDKey = "userID"
+
+// Limit describes a token bucket: Requests tokens refilled evenly over Window.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// ParseLimit parses limits written as "<requests>/<window>", e.g. "100/1m" or "10/s".
+func ParseLimit(s string) (Limit, error) {
+	parts := strings.SplitN(strings.TrimSpace(s), "/", 2)
+	if len(parts) != 2 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: expected <requests>/<window>", s)
+	}
+	requests, err := strconv.Atoi(parts[0])
+	if err != nil || requests <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: requests must be a positive integer", s)
+	}
+	window := parts[1]
+	if window != "" && !strings.ContainsAny(window[:1], "0123456789") {
+		window = "1" + window
+	}
+	d, err := time.ParseDuration(window)
+	if err != nil || d <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: bad window", s)
+	}
+	return Limit{Requests: requests, Window: d}, nil
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Window)
+}
+
+// ratePerSecond is the bucket refill rate.
+func (l Limit) ratePerSecond() float64 {
+	return float64(l.Requests) / l.Window.Seconds()
+}
+
+// RateLimitResult is the outcome of taking a token from a bucket.
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int
+	ResetAfter time.Duration // until the bucket is full again
+	RetryAfter time.Duration // until the next token is available, when denied
+}
+
+// RateLimitStore persists token buckets. Implementations must be safe for
+// concurrent use and must take tokens atomically.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (RateLimitResult, error)
+}
+
+// RateLimiter applies per-route-group limits to each client.
+type RateLimiter struct {
+	store  RateLimitStore
+	limits map[string]Limit
+}
+
+// NewRateLimiter returns a limiter using store, with limits keyed by route group name.
+func NewRateLimiter(store RateLimitStore, limits map[string]Limit) *RateLimiter {
+	return &RateLimiter{store: store, limits: limits}
+}
+
+// Middleware limits requests for the named route group. Groups without a
+// configured limit are not limited.
+func (rl *RateLimiter) Middleware(group string) gin.HandlerFunc {
+	limit, ok := rl.limits[group]
+	if !ok {
+		return func(c *gin.Context) { c.Next() }
+	}
+	policy := fmt.Sprintf("%d;w=%d", limit.Requests, int(math.Ceil(limit.Window.Seconds())))
+
+	return func(c *gin.Context) {
+		key := group + ":" + clientKey(c)
+		result, err := rl.store.Take(c.Request.Context(), key, limit, time.Now())
+		if err != nil {
+			// Fail open: an unavailable store must not take the API down with it.
+			logging.FromContext(c.Request.Context()).Warn("rate limit store unavailable", "group", group, "error", err)
+			c.Next()
+			return
+		}
+
+		h := c.Writer.Header()
+		h.Set("RateLimit-Policy", policy)
+		h.Set("RateLimit-Limit", strconv.Itoa(limit.Requests))
+		h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			h.Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// clientKey identifies the caller by the user Identity authenticated, or
+// else by IP. The IP is only taken from X-Forwarded-For when the request came
+// through one of the engine's trusted proxies.
+func clientKey(c *gin.Context) string {
+	if userID := c.GetString(UserIDKey); userID != "" {
+		return "user:" + userID
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
+
+// takeToken refills a bucket holding tokens as of last and takes one token at now.
+// It returns the new token count alongside the result.
+func takeToken(tokens float64, last time.Time, limit Limit, now time.Time) (float64, RateLimitResult) {
+	capacity := float64(limit.Requests)
+	rate := limit.ratePerSecond()
+
+	if elapsed := now.Sub(last).Seconds(); elapsed > 0 {
+		tokens = math.Min(capacity, tokens+elapsed*rate)
+	}
+
+	var result RateLimitResult
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - tokens) / rate * float64(time.Second))
+	}
+	result.Remaining = int(math.Floor(tokens))
+	result.ResetAfter = time.Duration((capacity - tokens) / rate * float64(time.Second))
+	return tokens, result
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryBucket struct {
+	tokens float64
+	last   time.Time
+	limit  Limit
+}
+
+// MemoryRateLimitStore keeps buckets in process memory. Limits are per
+// instance, so use PostgresRateLimitStore when running several replicas.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{buckets: make(map[string]*memoryBucket)}
+}
+
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, limit Limit, now time.Time) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: float64(limit.Requests), last: now}
+		s.buckets[key] = b
+	}
+	b.limit = limit
+
+	var result RateLimitResult
+	b.tokens, result = takeToken(b.tokens, b.last, limit, now)
+	b.last = now
+	return result, nil
+}
+
+// sweep drops buckets that have refilled completely, since a fresh bucket is
+// equivalent. It runs at most once a minute to keep Take cheap.
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if now.Sub(b.last) >= b.limit.Window {
+			delete(s.buckets, key)
+		}
+	}
+}
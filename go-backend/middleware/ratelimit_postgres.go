@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RateLimitBucket is a token bucket row shared by all API instances.
+type RateLimitBucket struct {
+	Key       string    `gorm:"primaryKey;type:varchar(255)"`
+	Tokens    float64   `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null;autoUpdateTime:false"`
+}
+
+func (RateLimitBucket) TableName() string {
+	return "rate_limit_buckets"
+}
+
+// PostgresRateLimitStore keeps buckets in the database so limits hold across
+// multiple API instances. Each Take locks the caller's row for the duration
+// of a short transaction.
+type PostgresRateLimitStore struct {
+	db *gorm.DB
+}
+
+// NewPostgresRateLimitStore returns a store backed by db. The bucket table
+// is created by the migrations.
+func NewPostgresRateLimitStore(db *gorm.DB) *PostgresRateLimitStore {
+	return &PostgresRateLimitStore{db: db}
+}
+
+func (s *PostgresRateLimitStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (RateLimitResult, error) {
+	var result RateLimitResult
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		fresh := RateLimitBucket{Key: key, Tokens: float64(limit.Requests), UpdatedAt: now}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&fresh).Error; err != nil {
+			return err
+		}
+
+		var bucket RateLimitBucket
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).First(&bucket).Error; err != nil {
+			return err
+		}
+
+		var tokens float64
+		tokens, result = takeToken(bucket.Tokens, bucket.UpdatedAt, limit, now)
+		return tx.Model(&RateLimitBucket{}).Where("key = ?", key).
+			Updates(map[string]interface{}{"tokens": tokens, "updated_at": now}).Error
+	})
+	return result, err
+}
+
+// Prune deletes buckets untouched since before cutoff.
+func (s *PostgresRateLimitStore) Prune(ctx context.Context, cutoff time.Time) error {
+	return s.db.WithContext(ctx).Where("updated_at < ?", cutoff).Delete(&RateLimitBucket{}).Error
+}
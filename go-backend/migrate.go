@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+
+	"everflown-logistics/migrations"
+	"gorm.io/gorm"
+)
+
+const migrateUsage = `usage: migrate <command>
+
+commands:
+  up [N]         apply all pending migrations, or the next N
+  down [N]       revert the last N applied migrations (default 1)
+  status         show the applied version and pending migrations
+  force VERSION  record VERSION as applied and clear the dirty flag`
+
+// newMigrator returns a migrator for the embedded schema migrations of db's dialect.
+func newMigrator(db *gorm.DB) (*migrations.Migrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	fsys, err := migrations.ForDialect(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	return migrations.New(sqlDB, fsys)
+}
+
+// ensureMigrated enforces the configured migration mode at startup: "check"
+// refuses to start on a pending or dirty schema, "auto" applies pending
+// migrations first, and "off" skips the check.
+func ensureMigrated(ctx context.Context, db *gorm.DB, mode string) error {
+	if mode == "off" {
+		return nil
+	}
+
+	migrator, err := newMigrator(db)
+	if err != nil {
+		return err
+	}
+	if mode == "auto" {
+		applied, err := migrator.Up(ctx, 0)
+		if err != nil {
+			return err
+		}
+		if applied > 0 {
+			log.Printf("Applied %d schema migration(s)", applied)
+		}
+	}
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if err := status.Check(); err != nil {
+		if errors.Is(err, migrations.ErrPending) {
+			return fmt.Errorf("%w; run \"migrate up\" or set DB_MIGRATIONS=auto", err)
+		}
+		return fmt.Errorf("%w; repair the schema, then run \"migrate force VERSION\"", err)
+	}
+	return nil
+}
+
+// runMigrate executes a migrate subcommand, writing results to out.
+func runMigrate(ctx context.Context, db *gorm.DB, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	migrator, err := newMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		steps, err := optionalCount(args[1:], 0)
+		if err != nil {
+			return err
+		}
+		applied, err := migrator.Up(ctx, steps)
+		fmt.Fprintf(out, "Applied %d migration(s)\n", applied)
+		return err
+	case "down":
+		steps, err := optionalCount(args[1:], 1)
+		if err != nil {
+			return err
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		fmt.Fprintf(out, "Reverted %d migration(s)\n", reverted)
+		return err
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		state := "clean"
+		if status.Dirty {
+			state = "dirty"
+		}
+		fmt.Fprintf(out, "Version: %d (%s)\n", status.Version, state)
+		for _, m := range status.Applied {
+			fmt.Fprintf(out, "  applied  %s\n", m)
+		}
+		for _, m := range status.Pending {
+			fmt.Fprintf(out, "  pending  %s\n", m)
+		}
+		return nil
+	case "force":
+		if len(args) != 2 {
+			return errors.New("usage: migrate force VERSION")
+		}
+		version, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if err := migrator.Force(ctx, uint(version)); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Forced version %d\n", version)
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", args[0], migrateUsage)
+	}
+}
+
+// optionalCount parses an optional positive count argument.
+func optionalCount(args []string, def int) (int, error) {
+	if len(args) == 0 {
+		return def, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid count %q", args[0])
+	}
+	return n, nil
+}
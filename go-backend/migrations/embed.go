@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+)
+
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+// Postgres holds the PostgreSQL migrations.
+var Postgres = mustSub(files, "postgres")
+
+// SQLite holds the SQLite migrations used for local development. Every
+// version must have a counterpart in Postgres.
+var SQLite = mustSub(files, "sqlite")
+
+// ForDialect returns the migrations for a gorm dialect name.
+func ForDialect(dialect string) (fs.FS, error) {
+	switch dialect {
+	case "postgres":
+		return Postgres, nil
+	case "sqlite":
+		return SQLite, nil
+	default:
+		return nil, fmt.Errorf("no migrations for database dialect %q", dialect)
+	}
+}
+
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
@@ -0,0 +1,277 @@
+// Package migrations applies the versioned SQL migrations embedded in the
+// binary. Files are named <version>_<name>.up.sql and <version>_<name>.down.sql;
+// the applied version and a dirty flag are kept in a single-row
+// schema_migrations table, compatible with golang-migrate.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// VersionTable records the applied version and whether a migration failed part way.
+const VersionTable = "schema_migrations"
+
+var (
+	// ErrDirty means a migration failed part way; fix the schema by hand and then Force a version.
+	ErrDirty = errors.New("database schema is dirty")
+	// ErrPending means migrations exist that have not been applied.
+	ErrPending = errors.New("database schema has pending migrations")
+)
+
+// Migration is one versioned schema change.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status describes the schema relative to the known migrations.
+type Status struct {
+	Version uint
+	Dirty   bool
+	Applied []Migration
+	Pending []Migration
+}
+
+// Check returns ErrDirty or ErrPending when the schema is not fully migrated.
+func (s Status) Check() error {
+	if s.Dirty {
+		return fmt.Errorf("%w at version %d", ErrDirty, s.Version)
+	}
+	if len(s.Pending) > 0 {
+		return fmt.Errorf("%w: at version %d, latest is %d", ErrPending, s.Version, s.Pending[len(s.Pending)-1].Version)
+	}
+	return nil
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Load reads the migrations in the root of fsys, ordered by version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("migration %s: invalid version", entry.Name())
+		}
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m := byVersion[uint(version)]
+		if m == nil {
+			m = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[m.Version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrator applies migrations to a database.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New returns a Migrator for the migrations in fsys.
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Status reports the applied version and which migrations are pending.
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	version, dirty, err := m.version(ctx)
+	if err != nil {
+		return Status{}, err
+	}
+	status := Status{Version: version, Dirty: dirty}
+	for _, mig := range m.migrations {
+		if mig.Version <= version {
+			status.Applied = append(status.Applied, mig)
+		} else {
+			status.Pending = append(status.Pending, mig)
+		}
+	}
+	return status, nil
+}
+
+// Up applies up to steps pending migrations, or all of them when steps <= 0,
+// and returns how many were applied.
+func (m *Migrator) Up(ctx context.Context, steps int) (int, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if status.Dirty {
+		return 0, status.Check()
+	}
+
+	applied := 0
+	for _, mig := range status.Pending {
+		if steps > 0 && applied == steps {
+			break
+		}
+		if err := m.run(ctx, mig.Version, mig.Up, mig.Version); err != nil {
+			return applied, fmt.Errorf("migration %d_%s up: %w", mig.Version, mig.Name, err)
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+// Down reverts the last steps applied migrations and returns how many were reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if status.Dirty {
+		return 0, status.Check()
+	}
+
+	reverted := 0
+	for i := len(status.Applied) - 1; i >= 0 && reverted < steps; i-- {
+		mig := status.Applied[i]
+		if mig.Down == "" {
+			return reverted, fmt.Errorf("migration %d_%s has no down file", mig.Version, mig.Name)
+		}
+		var previous uint
+		if i > 0 {
+			previous = status.Applied[i-1].Version
+		}
+		if err := m.run(ctx, mig.Version, mig.Down, previous); err != nil {
+			return reverted, fmt.Errorf("migration %d_%s down: %w", mig.Version, mig.Name, err)
+		}
+		reverted++
+	}
+	return reverted, nil
+}
+
+// Force records version as applied and clears the dirty flag without running
+// any migration, after a failed migration has been repaired by hand. Version
+// 0 marks the schema as empty.
+func (m *Migrator) Force(ctx context.Context, version uint) error {
+	if version != 0 && !m.known(version) {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+	if err := m.ensureTable(ctx); err != nil {
+		return err
+	}
+	return m.setVersion(ctx, version, false)
+}
+
+// run marks the schema dirty at version, executes script in a transaction
+// and records target as the clean version. A failure leaves the dirty flag set.
+func (m *Migrator) run(ctx context.Context, version uint, script string, target uint) error {
+	if err := m.setVersion(ctx, version, true); err != nil {
+		return err
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return m.setVersion(ctx, target, false)
+}
+
+func (m *Migrator) known(version uint) bool {
+	for _, mig := range m.migrations {
+		if mig.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+VersionTable+" (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)")
+	return err
+}
+
+func (m *Migrator) version(ctx context.Context) (uint, bool, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return 0, false, err
+	}
+	var version int64
+	var dirty bool
+	err := m.db.QueryRowContext(ctx, "SELECT version, dirty FROM "+VersionTable+" LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(version), dirty, nil
+}
+
+// setVersion replaces the single version row. Values are formatted inline
+// because the driver placeholder syntax differs between dialects.
+func (m *Migrator) setVersion(ctx context.Context, version uint, dirty bool) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM "+VersionTable); err != nil {
+		return err
+	}
+	if version != 0 || dirty {
+		stmt := fmt.Sprintf("INSERT INTO %s (version, dirty) VALUES (%d, %t)", VersionTable, version, dirty)
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// String returns the file name prefix of mig, such as 0001_initial_schema.
+func (mig Migration) String() string {
+	return fmt.Sprintf("%04d_%s", mig.Version, mig.Name)
+}
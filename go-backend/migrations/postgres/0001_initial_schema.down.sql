@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS follow_ups;
+DROP TABLE IF EXISTS invoices;
+DROP TABLE IF EXISTS quotes;
+DROP TABLE IF EXISTS dispatches;
+DROP TABLE IF EXISTS orders;
+DROP TABLE IF EXISTS carriers;
+DROP TABLE IF EXISTS customers;
+DROP TABLE IF EXISTS leads;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,14 @@
+ALTER TABLE invoices DROP COLUMN IF EXISTS currency;
+ALTER TABLE invoices ALTER COLUMN amount TYPE numeric;
+
+ALTER TABLE quotes DROP COLUMN IF EXISTS currency;
+ALTER TABLE quotes ALTER COLUMN quoted_rate TYPE numeric;
+
+ALTER TABLE dispatches DROP COLUMN IF EXISTS currency;
+ALTER TABLE dispatches ALTER COLUMN carrier_rate TYPE numeric;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS currency;
+ALTER TABLE orders ALTER COLUMN customer_rate TYPE numeric;
+
+ALTER TABLE customers DROP COLUMN IF EXISTS currency;
+ALTER TABLE customers ALTER COLUMN credit_limit TYPE numeric;
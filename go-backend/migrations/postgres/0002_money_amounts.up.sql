@@ -0,0 +1,18 @@
+-- Store money as exact NUMERIC(14,2) amounts with a currency per record.
+-- Existing values are rounded to the cent in numeric arithmetic, never
+-- through a float, so totals don't drift.
+
+ALTER TABLE customers ALTER COLUMN credit_limit TYPE numeric(14,2) USING round(credit_limit::numeric, 2);
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS currency varchar(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE orders ALTER COLUMN customer_rate TYPE numeric(14,2) USING round(customer_rate::numeric, 2);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency varchar(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE dispatches ALTER COLUMN carrier_rate TYPE numeric(14,2) USING round(carrier_rate::numeric, 2);
+ALTER TABLE dispatches ADD COLUMN IF NOT EXISTS currency varchar(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE quotes ALTER COLUMN quoted_rate TYPE numeric(14,2) USING round(quoted_rate::numeric, 2);
+ALTER TABLE quotes ADD COLUMN IF NOT EXISTS currency varchar(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE invoices ALTER COLUMN amount TYPE numeric(14,2) USING round(amount::numeric, 2);
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS currency varchar(3) NOT NULL DEFAULT 'USD';
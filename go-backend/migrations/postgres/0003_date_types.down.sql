@@ -0,0 +1,34 @@
+SET LOCAL TIME ZONE 'UTC';
+SET LOCAL datestyle = 'ISO, MDY';
+
+DROP INDEX IF EXISTS idx_invoices_due_date;
+DROP INDEX IF EXISTS idx_orders_pickup_date;
+
+ALTER TABLE dispatches DROP COLUMN IF EXISTS delivery_time_zone;
+ALTER TABLE dispatches DROP COLUMN IF EXISTS pickup_time_zone;
+
+ALTER TABLE follow_ups ALTER COLUMN completed_at TYPE text;
+ALTER TABLE invoices ALTER COLUMN paid_date TYPE text;
+ALTER TABLE invoices ALTER COLUMN due_date TYPE text;
+ALTER TABLE quotes ALTER COLUMN valid_until TYPE text;
+ALTER TABLE quotes ALTER COLUMN pickup_date TYPE text;
+ALTER TABLE dispatches ALTER COLUMN actual_delivery_time TYPE text;
+ALTER TABLE dispatches ALTER COLUMN estimated_delivery_time TYPE text;
+ALTER TABLE dispatches ALTER COLUMN actual_pickup_time TYPE text;
+ALTER TABLE dispatches ALTER COLUMN estimated_pickup_time TYPE text;
+ALTER TABLE orders ALTER COLUMN delivery_date TYPE text;
+ALTER TABLE orders ALTER COLUMN pickup_date TYPE text;
+ALTER TABLE carriers ALTER COLUMN insurance_expiry TYPE text;
+ALTER TABLE leads ALTER COLUMN pickup_date TYPE text;
+
+-- Put back the values that couldn't be parsed.
+DO $$
+DECLARE
+    r record;
+BEGIN
+    FOR r IN SELECT * FROM unparsed_dates LOOP
+        EXECUTE format('UPDATE %I SET %I = %L WHERE id = %s', r.table_name, r.column_name, r.value, r.row_id);
+    END LOOP;
+END $$;
+
+DROP TABLE IF EXISTS unparsed_dates;
@@ -0,0 +1,67 @@
+-- Convert the free-form text dates and times kept by the Node.js backend to
+-- DATE and TIMESTAMPTZ. Parsing is lenient: anything Postgres reads as a date
+-- or time is converted (2025-07-01, 07/01/2025, Jul 1 2025, 2025-07-01 08:00,
+-- ...). Times without an offset are taken as UTC. Values that can't be read
+-- are copied to unparsed_dates first so nothing is lost; required dates then
+-- fall back to the day the record was created.
+
+SET LOCAL TIME ZONE 'UTC';
+SET LOCAL datestyle = 'ISO, MDY';
+
+CREATE TABLE IF NOT EXISTS unparsed_dates (
+    table_name  text   NOT NULL,
+    row_id      bigint NOT NULL,
+    column_name text   NOT NULL,
+    value       text   NOT NULL
+);
+
+CREATE FUNCTION pg_temp.parse_date(value text) RETURNS date LANGUAGE plpgsql AS $$
+BEGIN
+    RETURN nullif(btrim(value), '')::date;
+EXCEPTION WHEN others THEN
+    RETURN NULL;
+END $$;
+
+CREATE FUNCTION pg_temp.parse_timestamptz(value text) RETURNS timestamptz LANGUAGE plpgsql AS $$
+BEGIN
+    RETURN nullif(btrim(value), '')::timestamptz;
+EXCEPTION WHEN others THEN
+    RETURN NULL;
+END $$;
+
+-- convert changes tbl.col to typ (date or timestamptz). When required, rows
+-- that can't be parsed get their creation date.
+CREATE FUNCTION pg_temp.convert(tbl text, col text, typ text, required boolean) RETURNS void LANGUAGE plpgsql AS $$
+BEGIN
+    EXECUTE format(
+        'INSERT INTO unparsed_dates (table_name, row_id, column_name, value)
+         SELECT %L, id, %L, %I FROM %I WHERE btrim(%I) <> %L AND pg_temp.parse_%s(%I) IS NULL',
+        tbl, col, col, tbl, col, '', typ, col);
+    IF required THEN
+        EXECUTE format(
+            'UPDATE %I SET %I = coalesce(created_at, now())::date::text WHERE pg_temp.parse_%s(%I) IS NULL',
+            tbl, col, typ, col);
+    END IF;
+    EXECUTE format('ALTER TABLE %I ALTER COLUMN %I TYPE %s USING pg_temp.parse_%s(%I)', tbl, col, typ, typ, col);
+END $$;
+
+SELECT pg_temp.convert('leads', 'pickup_date', 'date', false);
+SELECT pg_temp.convert('carriers', 'insurance_expiry', 'date', false);
+SELECT pg_temp.convert('orders', 'pickup_date', 'date', true);
+SELECT pg_temp.convert('orders', 'delivery_date', 'date', false);
+SELECT pg_temp.convert('dispatches', 'estimated_pickup_time', 'timestamptz', false);
+SELECT pg_temp.convert('dispatches', 'actual_pickup_time', 'timestamptz', false);
+SELECT pg_temp.convert('dispatches', 'estimated_delivery_time', 'timestamptz', false);
+SELECT pg_temp.convert('dispatches', 'actual_delivery_time', 'timestamptz', false);
+SELECT pg_temp.convert('quotes', 'pickup_date', 'date', false);
+SELECT pg_temp.convert('quotes', 'valid_until', 'date', true);
+SELECT pg_temp.convert('invoices', 'due_date', 'date', true);
+SELECT pg_temp.convert('invoices', 'paid_date', 'date', false);
+SELECT pg_temp.convert('follow_ups', 'completed_at', 'timestamptz', false);
+
+-- IANA zones of the pickup and delivery locations, such as America/Chicago.
+ALTER TABLE dispatches ADD COLUMN IF NOT EXISTS pickup_time_zone text;
+ALTER TABLE dispatches ADD COLUMN IF NOT EXISTS delivery_time_zone text;
+
+CREATE INDEX IF NOT EXISTS idx_orders_pickup_date ON orders (pickup_date);
+CREATE INDEX IF NOT EXISTS idx_invoices_due_date ON invoices (due_date);
@@ -0,0 +1,40 @@
+-- Dispatches moved aside by the up migration are restored. References that
+-- it cleared stay cleared.
+
+DROP INDEX IF EXISTS idx_follow_ups_order_id;
+DROP INDEX IF EXISTS idx_follow_ups_carrier_id;
+DROP INDEX IF EXISTS idx_follow_ups_customer_id;
+DROP INDEX IF EXISTS idx_follow_ups_lead_id;
+DROP INDEX IF EXISTS idx_invoices_dispatch_id;
+DROP INDEX IF EXISTS idx_invoices_order_id;
+DROP INDEX IF EXISTS idx_invoices_carrier_id;
+DROP INDEX IF EXISTS idx_invoices_customer_id;
+DROP INDEX IF EXISTS idx_quotes_customer_id;
+DROP INDEX IF EXISTS idx_quotes_lead_id;
+DROP INDEX IF EXISTS idx_dispatches_carrier_id;
+DROP INDEX IF EXISTS idx_dispatches_order_id;
+DROP INDEX IF EXISTS idx_orders_lead_id;
+DROP INDEX IF EXISTS idx_orders_customer_id;
+
+ALTER TABLE follow_ups
+    DROP CONSTRAINT IF EXISTS fk_follow_ups_order,
+    DROP CONSTRAINT IF EXISTS fk_follow_ups_carrier,
+    DROP CONSTRAINT IF EXISTS fk_follow_ups_customer,
+    DROP CONSTRAINT IF EXISTS fk_follow_ups_lead;
+ALTER TABLE invoices
+    DROP CONSTRAINT IF EXISTS fk_invoices_dispatch,
+    DROP CONSTRAINT IF EXISTS fk_invoices_order,
+    DROP CONSTRAINT IF EXISTS fk_invoices_carrier,
+    DROP CONSTRAINT IF EXISTS fk_invoices_customer;
+ALTER TABLE quotes
+    DROP CONSTRAINT IF EXISTS fk_quotes_customer,
+    DROP CONSTRAINT IF EXISTS fk_quotes_lead;
+ALTER TABLE dispatches
+    DROP CONSTRAINT IF EXISTS fk_dispatches_carrier,
+    DROP CONSTRAINT IF EXISTS fk_dispatches_order;
+ALTER TABLE orders
+    DROP CONSTRAINT IF EXISTS fk_orders_lead,
+    DROP CONSTRAINT IF EXISTS fk_orders_customer;
+
+INSERT INTO dispatches SELECT * FROM orphaned_dispatches;
+DROP TABLE orphaned_dispatches;
@@ -0,0 +1,63 @@
+-- Foreign keys for every relation between the models, with the delete
+-- behavior declared on each relation in models.go, plus an index on every
+-- foreign key column.
+--
+-- References to records that no longer exist are cleaned up first.
+-- Dispatches can't exist without their order and carrier, so orphaned ones
+-- are moved to orphaned_dispatches for review; other dangling references are
+-- cleared, including invoice links to the dispatches moved aside.
+
+CREATE TABLE orphaned_dispatches (LIKE dispatches INCLUDING DEFAULTS);
+INSERT INTO orphaned_dispatches
+SELECT * FROM dispatches d
+WHERE (d.order_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM orders o WHERE o.id = d.order_id))
+   OR (d.carrier_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM carriers c WHERE c.id = d.carrier_id));
+DELETE FROM dispatches WHERE id IN (SELECT id FROM orphaned_dispatches);
+
+UPDATE orders SET customer_id = NULL WHERE customer_id NOT IN (SELECT id FROM customers);
+UPDATE orders SET lead_id = NULL WHERE lead_id NOT IN (SELECT id FROM leads);
+UPDATE quotes SET lead_id = NULL WHERE lead_id NOT IN (SELECT id FROM leads);
+UPDATE quotes SET customer_id = NULL WHERE customer_id NOT IN (SELECT id FROM customers);
+UPDATE invoices SET customer_id = NULL WHERE customer_id NOT IN (SELECT id FROM customers);
+UPDATE invoices SET carrier_id = NULL WHERE carrier_id NOT IN (SELECT id FROM carriers);
+UPDATE invoices SET order_id = NULL WHERE order_id NOT IN (SELECT id FROM orders);
+UPDATE invoices SET dispatch_id = NULL WHERE dispatch_id NOT IN (SELECT id FROM dispatches);
+UPDATE follow_ups SET lead_id = NULL WHERE lead_id NOT IN (SELECT id FROM leads);
+UPDATE follow_ups SET customer_id = NULL WHERE customer_id NOT IN (SELECT id FROM customers);
+UPDATE follow_ups SET carrier_id = NULL WHERE carrier_id NOT IN (SELECT id FROM carriers);
+UPDATE follow_ups SET order_id = NULL WHERE order_id NOT IN (SELECT id FROM orders);
+
+ALTER TABLE orders
+    ADD CONSTRAINT fk_orders_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE RESTRICT,
+    ADD CONSTRAINT fk_orders_lead FOREIGN KEY (lead_id) REFERENCES leads (id) ON DELETE SET NULL;
+ALTER TABLE dispatches
+    ADD CONSTRAINT fk_dispatches_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_dispatches_carrier FOREIGN KEY (carrier_id) REFERENCES carriers (id) ON DELETE RESTRICT;
+ALTER TABLE quotes
+    ADD CONSTRAINT fk_quotes_lead FOREIGN KEY (lead_id) REFERENCES leads (id) ON DELETE SET NULL,
+    ADD CONSTRAINT fk_quotes_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE SET NULL;
+ALTER TABLE invoices
+    ADD CONSTRAINT fk_invoices_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE RESTRICT,
+    ADD CONSTRAINT fk_invoices_carrier FOREIGN KEY (carrier_id) REFERENCES carriers (id) ON DELETE RESTRICT,
+    ADD CONSTRAINT fk_invoices_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE RESTRICT,
+    ADD CONSTRAINT fk_invoices_dispatch FOREIGN KEY (dispatch_id) REFERENCES dispatches (id) ON DELETE RESTRICT;
+ALTER TABLE follow_ups
+    ADD CONSTRAINT fk_follow_ups_lead FOREIGN KEY (lead_id) REFERENCES leads (id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_follow_ups_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_follow_ups_carrier FOREIGN KEY (carrier_id) REFERENCES carriers (id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_follow_ups_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_orders_customer_id ON orders (customer_id);
+CREATE INDEX IF NOT EXISTS idx_orders_lead_id ON orders (lead_id);
+CREATE INDEX IF NOT EXISTS idx_dispatches_order_id ON dispatches (order_id);
+CREATE INDEX IF NOT EXISTS idx_dispatches_carrier_id ON dispatches (carrier_id);
+CREATE INDEX IF NOT EXISTS idx_quotes_lead_id ON quotes (lead_id);
+CREATE INDEX IF NOT EXISTS idx_quotes_customer_id ON quotes (customer_id);
+CREATE INDEX IF NOT EXISTS idx_invoices_customer_id ON invoices (customer_id);
+CREATE INDEX IF NOT EXISTS idx_invoices_carrier_id ON invoices (carrier_id);
+CREATE INDEX IF NOT EXISTS idx_invoices_order_id ON invoices (order_id);
+CREATE INDEX IF NOT EXISTS idx_invoices_dispatch_id ON invoices (dispatch_id);
+CREATE INDEX IF NOT EXISTS idx_follow_ups_lead_id ON follow_ups (lead_id);
+CREATE INDEX IF NOT EXISTS idx_follow_ups_customer_id ON follow_ups (customer_id);
+CREATE INDEX IF NOT EXISTS idx_follow_ups_carrier_id ON follow_ups (carrier_id);
+CREATE INDEX IF NOT EXISTS idx_follow_ups_order_id ON follow_ups (order_id);
@@ -0,0 +1,7 @@
+-- Archived records are dropped with their tables; restore any that should be
+-- kept before rolling back.
+
+DROP TABLE IF EXISTS archived_follow_ups;
+DROP TABLE IF EXISTS archived_invoices;
+DROP TABLE IF EXISTS archived_dispatches;
+DROP TABLE IF EXISTS archived_orders;
@@ -0,0 +1,59 @@
+-- Archive tables for closed orders. The archival job moves an order with its
+-- dispatches, invoices and follow-ups here once it is paid and closed; the
+-- records keep their IDs so they can be restored. Each table has the columns
+-- of its live table plus archived_at, so a migration adding a column to
+-- orders, dispatches, invoices or follow_ups must add it here too.
+--
+-- Foreign keys mirror the live tables: references between archived records
+-- point at the archive tables, references to customers, carriers and leads
+-- point at the live ones with the same delete behavior.
+
+CREATE TABLE archived_orders (LIKE orders INCLUDING DEFAULTS);
+CREATE TABLE archived_dispatches (LIKE dispatches INCLUDING DEFAULTS);
+CREATE TABLE archived_invoices (LIKE invoices INCLUDING DEFAULTS);
+CREATE TABLE archived_follow_ups (LIKE follow_ups INCLUDING DEFAULTS);
+
+-- IDs come from the live tables, not a sequence of their own.
+ALTER TABLE archived_orders
+    ALTER COLUMN id DROP DEFAULT,
+    ADD PRIMARY KEY (id),
+    ADD COLUMN archived_at timestamptz NOT NULL DEFAULT now(),
+    ADD CONSTRAINT fk_archived_orders_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE RESTRICT,
+    ADD CONSTRAINT fk_archived_orders_lead FOREIGN KEY (lead_id) REFERENCES leads (id) ON DELETE SET NULL;
+ALTER TABLE archived_dispatches
+    ALTER COLUMN id DROP DEFAULT,
+    ADD PRIMARY KEY (id),
+    ADD COLUMN archived_at timestamptz NOT NULL DEFAULT now(),
+    ADD CONSTRAINT fk_archived_dispatches_order FOREIGN KEY (order_id) REFERENCES archived_orders (id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_archived_dispatches_carrier FOREIGN KEY (carrier_id) REFERENCES carriers (id) ON DELETE RESTRICT;
+ALTER TABLE archived_invoices
+    ALTER COLUMN id DROP DEFAULT,
+    ADD PRIMARY KEY (id),
+    ADD COLUMN archived_at timestamptz NOT NULL DEFAULT now(),
+    ADD CONSTRAINT fk_archived_invoices_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE RESTRICT,
+    ADD CONSTRAINT fk_archived_invoices_carrier FOREIGN KEY (carrier_id) REFERENCES carriers (id) ON DELETE RESTRICT,
+    ADD CONSTRAINT fk_archived_invoices_order FOREIGN KEY (order_id) REFERENCES archived_orders (id) ON DELETE RESTRICT,
+    ADD CONSTRAINT fk_archived_invoices_dispatch FOREIGN KEY (dispatch_id) REFERENCES archived_dispatches (id) ON DELETE RESTRICT;
+ALTER TABLE archived_follow_ups
+    ALTER COLUMN id DROP DEFAULT,
+    ADD PRIMARY KEY (id),
+    ADD COLUMN archived_at timestamptz NOT NULL DEFAULT now(),
+    ADD CONSTRAINT fk_archived_follow_ups_lead FOREIGN KEY (lead_id) REFERENCES leads (id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_archived_follow_ups_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_archived_follow_ups_carrier FOREIGN KEY (carrier_id) REFERENCES carriers (id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_archived_follow_ups_order FOREIGN KEY (order_id) REFERENCES archived_orders (id) ON DELETE CASCADE;
+
+CREATE INDEX idx_archived_orders_order_number ON archived_orders (order_number);
+CREATE INDEX idx_archived_orders_archived_at ON archived_orders (archived_at);
+CREATE INDEX idx_archived_orders_customer_id ON archived_orders (customer_id);
+CREATE INDEX idx_archived_orders_lead_id ON archived_orders (lead_id);
+CREATE INDEX idx_archived_dispatches_order_id ON archived_dispatches (order_id);
+CREATE INDEX idx_archived_dispatches_carrier_id ON archived_dispatches (carrier_id);
+CREATE INDEX idx_archived_invoices_customer_id ON archived_invoices (customer_id);
+CREATE INDEX idx_archived_invoices_carrier_id ON archived_invoices (carrier_id);
+CREATE INDEX idx_archived_invoices_order_id ON archived_invoices (order_id);
+CREATE INDEX idx_archived_invoices_dispatch_id ON archived_invoices (dispatch_id);
+CREATE INDEX idx_archived_follow_ups_lead_id ON archived_follow_ups (lead_id);
+CREATE INDEX idx_archived_follow_ups_customer_id ON archived_follow_ups (customer_id);
+CREATE INDEX idx_archived_follow_ups_carrier_id ON archived_follow_ups (carrier_id);
+CREATE INDEX idx_archived_follow_ups_order_id ON archived_follow_ups (order_id);
@@ -0,0 +1,4 @@
+-- The audit log is dropped with its table.
+
+DROP TABLE IF EXISTS audit_log;
+DROP FUNCTION IF EXISTS audit_log_append_only();
@@ -0,0 +1,31 @@
+-- Append-only log of every change made through the API: who made it, when,
+-- and the fields it changed. Entries are written in the same transaction as
+-- the change. A trigger refuses updates and deletes, so entries can't be
+-- rewritten after the fact.
+
+CREATE TABLE audit_log (
+    id          bigserial PRIMARY KEY,
+    occurred_at timestamptz NOT NULL,
+    user_id     text        NOT NULL,
+    request_id  text,
+    entity      text        NOT NULL,
+    entity_id   text        NOT NULL,
+    action      text        NOT NULL,
+    changes     jsonb
+);
+CREATE INDEX idx_audit_log_entity ON audit_log (entity, entity_id);
+CREATE INDEX idx_audit_log_user_id ON audit_log (user_id);
+CREATE INDEX idx_audit_log_occurred_at ON audit_log (occurred_at);
+
+CREATE FUNCTION audit_log_append_only() RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+CREATE TRIGGER audit_log_no_truncate
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
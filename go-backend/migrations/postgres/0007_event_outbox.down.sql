@@ -0,0 +1,6 @@
+-- Undelivered events and dead letters are dropped with the outbox.
+
+DROP TABLE IF EXISTS event_dead_letters;
+DROP TABLE IF EXISTS event_deliveries;
+DROP TABLE IF EXISTS event_cursors;
+DROP TABLE IF EXISTS event_outbox;
@@ -0,0 +1,45 @@
+-- Domain events, written in the same transaction as the change they describe
+-- and delivered to subscribers afterwards. event_cursors registers each
+-- subscriber, and event_deliveries tracks its progress with each event:
+-- event IDs are taken before their transaction commits, so an event can
+-- become visible after later ones, and a position in the outbox would skip
+-- it. Events a subscriber gave up on are kept in event_dead_letters until
+-- retried.
+
+CREATE TABLE event_outbox (
+    id          bigserial   PRIMARY KEY,
+    type        text        NOT NULL,
+    occurred_at timestamptz NOT NULL,
+    user_id     text        NOT NULL,
+    request_id  text,
+    payload     jsonb       NOT NULL
+);
+CREATE INDEX idx_event_outbox_occurred_at ON event_outbox (occurred_at);
+
+CREATE TABLE event_cursors (
+    subscriber   text        PRIMARY KEY,
+    locked_by    text,
+    locked_until timestamptz,
+    updated_at   timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE event_deliveries (
+    subscriber text        NOT NULL REFERENCES event_cursors (subscriber) ON DELETE CASCADE,
+    event_id   bigint      NOT NULL REFERENCES event_outbox (id) ON DELETE CASCADE,
+    attempts   integer     NOT NULL DEFAULT 0,
+    last_error text,
+    handled_at timestamptz,
+    PRIMARY KEY (subscriber, event_id)
+);
+CREATE INDEX idx_event_deliveries_event_id ON event_deliveries (event_id);
+
+CREATE TABLE event_dead_letters (
+    id         bigserial   PRIMARY KEY,
+    subscriber text        NOT NULL REFERENCES event_cursors (subscriber) ON DELETE CASCADE,
+    event_id   bigint      NOT NULL REFERENCES event_outbox (id),
+    attempts   integer     NOT NULL,
+    last_error text        NOT NULL,
+    failed_at  timestamptz NOT NULL,
+    UNIQUE (subscriber, event_id)
+);
+CREATE INDEX idx_event_dead_letters_event_id ON event_dead_letters (event_id);
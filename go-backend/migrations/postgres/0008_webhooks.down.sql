@@ -0,0 +1,4 @@
+-- Subscriptions and their delivery log are dropped.
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
@@ -0,0 +1,39 @@
+-- Webhook subscriptions of customers and API clients, and the log of events
+-- delivered to them. A subscription belongs to exactly one of the two. Each
+-- event is queued at most once per subscription.
+
+CREATE TABLE webhook_subscriptions (
+    id                   bigserial   PRIMARY KEY,
+    customer_id          bigint      REFERENCES customers (id) ON DELETE CASCADE,
+    client_name          text,
+    url                  text        NOT NULL,
+    secret               text        NOT NULL,
+    description          text,
+    event_types          text        NOT NULL DEFAULT '',
+    active               boolean     NOT NULL DEFAULT true,
+    consecutive_failures integer     NOT NULL DEFAULT 0,
+    disabled_at          timestamptz,
+    disabled_reason      text,
+    created_at           timestamptz NOT NULL DEFAULT now(),
+    updated_at           timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT chk_webhook_subscriptions_owner CHECK ((customer_id IS NULL) <> (client_name IS NULL))
+);
+CREATE INDEX idx_webhook_subscriptions_customer_id ON webhook_subscriptions (customer_id);
+
+CREATE TABLE webhook_deliveries (
+    id              bigserial   PRIMARY KEY,
+    subscription_id bigint      NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id        bigint      NOT NULL,
+    event_type      text        NOT NULL,
+    payload         jsonb       NOT NULL,
+    status          text        NOT NULL DEFAULT 'pending',
+    attempts        integer     NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz,
+    last_attempt_at timestamptz,
+    response_status integer,
+    last_error      text,
+    delivered_at    timestamptz,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT uq_webhook_deliveries_event UNIQUE (subscription_id, event_id)
+);
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
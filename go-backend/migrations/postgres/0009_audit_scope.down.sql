@@ -0,0 +1,4 @@
+-- The tenants and customers recorded on audit entries are dropped.
+
+ALTER TABLE audit_log DROP COLUMN customer_id;
+ALTER TABLE audit_log DROP COLUMN tenant_id;
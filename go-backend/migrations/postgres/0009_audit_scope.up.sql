@@ -0,0 +1,9 @@
+-- Records whose business each change is, so change streams only show it to
+-- those allowed to see it. Entries written before this have neither.
+--   tenant_id:   the tenant the change was made for; a tenant only sees its
+--                own changes.
+--   customer_id: the customer owning the changed record; users acting for a
+--                customer only see changes to its records.
+
+ALTER TABLE audit_log ADD COLUMN tenant_id text;
+ALTER TABLE audit_log ADD COLUMN customer_id bigint;
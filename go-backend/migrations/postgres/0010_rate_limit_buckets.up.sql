@@ -0,0 +1,10 @@
+-- Token buckets for the Postgres rate limit store, shared by every API
+-- instance. Deployments that ran the store before this migration already
+-- have the table.
+
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    key        varchar(255)     PRIMARY KEY,
+    tokens     double precision NOT NULL,
+    updated_at timestamptz      NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at ON rate_limit_buckets (updated_at);
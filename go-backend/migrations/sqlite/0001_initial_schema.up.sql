@@ -0,0 +1,191 @@
+-- Baseline schema for local development on SQLite. Keep it equivalent to
+-- postgres/0001_initial_schema.up.sql.
+
+CREATE TABLE IF NOT EXISTS users (
+    id                text PRIMARY KEY,
+    username          text NOT NULL,
+    password          text NOT NULL,
+    email             text,
+    first_name        text,
+    last_name         text,
+    profile_image_url text,
+    role              text,
+    created_at        datetime,
+    updated_at        datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username ON users (username);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+CREATE TABLE IF NOT EXISTS leads (
+    id                integer PRIMARY KEY AUTOINCREMENT,
+    company_name      text NOT NULL,
+    contact_person    text NOT NULL,
+    email             text NOT NULL,
+    phone             text NOT NULL,
+    origin_city       text,
+    origin_state      text,
+    destination_city  text,
+    destination_state text,
+    pickup_date       text,
+    equipment_type    text,
+    commodity         text,
+    weight            integer,
+    notes             text,
+    status            text DEFAULT 'new',
+    created_at        datetime,
+    updated_at        datetime
+);
+
+CREATE TABLE IF NOT EXISTS customers (
+    id                   integer PRIMARY KEY AUTOINCREMENT,
+    company_name         text NOT NULL,
+    contact_person       text NOT NULL,
+    email                text NOT NULL,
+    phone                text NOT NULL,
+    address              text,
+    city                 text,
+    state                text,
+    zip_code             text,
+    billing_address      text,
+    billing_city         text,
+    billing_state        text,
+    billing_zip_code     text,
+    credit_limit         numeric,
+    payment_terms        text DEFAULT 'Net 30',
+    special_instructions text,
+    is_active            boolean DEFAULT true,
+    created_at           datetime,
+    updated_at           datetime
+);
+
+CREATE TABLE IF NOT EXISTS carriers (
+    id                 integer PRIMARY KEY AUTOINCREMENT,
+    company_name       text NOT NULL,
+    contact_person     text NOT NULL,
+    email              text NOT NULL,
+    phone              text NOT NULL,
+    address            text,
+    city               text,
+    state              text,
+    zip_code           text,
+    mc_number          text,
+    dot_number         text,
+    insurance_expiry   text,
+    w9_on_file         boolean DEFAULT false,
+    performance_rating numeric DEFAULT 0.00,
+    preferred_lanes    text,
+    equipment_types    text,
+    notes              text,
+    is_active          boolean DEFAULT true,
+    created_at         datetime,
+    updated_at         datetime
+);
+
+CREATE TABLE IF NOT EXISTS orders (
+    id                   integer PRIMARY KEY AUTOINCREMENT,
+    order_number         text NOT NULL,
+    customer_id          integer,
+    customer_name        text,
+    lead_id              integer,
+    origin_company       text,
+    origin_address       text NOT NULL,
+    origin_city          text NOT NULL,
+    origin_state         text NOT NULL,
+    origin_zip_code      text NOT NULL,
+    destination_company  text,
+    destination_address  text NOT NULL,
+    destination_city     text NOT NULL,
+    destination_state    text NOT NULL,
+    destination_zip_code text NOT NULL,
+    pickup_date          text NOT NULL,
+    delivery_date        text,
+    equipment_type       text NOT NULL,
+    weight               numeric,
+    commodity            text,
+    customer_rate        numeric NOT NULL,
+    status               text DEFAULT 'needs_truck',
+    special_instructions text,
+    created_at           datetime,
+    updated_at           datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_order_number ON orders (order_number);
+
+CREATE TABLE IF NOT EXISTS dispatches (
+    id                       integer PRIMARY KEY AUTOINCREMENT,
+    order_id                 integer,
+    carrier_id               integer,
+    carrier_rate             numeric NOT NULL,
+    driver_name              text,
+    driver_phone             text,
+    truck_number             text,
+    trailer_number           text,
+    status                   text DEFAULT 'assigned',
+    rate_confirmation_sent   boolean DEFAULT false,
+    rate_confirmation_signed boolean DEFAULT false,
+    estimated_pickup_time    text,
+    actual_pickup_time       text,
+    estimated_delivery_time  text,
+    actual_delivery_time     text,
+    notes                    text,
+    created_at               datetime,
+    updated_at               datetime
+);
+
+CREATE TABLE IF NOT EXISTS quotes (
+    id                integer PRIMARY KEY AUTOINCREMENT,
+    quote_number      text NOT NULL,
+    lead_id           integer,
+    customer_id       integer,
+    origin_city       text NOT NULL,
+    origin_state      text NOT NULL,
+    destination_city  text NOT NULL,
+    destination_state text NOT NULL,
+    pickup_date       text,
+    equipment_type    text NOT NULL,
+    weight            numeric,
+    commodity         text,
+    quoted_rate       numeric NOT NULL,
+    valid_until       text NOT NULL,
+    status            text DEFAULT 'pending',
+    notes             text,
+    created_at        datetime,
+    updated_at        datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_quotes_quote_number ON quotes (quote_number);
+
+CREATE TABLE IF NOT EXISTS invoices (
+    id             integer PRIMARY KEY AUTOINCREMENT,
+    invoice_number text NOT NULL,
+    type           text NOT NULL,
+    customer_id    integer,
+    carrier_id     integer,
+    order_id       integer,
+    dispatch_id    integer,
+    amount         numeric NOT NULL,
+    status         text DEFAULT 'draft',
+    due_date       text NOT NULL,
+    paid_date      text,
+    notes          text,
+    created_at     datetime,
+    updated_at     datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_invoice_number ON invoices (invoice_number);
+
+CREATE TABLE IF NOT EXISTS follow_ups (
+    id           integer PRIMARY KEY AUTOINCREMENT,
+    title        text NOT NULL,
+    description  text,
+    type         text NOT NULL,
+    lead_id      integer,
+    customer_id  integer,
+    carrier_id   integer,
+    order_id     integer,
+    due_date     datetime NOT NULL,
+    completed    boolean DEFAULT false,
+    completed_at text,
+    priority     text DEFAULT 'medium',
+    assigned_to  text,
+    notes        text,
+    created_at   datetime,
+    updated_at   datetime
+);
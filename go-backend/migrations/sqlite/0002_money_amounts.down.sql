@@ -0,0 +1,5 @@
+ALTER TABLE invoices DROP COLUMN currency;
+ALTER TABLE quotes DROP COLUMN currency;
+ALTER TABLE dispatches DROP COLUMN currency;
+ALTER TABLE orders DROP COLUMN currency;
+ALTER TABLE customers DROP COLUMN currency;
@@ -0,0 +1,18 @@
+-- Equivalent to postgres/0002_money_amounts.up.sql. SQLite can't change a
+-- column's type, but the amount columns already have NUMERIC affinity; round
+-- what is stored to the cent and add the currencies.
+
+UPDATE customers SET credit_limit = round(credit_limit, 2) WHERE credit_limit IS NOT NULL;
+ALTER TABLE customers ADD COLUMN currency text NOT NULL DEFAULT 'USD';
+
+UPDATE orders SET customer_rate = round(customer_rate, 2);
+ALTER TABLE orders ADD COLUMN currency text NOT NULL DEFAULT 'USD';
+
+UPDATE dispatches SET carrier_rate = round(carrier_rate, 2);
+ALTER TABLE dispatches ADD COLUMN currency text NOT NULL DEFAULT 'USD';
+
+UPDATE quotes SET quoted_rate = round(quoted_rate, 2);
+ALTER TABLE quotes ADD COLUMN currency text NOT NULL DEFAULT 'USD';
+
+UPDATE invoices SET amount = round(amount, 2);
+ALTER TABLE invoices ADD COLUMN currency text NOT NULL DEFAULT 'USD';
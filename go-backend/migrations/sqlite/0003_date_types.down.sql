@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_invoices_due_date;
+DROP INDEX IF EXISTS idx_orders_pickup_date;
+
+ALTER TABLE dispatches DROP COLUMN delivery_time_zone;
+ALTER TABLE dispatches DROP COLUMN pickup_time_zone;
@@ -0,0 +1,25 @@
+-- Equivalent to postgres/0003_date_types.up.sql. SQLite has no date types,
+-- so the columns stay text: dates as YYYY-MM-DD and times in UTC, which sort
+-- and compare correctly. Values already in ISO form are normalized here;
+-- anything else is parsed leniently when read and rewritten on the next save.
+
+UPDATE leads SET pickup_date = substr(pickup_date, 1, 10) WHERE pickup_date GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*';
+UPDATE carriers SET insurance_expiry = substr(insurance_expiry, 1, 10) WHERE insurance_expiry GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*';
+UPDATE orders SET pickup_date = substr(pickup_date, 1, 10) WHERE pickup_date GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*';
+UPDATE orders SET delivery_date = substr(delivery_date, 1, 10) WHERE delivery_date GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*';
+UPDATE quotes SET pickup_date = substr(pickup_date, 1, 10) WHERE pickup_date GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*';
+UPDATE quotes SET valid_until = substr(valid_until, 1, 10) WHERE valid_until GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*';
+UPDATE invoices SET due_date = substr(due_date, 1, 10) WHERE due_date GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*';
+UPDATE invoices SET paid_date = substr(paid_date, 1, 10) WHERE paid_date GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*';
+
+UPDATE dispatches SET estimated_pickup_time = datetime(estimated_pickup_time) || '+00:00' WHERE datetime(estimated_pickup_time) IS NOT NULL;
+UPDATE dispatches SET actual_pickup_time = datetime(actual_pickup_time) || '+00:00' WHERE datetime(actual_pickup_time) IS NOT NULL;
+UPDATE dispatches SET estimated_delivery_time = datetime(estimated_delivery_time) || '+00:00' WHERE datetime(estimated_delivery_time) IS NOT NULL;
+UPDATE dispatches SET actual_delivery_time = datetime(actual_delivery_time) || '+00:00' WHERE datetime(actual_delivery_time) IS NOT NULL;
+UPDATE follow_ups SET completed_at = datetime(completed_at) || '+00:00' WHERE datetime(completed_at) IS NOT NULL;
+
+ALTER TABLE dispatches ADD COLUMN pickup_time_zone text;
+ALTER TABLE dispatches ADD COLUMN delivery_time_zone text;
+
+CREATE INDEX IF NOT EXISTS idx_orders_pickup_date ON orders (pickup_date);
+CREATE INDEX IF NOT EXISTS idx_invoices_due_date ON invoices (due_date);
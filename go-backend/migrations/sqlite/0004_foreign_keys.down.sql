@@ -0,0 +1,138 @@
+-- Rebuilds the tables without their foreign keys, children before parents,
+-- and restores the dispatches moved aside. References that the up migration
+-- cleared stay cleared.
+
+CREATE TABLE new_follow_ups (
+    id           integer PRIMARY KEY AUTOINCREMENT,
+    title        text NOT NULL,
+    description  text,
+    type         text NOT NULL,
+    lead_id      integer,
+    customer_id  integer,
+    carrier_id   integer,
+    order_id     integer,
+    due_date     datetime NOT NULL,
+    completed    boolean DEFAULT false,
+    completed_at text,
+    priority     text DEFAULT 'medium',
+    assigned_to  text,
+    notes        text,
+    created_at   datetime,
+    updated_at   datetime
+);
+INSERT INTO new_follow_ups SELECT * FROM follow_ups;
+DROP TABLE follow_ups;
+ALTER TABLE new_follow_ups RENAME TO follow_ups;
+
+CREATE TABLE new_invoices (
+    id             integer PRIMARY KEY AUTOINCREMENT,
+    invoice_number text NOT NULL,
+    type           text NOT NULL,
+    customer_id    integer,
+    carrier_id     integer,
+    order_id       integer,
+    dispatch_id    integer,
+    amount         numeric NOT NULL,
+    status         text DEFAULT 'draft',
+    due_date       text NOT NULL,
+    paid_date      text,
+    notes          text,
+    created_at     datetime,
+    updated_at     datetime,
+    currency       text NOT NULL DEFAULT 'USD'
+);
+INSERT INTO new_invoices SELECT * FROM invoices;
+DROP TABLE invoices;
+ALTER TABLE new_invoices RENAME TO invoices;
+CREATE UNIQUE INDEX idx_invoices_invoice_number ON invoices (invoice_number);
+CREATE INDEX idx_invoices_due_date ON invoices (due_date);
+
+CREATE TABLE new_quotes (
+    id                integer PRIMARY KEY AUTOINCREMENT,
+    quote_number      text NOT NULL,
+    lead_id           integer,
+    customer_id       integer,
+    origin_city       text NOT NULL,
+    origin_state      text NOT NULL,
+    destination_city  text NOT NULL,
+    destination_state text NOT NULL,
+    pickup_date       text,
+    equipment_type    text NOT NULL,
+    weight            numeric,
+    commodity         text,
+    quoted_rate       numeric NOT NULL,
+    valid_until       text NOT NULL,
+    status            text DEFAULT 'pending',
+    notes             text,
+    created_at        datetime,
+    updated_at        datetime,
+    currency          text NOT NULL DEFAULT 'USD'
+);
+INSERT INTO new_quotes SELECT * FROM quotes;
+DROP TABLE quotes;
+ALTER TABLE new_quotes RENAME TO quotes;
+CREATE UNIQUE INDEX idx_quotes_quote_number ON quotes (quote_number);
+
+CREATE TABLE new_dispatches (
+    id                       integer PRIMARY KEY AUTOINCREMENT,
+    order_id                 integer,
+    carrier_id               integer,
+    carrier_rate             numeric NOT NULL,
+    driver_name              text,
+    driver_phone             text,
+    truck_number             text,
+    trailer_number           text,
+    status                   text DEFAULT 'assigned',
+    rate_confirmation_sent   boolean DEFAULT false,
+    rate_confirmation_signed boolean DEFAULT false,
+    estimated_pickup_time    text,
+    actual_pickup_time       text,
+    estimated_delivery_time  text,
+    actual_delivery_time     text,
+    notes                    text,
+    created_at               datetime,
+    updated_at               datetime,
+    currency                 text NOT NULL DEFAULT 'USD',
+    pickup_time_zone         text,
+    delivery_time_zone       text
+);
+INSERT INTO new_dispatches SELECT * FROM dispatches;
+DROP TABLE dispatches;
+ALTER TABLE new_dispatches RENAME TO dispatches;
+
+CREATE TABLE new_orders (
+    id                   integer PRIMARY KEY AUTOINCREMENT,
+    order_number         text NOT NULL,
+    customer_id          integer,
+    customer_name        text,
+    lead_id              integer,
+    origin_company       text,
+    origin_address       text NOT NULL,
+    origin_city          text NOT NULL,
+    origin_state         text NOT NULL,
+    origin_zip_code      text NOT NULL,
+    destination_company  text,
+    destination_address  text NOT NULL,
+    destination_city     text NOT NULL,
+    destination_state    text NOT NULL,
+    destination_zip_code text NOT NULL,
+    pickup_date          text NOT NULL,
+    delivery_date        text,
+    equipment_type       text NOT NULL,
+    weight               numeric,
+    commodity            text,
+    customer_rate        numeric NOT NULL,
+    status               text DEFAULT 'needs_truck',
+    special_instructions text,
+    created_at           datetime,
+    updated_at           datetime,
+    currency             text NOT NULL DEFAULT 'USD'
+);
+INSERT INTO new_orders SELECT * FROM orders;
+DROP TABLE orders;
+ALTER TABLE new_orders RENAME TO orders;
+CREATE UNIQUE INDEX idx_orders_order_number ON orders (order_number);
+CREATE INDEX idx_orders_pickup_date ON orders (pickup_date);
+
+INSERT INTO dispatches SELECT * FROM orphaned_dispatches;
+DROP TABLE orphaned_dispatches;
@@ -0,0 +1,183 @@
+-- Equivalent to postgres/0004_foreign_keys.up.sql. SQLite can't add a
+-- constraint to an existing table, so the tables holding foreign keys are
+-- rebuilt with them, parents before children. Column types and order are
+-- unchanged.
+
+CREATE TABLE orphaned_dispatches AS
+SELECT * FROM dispatches d
+WHERE (d.order_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM orders o WHERE o.id = d.order_id))
+   OR (d.carrier_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM carriers c WHERE c.id = d.carrier_id));
+DELETE FROM dispatches WHERE id IN (SELECT id FROM orphaned_dispatches);
+
+UPDATE orders SET customer_id = NULL WHERE customer_id NOT IN (SELECT id FROM customers);
+UPDATE orders SET lead_id = NULL WHERE lead_id NOT IN (SELECT id FROM leads);
+UPDATE quotes SET lead_id = NULL WHERE lead_id NOT IN (SELECT id FROM leads);
+UPDATE quotes SET customer_id = NULL WHERE customer_id NOT IN (SELECT id FROM customers);
+UPDATE invoices SET customer_id = NULL WHERE customer_id NOT IN (SELECT id FROM customers);
+UPDATE invoices SET carrier_id = NULL WHERE carrier_id NOT IN (SELECT id FROM carriers);
+UPDATE invoices SET order_id = NULL WHERE order_id NOT IN (SELECT id FROM orders);
+UPDATE invoices SET dispatch_id = NULL WHERE dispatch_id NOT IN (SELECT id FROM dispatches);
+UPDATE follow_ups SET lead_id = NULL WHERE lead_id NOT IN (SELECT id FROM leads);
+UPDATE follow_ups SET customer_id = NULL WHERE customer_id NOT IN (SELECT id FROM customers);
+UPDATE follow_ups SET carrier_id = NULL WHERE carrier_id NOT IN (SELECT id FROM carriers);
+UPDATE follow_ups SET order_id = NULL WHERE order_id NOT IN (SELECT id FROM orders);
+
+CREATE TABLE new_orders (
+    id                   integer PRIMARY KEY AUTOINCREMENT,
+    order_number         text NOT NULL,
+    customer_id          integer,
+    customer_name        text,
+    lead_id              integer,
+    origin_company       text,
+    origin_address       text NOT NULL,
+    origin_city          text NOT NULL,
+    origin_state         text NOT NULL,
+    origin_zip_code      text NOT NULL,
+    destination_company  text,
+    destination_address  text NOT NULL,
+    destination_city     text NOT NULL,
+    destination_state    text NOT NULL,
+    destination_zip_code text NOT NULL,
+    pickup_date          text NOT NULL,
+    delivery_date        text,
+    equipment_type       text NOT NULL,
+    weight               numeric,
+    commodity            text,
+    customer_rate        numeric NOT NULL,
+    status               text DEFAULT 'needs_truck',
+    special_instructions text,
+    created_at           datetime,
+    updated_at           datetime,
+    currency             text NOT NULL DEFAULT 'USD',
+    CONSTRAINT fk_orders_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_orders_lead FOREIGN KEY (lead_id) REFERENCES leads (id) ON DELETE SET NULL
+);
+INSERT INTO new_orders SELECT * FROM orders;
+DROP TABLE orders;
+ALTER TABLE new_orders RENAME TO orders;
+CREATE UNIQUE INDEX idx_orders_order_number ON orders (order_number);
+CREATE INDEX idx_orders_pickup_date ON orders (pickup_date);
+CREATE INDEX idx_orders_customer_id ON orders (customer_id);
+CREATE INDEX idx_orders_lead_id ON orders (lead_id);
+
+CREATE TABLE new_dispatches (
+    id                       integer PRIMARY KEY AUTOINCREMENT,
+    order_id                 integer,
+    carrier_id               integer,
+    carrier_rate             numeric NOT NULL,
+    driver_name              text,
+    driver_phone             text,
+    truck_number             text,
+    trailer_number           text,
+    status                   text DEFAULT 'assigned',
+    rate_confirmation_sent   boolean DEFAULT false,
+    rate_confirmation_signed boolean DEFAULT false,
+    estimated_pickup_time    text,
+    actual_pickup_time       text,
+    estimated_delivery_time  text,
+    actual_delivery_time     text,
+    notes                    text,
+    created_at               datetime,
+    updated_at               datetime,
+    currency                 text NOT NULL DEFAULT 'USD',
+    pickup_time_zone         text,
+    delivery_time_zone       text,
+    CONSTRAINT fk_dispatches_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE,
+    CONSTRAINT fk_dispatches_carrier FOREIGN KEY (carrier_id) REFERENCES carriers (id) ON DELETE RESTRICT
+);
+INSERT INTO new_dispatches SELECT * FROM dispatches;
+DROP TABLE dispatches;
+ALTER TABLE new_dispatches RENAME TO dispatches;
+CREATE INDEX idx_dispatches_order_id ON dispatches (order_id);
+CREATE INDEX idx_dispatches_carrier_id ON dispatches (carrier_id);
+
+CREATE TABLE new_quotes (
+    id                integer PRIMARY KEY AUTOINCREMENT,
+    quote_number      text NOT NULL,
+    lead_id           integer,
+    customer_id       integer,
+    origin_city       text NOT NULL,
+    origin_state      text NOT NULL,
+    destination_city  text NOT NULL,
+    destination_state text NOT NULL,
+    pickup_date       text,
+    equipment_type    text NOT NULL,
+    weight            numeric,
+    commodity         text,
+    quoted_rate       numeric NOT NULL,
+    valid_until       text NOT NULL,
+    status            text DEFAULT 'pending',
+    notes             text,
+    created_at        datetime,
+    updated_at        datetime,
+    currency          text NOT NULL DEFAULT 'USD',
+    CONSTRAINT fk_quotes_lead FOREIGN KEY (lead_id) REFERENCES leads (id) ON DELETE SET NULL,
+    CONSTRAINT fk_quotes_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE SET NULL
+);
+INSERT INTO new_quotes SELECT * FROM quotes;
+DROP TABLE quotes;
+ALTER TABLE new_quotes RENAME TO quotes;
+CREATE UNIQUE INDEX idx_quotes_quote_number ON quotes (quote_number);
+CREATE INDEX idx_quotes_lead_id ON quotes (lead_id);
+CREATE INDEX idx_quotes_customer_id ON quotes (customer_id);
+
+CREATE TABLE new_invoices (
+    id             integer PRIMARY KEY AUTOINCREMENT,
+    invoice_number text NOT NULL,
+    type           text NOT NULL,
+    customer_id    integer,
+    carrier_id     integer,
+    order_id       integer,
+    dispatch_id    integer,
+    amount         numeric NOT NULL,
+    status         text DEFAULT 'draft',
+    due_date       text NOT NULL,
+    paid_date      text,
+    notes          text,
+    created_at     datetime,
+    updated_at     datetime,
+    currency       text NOT NULL DEFAULT 'USD',
+    CONSTRAINT fk_invoices_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_invoices_carrier FOREIGN KEY (carrier_id) REFERENCES carriers (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_invoices_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_invoices_dispatch FOREIGN KEY (dispatch_id) REFERENCES dispatches (id) ON DELETE RESTRICT
+);
+INSERT INTO new_invoices SELECT * FROM invoices;
+DROP TABLE invoices;
+ALTER TABLE new_invoices RENAME TO invoices;
+CREATE UNIQUE INDEX idx_invoices_invoice_number ON invoices (invoice_number);
+CREATE INDEX idx_invoices_due_date ON invoices (due_date);
+CREATE INDEX idx_invoices_customer_id ON invoices (customer_id);
+CREATE INDEX idx_invoices_carrier_id ON invoices (carrier_id);
+CREATE INDEX idx_invoices_order_id ON invoices (order_id);
+CREATE INDEX idx_invoices_dispatch_id ON invoices (dispatch_id);
+
+CREATE TABLE new_follow_ups (
+    id           integer PRIMARY KEY AUTOINCREMENT,
+    title        text NOT NULL,
+    description  text,
+    type         text NOT NULL,
+    lead_id      integer,
+    customer_id  integer,
+    carrier_id   integer,
+    order_id     integer,
+    due_date     datetime NOT NULL,
+    completed    boolean DEFAULT false,
+    completed_at text,
+    priority     text DEFAULT 'medium',
+    assigned_to  text,
+    notes        text,
+    created_at   datetime,
+    updated_at   datetime,
+    CONSTRAINT fk_follow_ups_lead FOREIGN KEY (lead_id) REFERENCES leads (id) ON DELETE CASCADE,
+    CONSTRAINT fk_follow_ups_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE CASCADE,
+    CONSTRAINT fk_follow_ups_carrier FOREIGN KEY (carrier_id) REFERENCES carriers (id) ON DELETE CASCADE,
+    CONSTRAINT fk_follow_ups_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE
+);
+INSERT INTO new_follow_ups SELECT * FROM follow_ups;
+DROP TABLE follow_ups;
+ALTER TABLE new_follow_ups RENAME TO follow_ups;
+CREATE INDEX idx_follow_ups_lead_id ON follow_ups (lead_id);
+CREATE INDEX idx_follow_ups_customer_id ON follow_ups (customer_id);
+CREATE INDEX idx_follow_ups_carrier_id ON follow_ups (carrier_id);
+CREATE INDEX idx_follow_ups_order_id ON follow_ups (order_id);
@@ -0,0 +1,124 @@
+-- Equivalent to postgres/0005_archive_tables.up.sql. The columns are those of
+-- the live tables as of 0004 plus archived_at; a migration adding a column to
+-- orders, dispatches, invoices or follow_ups must add it here too. IDs are
+-- copied from the live tables, so they don't autoincrement.
+
+CREATE TABLE archived_orders (
+    id                   integer PRIMARY KEY,
+    order_number         text NOT NULL,
+    customer_id          integer,
+    customer_name        text,
+    lead_id              integer,
+    origin_company       text,
+    origin_address       text NOT NULL,
+    origin_city          text NOT NULL,
+    origin_state         text NOT NULL,
+    origin_zip_code      text NOT NULL,
+    destination_company  text,
+    destination_address  text NOT NULL,
+    destination_city     text NOT NULL,
+    destination_state    text NOT NULL,
+    destination_zip_code text NOT NULL,
+    pickup_date          text NOT NULL,
+    delivery_date        text,
+    equipment_type       text NOT NULL,
+    weight               numeric,
+    commodity            text,
+    customer_rate        numeric NOT NULL,
+    status               text DEFAULT 'needs_truck',
+    special_instructions text,
+    created_at           datetime,
+    updated_at           datetime,
+    currency             text NOT NULL DEFAULT 'USD',
+    archived_at          datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_archived_orders_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_archived_orders_lead FOREIGN KEY (lead_id) REFERENCES leads (id) ON DELETE SET NULL
+);
+CREATE INDEX idx_archived_orders_order_number ON archived_orders (order_number);
+CREATE INDEX idx_archived_orders_archived_at ON archived_orders (archived_at);
+CREATE INDEX idx_archived_orders_customer_id ON archived_orders (customer_id);
+CREATE INDEX idx_archived_orders_lead_id ON archived_orders (lead_id);
+
+CREATE TABLE archived_dispatches (
+    id                       integer PRIMARY KEY,
+    order_id                 integer,
+    carrier_id               integer,
+    carrier_rate             numeric NOT NULL,
+    driver_name              text,
+    driver_phone             text,
+    truck_number             text,
+    trailer_number           text,
+    status                   text DEFAULT 'assigned',
+    rate_confirmation_sent   boolean DEFAULT false,
+    rate_confirmation_signed boolean DEFAULT false,
+    estimated_pickup_time    text,
+    actual_pickup_time       text,
+    estimated_delivery_time  text,
+    actual_delivery_time     text,
+    notes                    text,
+    created_at               datetime,
+    updated_at               datetime,
+    currency                 text NOT NULL DEFAULT 'USD',
+    pickup_time_zone         text,
+    delivery_time_zone       text,
+    archived_at              datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_archived_dispatches_order FOREIGN KEY (order_id) REFERENCES archived_orders (id) ON DELETE CASCADE,
+    CONSTRAINT fk_archived_dispatches_carrier FOREIGN KEY (carrier_id) REFERENCES carriers (id) ON DELETE RESTRICT
+);
+CREATE INDEX idx_archived_dispatches_order_id ON archived_dispatches (order_id);
+CREATE INDEX idx_archived_dispatches_carrier_id ON archived_dispatches (carrier_id);
+
+CREATE TABLE archived_invoices (
+    id             integer PRIMARY KEY,
+    invoice_number text NOT NULL,
+    type           text NOT NULL,
+    customer_id    integer,
+    carrier_id     integer,
+    order_id       integer,
+    dispatch_id    integer,
+    amount         numeric NOT NULL,
+    status         text DEFAULT 'draft',
+    due_date       text NOT NULL,
+    paid_date      text,
+    notes          text,
+    created_at     datetime,
+    updated_at     datetime,
+    currency       text NOT NULL DEFAULT 'USD',
+    archived_at    datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_archived_invoices_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_archived_invoices_carrier FOREIGN KEY (carrier_id) REFERENCES carriers (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_archived_invoices_order FOREIGN KEY (order_id) REFERENCES archived_orders (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_archived_invoices_dispatch FOREIGN KEY (dispatch_id) REFERENCES archived_dispatches (id) ON DELETE RESTRICT
+);
+CREATE INDEX idx_archived_invoices_customer_id ON archived_invoices (customer_id);
+CREATE INDEX idx_archived_invoices_carrier_id ON archived_invoices (carrier_id);
+CREATE INDEX idx_archived_invoices_order_id ON archived_invoices (order_id);
+CREATE INDEX idx_archived_invoices_dispatch_id ON archived_invoices (dispatch_id);
+
+CREATE TABLE archived_follow_ups (
+    id           integer PRIMARY KEY,
+    title        text NOT NULL,
+    description  text,
+    type         text NOT NULL,
+    lead_id      integer,
+    customer_id  integer,
+    carrier_id   integer,
+    order_id     integer,
+    due_date     datetime NOT NULL,
+    completed    boolean DEFAULT false,
+    completed_at text,
+    priority     text DEFAULT 'medium',
+    assigned_to  text,
+    notes        text,
+    created_at   datetime,
+    updated_at   datetime,
+    archived_at  datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_archived_follow_ups_lead FOREIGN KEY (lead_id) REFERENCES leads (id) ON DELETE CASCADE,
+    CONSTRAINT fk_archived_follow_ups_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE CASCADE,
+    CONSTRAINT fk_archived_follow_ups_carrier FOREIGN KEY (carrier_id) REFERENCES carriers (id) ON DELETE CASCADE,
+    CONSTRAINT fk_archived_follow_ups_order FOREIGN KEY (order_id) REFERENCES archived_orders (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_archived_follow_ups_lead_id ON archived_follow_ups (lead_id);
+CREATE INDEX idx_archived_follow_ups_customer_id ON archived_follow_ups (customer_id);
+CREATE INDEX idx_archived_follow_ups_carrier_id ON archived_follow_ups (carrier_id);
+CREATE INDEX idx_archived_follow_ups_order_id ON archived_follow_ups (order_id);
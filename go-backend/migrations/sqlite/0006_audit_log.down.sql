@@ -0,0 +1,3 @@
+-- The audit log is dropped with its table, along with its triggers.
+
+DROP TABLE IF EXISTS audit_log;
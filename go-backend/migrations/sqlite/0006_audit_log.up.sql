@@ -0,0 +1,24 @@
+-- Equivalent to postgres/0006_audit_log.up.sql. changes holds JSON text.
+
+CREATE TABLE audit_log (
+    id          integer  PRIMARY KEY AUTOINCREMENT,
+    occurred_at datetime NOT NULL,
+    user_id     text     NOT NULL,
+    request_id  text,
+    entity      text     NOT NULL,
+    entity_id   text     NOT NULL,
+    action      text     NOT NULL,
+    changes     text
+);
+CREATE INDEX idx_audit_log_entity ON audit_log (entity, entity_id);
+CREATE INDEX idx_audit_log_user_id ON audit_log (user_id);
+CREATE INDEX idx_audit_log_occurred_at ON audit_log (occurred_at);
+
+CREATE TRIGGER audit_log_no_update BEFORE UPDATE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+CREATE TRIGGER audit_log_no_delete BEFORE DELETE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
@@ -0,0 +1,39 @@
+-- Equivalent to postgres/0007_event_outbox.up.sql. payload holds JSON text.
+
+CREATE TABLE event_outbox (
+    id          integer  PRIMARY KEY AUTOINCREMENT,
+    type        text     NOT NULL,
+    occurred_at datetime NOT NULL,
+    user_id     text     NOT NULL,
+    request_id  text,
+    payload     text     NOT NULL
+);
+CREATE INDEX idx_event_outbox_occurred_at ON event_outbox (occurred_at);
+
+CREATE TABLE event_cursors (
+    subscriber   text     PRIMARY KEY,
+    locked_by    text,
+    locked_until datetime,
+    updated_at   datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE event_deliveries (
+    subscriber text     NOT NULL REFERENCES event_cursors (subscriber) ON DELETE CASCADE,
+    event_id   integer  NOT NULL REFERENCES event_outbox (id) ON DELETE CASCADE,
+    attempts   integer  NOT NULL DEFAULT 0,
+    last_error text,
+    handled_at datetime,
+    PRIMARY KEY (subscriber, event_id)
+);
+CREATE INDEX idx_event_deliveries_event_id ON event_deliveries (event_id);
+
+CREATE TABLE event_dead_letters (
+    id         integer  PRIMARY KEY AUTOINCREMENT,
+    subscriber text     NOT NULL REFERENCES event_cursors (subscriber) ON DELETE CASCADE,
+    event_id   integer  NOT NULL REFERENCES event_outbox (id),
+    attempts   integer  NOT NULL,
+    last_error text     NOT NULL,
+    failed_at  datetime NOT NULL,
+    UNIQUE (subscriber, event_id)
+);
+CREATE INDEX idx_event_dead_letters_event_id ON event_dead_letters (event_id);
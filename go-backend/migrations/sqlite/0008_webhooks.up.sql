@@ -0,0 +1,37 @@
+-- Equivalent to postgres/0008_webhooks.up.sql. payload holds JSON text.
+
+CREATE TABLE webhook_subscriptions (
+    id                   integer  PRIMARY KEY AUTOINCREMENT,
+    customer_id          integer  REFERENCES customers (id) ON DELETE CASCADE,
+    client_name          text,
+    url                  text     NOT NULL,
+    secret               text     NOT NULL,
+    description          text,
+    event_types          text     NOT NULL DEFAULT '',
+    active               boolean  NOT NULL DEFAULT true,
+    consecutive_failures integer  NOT NULL DEFAULT 0,
+    disabled_at          datetime,
+    disabled_reason      text,
+    created_at           datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at           datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((customer_id IS NULL) <> (client_name IS NULL))
+);
+CREATE INDEX idx_webhook_subscriptions_customer_id ON webhook_subscriptions (customer_id);
+
+CREATE TABLE webhook_deliveries (
+    id              integer  PRIMARY KEY AUTOINCREMENT,
+    subscription_id integer  NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id        integer  NOT NULL,
+    event_type      text     NOT NULL,
+    payload         text     NOT NULL,
+    status          text     NOT NULL DEFAULT 'pending',
+    attempts        integer  NOT NULL DEFAULT 0,
+    next_attempt_at datetime,
+    last_attempt_at datetime,
+    response_status integer,
+    last_error      text,
+    delivered_at    datetime,
+    created_at      datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (subscription_id, event_id)
+);
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
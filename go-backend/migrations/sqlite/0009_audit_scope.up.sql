@@ -0,0 +1,4 @@
+-- Equivalent to postgres/0009_audit_scope.up.sql.
+
+ALTER TABLE audit_log ADD COLUMN tenant_id text;
+ALTER TABLE audit_log ADD COLUMN customer_id integer;
@@ -0,0 +1,3 @@
+-- Buckets start full again once the table is recreated.
+
+DROP TABLE IF EXISTS rate_limit_buckets;
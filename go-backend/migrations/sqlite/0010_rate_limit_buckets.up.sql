@@ -0,0 +1,8 @@
+-- Equivalent to postgres/0010_rate_limit_buckets.up.sql.
+
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    key        varchar(255) PRIMARY KEY,
+    tokens     real         NOT NULL,
+    updated_at datetime     NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at ON rate_limit_buckets (updated_at);
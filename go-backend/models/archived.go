@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// ArchivedOrder is a closed order moved out of the live tables by the
+// archival job. The archive tables have the columns of the live tables plus
+// archived_at, and keep the record IDs so an order can be restored as it was.
+type ArchivedOrder struct {
+	Order
+	ArchivedAt time.Time `json:"archivedAt" gorm:"not null"`
+
+	// Filled in when a single archived order is loaded.
+	Dispatches []ArchivedDispatch `json:"dispatches,omitempty" gorm:"-"`
+	Invoices   []ArchivedInvoice  `json:"invoices,omitempty" gorm:"-"`
+	FollowUps  []ArchivedFollowUp `json:"followUps,omitempty" gorm:"-"`
+}
+
+func (ArchivedOrder) TableName() string { return "archived_orders" }
+
+// ArchivedDispatch is a dispatch archived with its order.
+type ArchivedDispatch struct {
+	Dispatch
+	ArchivedAt time.Time `json:"archivedAt" gorm:"not null"`
+}
+
+func (ArchivedDispatch) TableName() string { return "archived_dispatches" }
+
+// ArchivedInvoice is an invoice archived with its order.
+type ArchivedInvoice struct {
+	Invoice
+	ArchivedAt time.Time `json:"archivedAt" gorm:"not null"`
+}
+
+func (ArchivedInvoice) TableName() string { return "archived_invoices" }
+
+// ArchivedFollowUp is a follow-up archived with its order.
+type ArchivedFollowUp struct {
+	FollowUp
+	ArchivedAt time.Time `json:"archivedAt" gorm:"not null"`
+}
+
+func (ArchivedFollowUp) TableName() string { return "archived_follow_ups" }
+
+// Archived returns the archive table models, parents first.
+func Archived() []interface{} {
+	return []interface{}{
+		&ArchivedOrder{},
+		&ArchivedDispatch{},
+		&ArchivedInvoice{},
+		&ArchivedFollowUp{},
+	}
+}
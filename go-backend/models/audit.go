@@ -0,0 +1,71 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// AuditEntry records one change to a record: who made it, when, and the
+// fields it changed. The audit log is append-only.
+type AuditEntry struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	OccurredAt time.Time `json:"occurredAt" gorm:"not null"`
+	// UserID is the user who made the change, or "system".
+	UserID string `json:"userId" gorm:"not null"`
+	// TenantID is the tenant the user made the change for, if any.
+	TenantID  *string `json:"tenantId,omitempty"`
+	RequestID string  `json:"requestId,omitempty"`
+	// CustomerID is the customer owning the changed record, if any.
+	CustomerID *uint `json:"customerId,omitempty"`
+	// Entity is the table of the changed record, such as "dispatches".
+	Entity   string  `json:"entity" gorm:"not null"`
+	EntityID string  `json:"entityId" gorm:"not null"`
+	Action   string  `json:"action" gorm:"not null"`
+	Changes  Changes `json:"changes,omitempty"`
+}
+
+func (AuditEntry) TableName() string { return "audit_log" }
+
+// FieldChange is a field's value before and after a change, as the API writes
+// it. From is empty for a create and To for a delete; both are empty for
+// fields whose values are not logged, such as passwords.
+type FieldChange struct {
+	From json.RawMessage `json:"from,omitempty"`
+	To   json.RawMessage `json:"to,omitempty"`
+}
+
+// Changes maps the JSON names of changed fields to their values, stored as a
+// JSON object.
+type Changes map[string]FieldChange
+
+// GormDataType tells GORM the column holds JSON.
+func (Changes) GormDataType() string { return "json" }
+
+// Scan reads a JSON column.
+func (c *Changes) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	case []byte:
+		return json.Unmarshal(v, c)
+	default:
+		return fmt.Errorf("models: cannot scan %T into Changes", src)
+	}
+}
+
+// Value writes the changes as JSON.
+func (c Changes) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// FieldLabel is a field's API name and a label for people to read.
+type FieldLabel struct {
+	Name  string
+	Label string
+}
+
+// FieldLabels returns the fields of model, a pointer to a struct, in
+// declaration order, each labeled from its Go name: PickupDate becomes
+// "Pickup date" and CustomerID "Customer". A label tag overrides the label.
+func FieldLabels(model interface{}) []FieldLabel {
+	var labels []FieldLabel
+	collectLabels(reflect.TypeOf(model).Elem(), &labels)
+	return labels
+}
+
+func collectLabels(t reflect.Type, labels *[]FieldLabel) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			collectLabels(field.Type, labels)
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		label := field.Tag.Get("label")
+		if label == "" {
+			label = labelFor(field.Name)
+		}
+		*labels = append(*labels, FieldLabel{Name: name, Label: label})
+	}
+}
+
+// labelFor turns a Go field name into a sentence-case label, keeping
+// acronyms such as MC upper case.
+func labelFor(goName string) string {
+	words := splitWords(goName)
+	if len(words) > 1 && words[len(words)-1] == "ID" {
+		words = words[:len(words)-1]
+	}
+	for i, w := range words {
+		if i > 0 && !isAcronym(w) {
+			words[i] = strings.ToLower(w)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// splitWords splits a mixed-case name into words, treating a run of capitals
+// followed by a capitalized word as an acronym: MCNumber is MC and Number.
+func splitWords(s string) []string {
+	runes := []rune(s)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		next := rune(0)
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+		lowerToUpper := !unicode.IsUpper(prev) && unicode.IsUpper(cur)
+		acronymEnd := unicode.IsUpper(prev) && unicode.IsUpper(cur) && unicode.IsLower(next)
+		if lowerToUpper || acronymEnd {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
+
+func isAcronym(word string) bool {
+	return len(word) > 1 && strings.ToUpper(word) == word
+}
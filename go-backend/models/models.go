@@ -2,13 +2,16 @@ package models
 
 import (
         "time"
+
+        "everflown-logistics/dates"
+        "everflown-logistics/money"
 )
 
 // User represents a user in the system
 type User struct {
         ID              string    `json:"id" gorm:"primaryKey;type:varchar(255)"`
         Username        string    `json:"username" gorm:"uniqueIndex;not null;type:varchar(255)"`
-        Password        string    `json:"password" gorm:"not null;type:varchar(255)"`
+        Password        string    `json:"password" gorm:"not null;type:varchar(255)" secret:"true"`
         Email           string    `json:"email" gorm:"uniqueIndex;type:varchar(255)"`
         FirstName       *string   `json:"firstName" gorm:"type:varchar(255)"`
         LastName        *string   `json:"lastName" gorm:"type:varchar(255)"`
@@ -29,7 +32,7 @@ type Lead struct {
         OriginState      *string   `json:"originState"`
         DestinationCity  *string   `json:"destinationCity"`
         DestinationState *string   `json:"destinationState"`
-        PickupDate       *string   `json:"pickupDate"`
+        PickupDate       *dates.Date `json:"pickupDate" gorm:"type:date"`
         EquipmentType    *string   `json:"equipmentType"`
         Commodity        *string   `json:"commodity"`
         Weight           *int      `json:"weight"`
@@ -54,7 +57,8 @@ type Customer struct {
         BillingCity         *string   `json:"billingCity"`
         BillingState        *string   `json:"billingState"`
         BillingZipCode      *string   `json:"billingZipCode"`
-        CreditLimit         *float64  `json:"creditLimit"`
+        CreditLimit         *money.Amount `json:"creditLimit" gorm:"type:numeric(14,2)"`
+        Currency            string    `json:"currency" gorm:"type:varchar(3);not null;default:USD"`
         PaymentTerms        string    `json:"paymentTerms" gorm:"default:Net 30"`
         SpecialInstructions *string   `json:"specialInstructions"`
         IsActive            bool      `json:"isActive" gorm:"default:true"`
@@ -75,7 +79,7 @@ type Carrier struct {
         ZipCode           *string   `json:"zipCode"`
         MCNumber          *string   `json:"mcNumber"`
         DOTNumber         *string   `json:"dotNumber"`
-        InsuranceExpiry   *string   `json:"insuranceExpiry"`
+        InsuranceExpiry   *dates.Date `json:"insuranceExpiry" gorm:"type:date"`
         W9OnFile          bool      `json:"w9OnFile" gorm:"default:false"`
         PerformanceRating float64   `json:"performanceRating" gorm:"default:0.00"`
         PreferredLanes    *string   `json:"preferredLanes"`
@@ -86,15 +90,16 @@ type Carrier struct {
         UpdatedAt         time.Time `json:"updatedAt"`
 }
 
-// Order represents an order
+// Order represents an order. Deleting its customer is refused while the
+// order exists; deleting its lead clears the link.
 type Order struct {
         ID                   uint      `json:"id" gorm:"primaryKey"`
         OrderNumber          string    `json:"orderNumber" gorm:"not null;uniqueIndex"`
         CustomerID           *uint     `json:"customerId"`
-        Customer             *Customer `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+        Customer             *Customer `json:"customer,omitempty" gorm:"foreignKey:CustomerID;constraint:OnDelete:RESTRICT"`
         CustomerName         *string   `json:"customerName"`
         LeadID               *uint     `json:"leadId"`
-        Lead                 *Lead     `json:"lead,omitempty" gorm:"foreignKey:LeadID"`
+        Lead                 *Lead     `json:"lead,omitempty" gorm:"foreignKey:LeadID;constraint:OnDelete:SET NULL"`
         OriginCompany        *string   `json:"originCompany"`
         OriginAddress        string    `json:"originAddress" gorm:"not null"`
         OriginCity           string    `json:"originCity" gorm:"not null"`
@@ -105,26 +110,29 @@ type Order struct {
         DestinationCity      string    `json:"destinationCity" gorm:"not null"`
         DestinationState     string    `json:"destinationState" gorm:"not null"`
         DestinationZipCode   string    `json:"destinationZipCode" gorm:"not null"`
-        PickupDate           string    `json:"pickupDate" gorm:"not null"`
-        DeliveryDate         *string   `json:"deliveryDate"`
+        PickupDate           dates.Date `json:"pickupDate" gorm:"type:date;not null"`
+        DeliveryDate         *dates.Date `json:"deliveryDate" gorm:"type:date"`
         EquipmentType        string    `json:"equipmentType" gorm:"not null"`
         Weight               *float64  `json:"weight"`
         Commodity            *string   `json:"commodity"`
-        CustomerRate         float64   `json:"customerRate" gorm:"not null"`
+        CustomerRate         money.Amount `json:"customerRate" gorm:"type:numeric(14,2);not null"`
+        Currency             string    `json:"currency" gorm:"type:varchar(3);not null;default:USD"`
         Status               string    `json:"status" gorm:"default:needs_truck"`
         SpecialInstructions  *string   `json:"specialInstructions"`
         CreatedAt            time.Time `json:"createdAt"`
         UpdatedAt            time.Time `json:"updatedAt"`
 }
 
-// Dispatch represents a dispatch
+// Dispatch represents a dispatch. It is deleted with its order, and its
+// carrier can't be deleted while it exists.
 type Dispatch struct {
         ID                     uint      `json:"id" gorm:"primaryKey"`
         OrderID                uint      `json:"orderId"`
-        Order                  *Order    `json:"order,omitempty" gorm:"foreignKey:OrderID"`
+        Order                  *Order    `json:"order,omitempty" gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
         CarrierID              uint      `json:"carrierId"`
-        Carrier                *Carrier  `json:"carrier,omitempty" gorm:"foreignKey:CarrierID"`
-        CarrierRate            float64   `json:"carrierRate" gorm:"not null"`
+        Carrier                *Carrier  `json:"carrier,omitempty" gorm:"foreignKey:CarrierID;constraint:OnDelete:RESTRICT"`
+        CarrierRate            money.Amount `json:"carrierRate" gorm:"type:numeric(14,2);not null"`
+        Currency               string    `json:"currency" gorm:"type:varchar(3);not null;default:USD"`
         DriverName             *string   `json:"driverName"`
         DriverPhone            *string   `json:"driverPhone"`
         TruckNumber            *string   `json:"truckNumber"`
@@ -132,78 +140,86 @@ type Dispatch struct {
         Status                 string    `json:"status" gorm:"default:assigned"`
         RateConfirmationSent   bool      `json:"rateConfirmationSent" gorm:"default:false"`
         RateConfirmationSigned bool      `json:"rateConfirmationSigned" gorm:"default:false"`
-        EstimatedPickupTime    *string   `json:"estimatedPickupTime"`
-        ActualPickupTime       *string   `json:"actualPickupTime"`
-        EstimatedDeliveryTime  *string   `json:"estimatedDeliveryTime"`
-        ActualDeliveryTime     *string   `json:"actualDeliveryTime"`
+        EstimatedPickupTime    *dates.Timestamp `json:"estimatedPickupTime" gorm:"type:timestamptz"`
+        ActualPickupTime       *dates.Timestamp `json:"actualPickupTime" gorm:"type:timestamptz"`
+        EstimatedDeliveryTime  *dates.Timestamp `json:"estimatedDeliveryTime" gorm:"type:timestamptz"`
+        ActualDeliveryTime     *dates.Timestamp `json:"actualDeliveryTime" gorm:"type:timestamptz"`
+        // PickupTimeZone and DeliveryTimeZone are the IANA zones of the
+        // origin and destination, such as "America/Chicago". Appointment
+        // times given without a UTC offset are read in these zones.
+        PickupTimeZone         *string   `json:"pickupTimeZone"`
+        DeliveryTimeZone       *string   `json:"deliveryTimeZone"`
         Notes                  *string   `json:"notes"`
         CreatedAt              time.Time `json:"createdAt"`
         UpdatedAt              time.Time `json:"updatedAt"`
 }
 
-// Quote represents a quote
+// Quote represents a quote. Deleting its lead or customer clears the link.
 type Quote struct {
         ID               uint      `json:"id" gorm:"primaryKey"`
         QuoteNumber      string    `json:"quoteNumber" gorm:"not null;uniqueIndex"`
         LeadID           *uint     `json:"leadId"`
-        Lead             *Lead     `json:"lead,omitempty" gorm:"foreignKey:LeadID"`
+        Lead             *Lead     `json:"lead,omitempty" gorm:"foreignKey:LeadID;constraint:OnDelete:SET NULL"`
         CustomerID       *uint     `json:"customerId"`
-        Customer         *Customer `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+        Customer         *Customer `json:"customer,omitempty" gorm:"foreignKey:CustomerID;constraint:OnDelete:SET NULL"`
         OriginCity       string    `json:"originCity" gorm:"not null"`
         OriginState      string    `json:"originState" gorm:"not null"`
         DestinationCity  string    `json:"destinationCity" gorm:"not null"`
         DestinationState string    `json:"destinationState" gorm:"not null"`
-        PickupDate       *string   `json:"pickupDate"`
+        PickupDate       *dates.Date `json:"pickupDate" gorm:"type:date"`
         EquipmentType    string    `json:"equipmentType" gorm:"not null"`
         Weight           *float64  `json:"weight"`
         Commodity        *string   `json:"commodity"`
-        QuotedRate       float64   `json:"quotedRate" gorm:"not null"`
-        ValidUntil       string    `json:"validUntil" gorm:"not null"`
+        QuotedRate       money.Amount `json:"quotedRate" gorm:"type:numeric(14,2);not null"`
+        Currency         string    `json:"currency" gorm:"type:varchar(3);not null;default:USD"`
+        ValidUntil       dates.Date `json:"validUntil" gorm:"type:date;not null"`
         Status           string    `json:"status" gorm:"default:pending"`
         Notes            *string   `json:"notes"`
         CreatedAt        time.Time `json:"createdAt"`
         UpdatedAt        time.Time `json:"updatedAt"`
 }
 
-// Invoice represents an invoice
+// Invoice represents an invoice. Its customer, carrier, order and dispatch
+// can't be deleted while it exists.
 type Invoice struct {
         ID            uint      `json:"id" gorm:"primaryKey"`
         InvoiceNumber string    `json:"invoiceNumber" gorm:"not null;uniqueIndex"`
         Type          string    `json:"type" gorm:"not null"`
         CustomerID    *uint     `json:"customerId"`
-        Customer      *Customer `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+        Customer      *Customer `json:"customer,omitempty" gorm:"foreignKey:CustomerID;constraint:OnDelete:RESTRICT"`
         CarrierID     *uint     `json:"carrierId"`
-        Carrier       *Carrier  `json:"carrier,omitempty" gorm:"foreignKey:CarrierID"`
+        Carrier       *Carrier  `json:"carrier,omitempty" gorm:"foreignKey:CarrierID;constraint:OnDelete:RESTRICT"`
         OrderID       *uint     `json:"orderId"`
-        Order         *Order    `json:"order,omitempty" gorm:"foreignKey:OrderID"`
+        Order         *Order    `json:"order,omitempty" gorm:"foreignKey:OrderID;constraint:OnDelete:RESTRICT"`
         DispatchID    *uint     `json:"dispatchId"`
-        Dispatch      *Dispatch `json:"dispatch,omitempty" gorm:"foreignKey:DispatchID"`
-        Amount        float64   `json:"amount" gorm:"not null"`
+        Dispatch      *Dispatch `json:"dispatch,omitempty" gorm:"foreignKey:DispatchID;constraint:OnDelete:RESTRICT"`
+        Amount        money.Amount `json:"amount" gorm:"type:numeric(14,2);not null"`
+        Currency      string    `json:"currency" gorm:"type:varchar(3);not null;default:USD"`
         Status        string    `json:"status" gorm:"default:draft"`
-        DueDate       string    `json:"dueDate" gorm:"not null"`
-        PaidDate      *string   `json:"paidDate"`
+        DueDate       dates.Date `json:"dueDate" gorm:"type:date;not null"`
+        PaidDate      *dates.Date `json:"paidDate" gorm:"type:date"`
         Notes         *string   `json:"notes"`
         CreatedAt     time.Time `json:"createdAt"`
         UpdatedAt     time.Time `json:"updatedAt"`
 }
 
-// FollowUp represents a follow-up task
+// FollowUp represents a follow-up task. It is deleted with the record it is about.
 type FollowUp struct {
         ID          uint      `json:"id" gorm:"primaryKey"`
         Title       string    `json:"title" gorm:"not null"`
         Description *string   `json:"description"`
         Type        string    `json:"type" gorm:"not null"`
         LeadID      *uint     `json:"leadId"`
-        Lead        *Lead     `json:"lead,omitempty" gorm:"foreignKey:LeadID"`
+        Lead        *Lead     `json:"lead,omitempty" gorm:"foreignKey:LeadID;constraint:OnDelete:CASCADE"`
         CustomerID  *uint     `json:"customerId"`
-        Customer    *Customer `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+        Customer    *Customer `json:"customer,omitempty" gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE"`
         CarrierID   *uint     `json:"carrierId"`
-        Carrier     *Carrier  `json:"carrier,omitempty" gorm:"foreignKey:CarrierID"`
+        Carrier     *Carrier  `json:"carrier,omitempty" gorm:"foreignKey:CarrierID;constraint:OnDelete:CASCADE"`
         OrderID     *uint     `json:"orderId"`
-        Order       *Order    `json:"order,omitempty" gorm:"foreignKey:OrderID"`
+        Order       *Order    `json:"order,omitempty" gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
         DueDate     time.Time `json:"dueDate" gorm:"not null"`
         Completed   bool      `json:"completed" gorm:"default:false"`
-        CompletedAt *string   `json:"completedAt"`
+        CompletedAt *dates.Timestamp `json:"completedAt" gorm:"type:timestamptz"`
         Priority    string    `json:"priority" gorm:"default:medium"`
         AssignedTo  *string   `json:"assignedTo"`
         Notes       *string   `json:"notes"`
@@ -216,9 +232,32 @@ type DashboardStats struct {
         ActiveOrders    int     `json:"activeOrders"`
         InTransit       int     `json:"inTransit"`
         PendingInvoices int     `json:"pendingInvoices"`
-        Revenue         float64 `json:"revenue"`
+        Revenue         money.Amount `json:"revenue"`
         TotalLeads      int     `json:"totalLeads"`
         TotalCustomers  int     `json:"totalCustomers"`
         TotalCarriers   int     `json:"totalCarriers"`
         TotalOrders     int     `json:"totalOrders"`
-}
\ No newline at end of file
+}
+
+// All returns every persisted model, parents before the records that reference them.
+func All() []interface{} {
+        return []interface{}{
+                &User{},
+                &Lead{},
+                &Customer{},
+                &Carrier{},
+                &Order{},
+                &Dispatch{},
+                &Quote{},
+                &Invoice{},
+                &FollowUp{},
+        }
+}
+
+// Tables returns the model of every table the migrations create: the
+// records, their archives, and the audit log, outbox and webhook tables.
+func Tables() []interface{} {
+        tables := append(All(), Archived()...)
+        return append(tables, &AuditEntry{}, &OutboxEvent{}, &EventCursor{}, &EventDelivery{}, &EventDeadLetter{},
+                &WebhookSubscription{}, &WebhookDelivery{})
+}
@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// OutboxEvent is a domain event waiting to be delivered to subscribers. It is
+// written in the same transaction as the change it describes.
+type OutboxEvent struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	Type       string    `json:"type" gorm:"not null"`
+	OccurredAt time.Time `json:"occurredAt" gorm:"not null"`
+	// UserID and RequestID identify who made the change, as in the audit log.
+	UserID    string `json:"userId" gorm:"not null"`
+	RequestID string `json:"requestId,omitempty"`
+	// Payload is the event's data as a JSON object.
+	Payload string `json:"payload" gorm:"not null"`
+}
+
+func (OutboxEvent) TableName() string { return "event_outbox" }
+
+// EventCursor registers a subscriber to the outbox. A dispatcher leases the
+// cursor while delivering, so that only one instance delivers to a
+// subscriber at a time.
+type EventCursor struct {
+	Subscriber string `gorm:"primaryKey"`
+	LockedBy   *string
+	// LockedUntil ends the current lease, or after a failure delays the retry.
+	LockedUntil *time.Time
+	UpdatedAt   time.Time
+}
+
+// EventDelivery records a subscriber's progress with one event. An event
+// without one hasn't been tried yet.
+type EventDelivery struct {
+	Subscriber string `gorm:"primaryKey"`
+	EventID    uint   `gorm:"primaryKey"`
+	// Attempts counts failed attempts at handling the event.
+	Attempts  int `gorm:"not null"`
+	LastError *string
+	// HandledAt is set once the event was delivered, skipped as a type the
+	// subscriber doesn't want, or given up on.
+	HandledAt *time.Time
+}
+
+func (EventDelivery) TableName() string { return "event_deliveries" }
+
+// EventDeadLetter is an event a subscriber gave up on after its last
+// attempt failed. The event is kept in the outbox until the dead letter is
+// retried.
+type EventDeadLetter struct {
+	ID         uint         `json:"id" gorm:"primaryKey"`
+	Subscriber string       `json:"subscriber" gorm:"not null"`
+	EventID    uint         `json:"eventId" gorm:"not null"`
+	Event      *OutboxEvent `json:"event,omitempty" gorm:"foreignKey:EventID"`
+	Attempts   int          `json:"attempts" gorm:"not null"`
+	LastError  string       `json:"lastError" gorm:"not null"`
+	FailedAt   time.Time    `json:"failedAt" gorm:"not null"`
+}
+
+func (EventDeadLetter) TableName() string { return "event_dead_letters" }
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WebhookSubscription sends events to an HTTP endpoint of a customer or of
+// an API client. A customer's subscription only receives events about its
+// own records; an API client's receives them all.
+type WebhookSubscription struct {
+	ID         uint    `json:"id" gorm:"primaryKey"`
+	CustomerID *uint   `json:"customerId"`
+	ClientName *string `json:"clientName"`
+	URL        string  `json:"url" gorm:"not null"`
+	// Secret signs the payloads. It is only returned when the subscription
+	// is created.
+	Secret      string  `json:"secret,omitempty" gorm:"not null" secret:"true"`
+	Description *string `json:"description"`
+	// EventTypes limits the events sent; empty means every type.
+	EventTypes StringList `json:"eventTypes" gorm:"not null"`
+	Active     bool       `json:"active" gorm:"not null;default:true"`
+	// ConsecutiveFailures counts failed attempts since the last success; the
+	// subscription is disabled once it reaches the configured limit.
+	ConsecutiveFailures int        `json:"consecutiveFailures" gorm:"not null"`
+	DisabledAt          *time.Time `json:"disabledAt"`
+	DisabledReason      *string    `json:"disabledReason"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+}
+
+// Wants reports whether the subscription receives events of eventType.
+func (s WebhookSubscription) Wants(eventType string) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook delivery statuses.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookDelivery is one event queued for, or sent to, a subscription, with
+// the outcome of its latest attempt.
+type WebhookDelivery struct {
+	ID             uint   `json:"id" gorm:"primaryKey"`
+	SubscriptionID uint   `json:"subscriptionId" gorm:"not null"`
+	EventID        uint   `json:"eventId" gorm:"not null"`
+	EventType      string `json:"eventType" gorm:"not null"`
+	// Payload is the request body, kept so a redelivery sends the same bytes.
+	Payload        string     `json:"payload" gorm:"not null"`
+	Status         string     `json:"status" gorm:"not null;default:pending"`
+	Attempts       int        `json:"attempts" gorm:"not null"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt"`
+	LastAttemptAt  *time.Time `json:"lastAttemptAt"`
+	ResponseStatus *int       `json:"responseStatus"`
+	LastError      *string    `json:"lastError"`
+	DeliveredAt    *time.Time `json:"deliveredAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// StringList is a list of strings stored comma-separated.
+type StringList []string
+
+// GormDataType tells GORM the column holds text.
+func (StringList) GormDataType() string { return "text" }
+
+// Scan reads a comma-separated column.
+func (l *StringList) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("models: cannot scan %T into StringList", src)
+	}
+	*l = nil
+	if s != "" {
+		*l = strings.Split(s, ",")
+	}
+	return nil
+}
+
+// Value writes the list comma-separated.
+func (l StringList) Value() (driver.Value, error) {
+	return strings.Join(l, ","), nil
+}
@@ -0,0 +1,198 @@
+// Package money represents monetary amounts exactly. Amounts are whole
+// cents; the currency lives alongside them on each record.
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is used for records that don't name a currency.
+const DefaultCurrency = "USD"
+
+// Amount is a monetary amount in cents. It is stored in NUMERIC(14,2)
+// columns and written to JSON as a number with two decimal places, so values
+// round-trip without floating-point error. The zero value is 0.00.
+type Amount struct {
+	cents int64
+}
+
+// FromCents returns an amount of cents hundredths of the currency unit.
+func FromCents(cents int64) Amount {
+	return Amount{cents: cents}
+}
+
+// New returns units whole currency units plus cents, as in New(1850, 50)
+// for 1850.50.
+func New(units, cents int64) Amount {
+	return Amount{cents: units*100 + cents}
+}
+
+// FromFloat rounds f to the nearest cent. Use it only at boundaries that
+// still carry floating-point amounts.
+func FromFloat(f float64) Amount {
+	return Amount{cents: int64(math.Round(f * 100))}
+}
+
+var errPrecision = errors.New("amount has more than two decimal places")
+
+// decimalPattern is the form Parse accepts. big.Rat alone would also take
+// fractions such as "1/4" and exponents such as "1e3".
+var decimalPattern = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+// Parse reads a decimal amount such as "1850", "1850.5" or "-12.34". It
+// rejects values with fractions of a cent rather than rounding them.
+func Parse(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	if !decimalPattern.MatchString(s) {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+	r.Mul(r, big.NewRat(100, 1))
+	if !r.IsInt() {
+		return Amount{}, fmt.Errorf("%w: %q", errPrecision, s)
+	}
+	if !r.Num().IsInt64() {
+		return Amount{}, fmt.Errorf("amount %q is out of range", s)
+	}
+	return Amount{cents: r.Num().Int64()}, nil
+}
+
+// Cents returns the amount in hundredths of the currency unit.
+func (a Amount) Cents() int64 {
+	return a.cents
+}
+
+// Float64 approximates the amount for boundaries that need a float.
+func (a Amount) Float64() float64 {
+	return float64(a.cents) / 100
+}
+
+func (a Amount) Add(b Amount) Amount {
+	return Amount{cents: a.cents + b.cents}
+}
+
+func (a Amount) Sub(b Amount) Amount {
+	return Amount{cents: a.cents - b.cents}
+}
+
+func (a Amount) IsNegative() bool {
+	return a.cents < 0
+}
+
+func (a Amount) IsZero() bool {
+	return a.cents == 0
+}
+
+// String formats the amount with two decimal places and no grouping, as in
+// "-1850.50".
+func (a Amount) String() string {
+	sign, cents := "", a.cents
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// symbols are the currencies printed with a symbol rather than their code.
+// The PDF fonts only cover Latin-1, so they are all ASCII.
+var symbols = map[string]string{
+	"USD": "$",
+	"CAD": "CA$",
+	"MXN": "MX$",
+	"AUD": "A$",
+}
+
+// Format renders the amount for people, with thousands separators and the
+// currency symbol or code, as in "$1,850.50" or "EUR 1,850.50".
+func (a Amount) Format(currency string) string {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	s := a.String()
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + "," + whole[i:]
+	}
+	if symbol, ok := symbols[currency]; ok {
+		return sign + symbol + whole + "." + frac
+	}
+	return sign + currency + " " + whole + "." + frac
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a decimal string.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Scan reads a NUMERIC column. Postgres returns the exact decimal text;
+// SQLite stores NUMERIC values as integers or REALs, which are rounded to the
+// nearest cent.
+func (a *Amount) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return a.scanString(string(v))
+	case string:
+		return a.scanString(v)
+	case int64:
+		*a = Amount{cents: v * 100}
+	case float64:
+		*a = FromFloat(v)
+	case nil:
+		*a = Amount{}
+	default:
+		return fmt.Errorf("money: cannot scan %T into Amount", src)
+	}
+	return nil
+}
+
+func (a *Amount) scanString(s string) error {
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Value writes the amount as decimal text, which both Postgres and SQLite
+// convert to their NUMERIC storage without going through a float.
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// ValidCurrency reports whether code looks like an ISO 4217 currency code.
+func ValidCurrency(code string) bool {
+	return currencyCode.MatchString(code)
+}
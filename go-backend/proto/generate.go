@@ -0,0 +1,4 @@
+// Package proto holds the protobuf definitions for the gRPC API.
+package proto
+
+//go:generate protoc -I. --go_out=. --go_opt=module=everflown-logistics/proto --go-grpc_out=. --go-grpc_opt=module=everflown-logistics/proto logistics.proto
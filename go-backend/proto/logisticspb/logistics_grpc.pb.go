@@ -0,0 +1,814 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: logistics.proto
+
+package logisticspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	OrderService_ListOrders_FullMethodName  = "/logistics.v1.OrderService/ListOrders"
+	OrderService_GetOrder_FullMethodName    = "/logistics.v1.OrderService/GetOrder"
+	OrderService_CreateOrder_FullMethodName = "/logistics.v1.OrderService/CreateOrder"
+	OrderService_UpdateOrder_FullMethodName = "/logistics.v1.OrderService/UpdateOrder"
+	OrderService_DeleteOrder_FullMethodName = "/logistics.v1.OrderService/DeleteOrder"
+)
+
+// OrderServiceClient is the client API for OrderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OrderServiceClient interface {
+	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
+	GetOrder(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Order, error)
+	CreateOrder(ctx context.Context, in *Order, opts ...grpc.CallOption) (*Order, error)
+	UpdateOrder(ctx context.Context, in *UpdateOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	DeleteOrder(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+}
+
+type orderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrderServiceClient(cc grpc.ClientConnInterface) OrderServiceClient {
+	return &orderServiceClient{cc}
+}
+
+func (c *orderServiceClient) ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrdersResponse)
+	err := c.cc.Invoke(ctx, OrderService_ListOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetOrder(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderService_GetOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CreateOrder(ctx context.Context, in *Order, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderService_CreateOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) UpdateOrder(ctx context.Context, in *UpdateOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderService_UpdateOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) DeleteOrder(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, OrderService_DeleteOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrderServiceServer is the server API for OrderService service.
+// All implementations must embed UnimplementedOrderServiceServer
+// for forward compatibility
+type OrderServiceServer interface {
+	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
+	GetOrder(context.Context, *IDRequest) (*Order, error)
+	CreateOrder(context.Context, *Order) (*Order, error)
+	UpdateOrder(context.Context, *UpdateOrderRequest) (*Order, error)
+	DeleteOrder(context.Context, *IDRequest) (*DeleteResponse, error)
+	mustEmbedUnimplementedOrderServiceServer()
+}
+
+// UnimplementedOrderServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedOrderServiceServer struct {
+}
+
+func (UnimplementedOrderServiceServer) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrders not implemented")
+}
+func (UnimplementedOrderServiceServer) GetOrder(context.Context, *IDRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) CreateOrder(context.Context, *Order) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) UpdateOrder(context.Context, *UpdateOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) DeleteOrder(context.Context, *IDRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
+
+// UnsafeOrderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrderServiceServer will
+// result in compilation errors.
+type UnsafeOrderServiceServer interface {
+	mustEmbedUnimplementedOrderServiceServer()
+}
+
+func RegisterOrderServiceServer(s grpc.ServiceRegistrar, srv OrderServiceServer) {
+	s.RegisterService(&OrderService_ServiceDesc, srv)
+}
+
+func _OrderService_ListOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ListOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListOrders(ctx, req.(*ListOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrder(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Order)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CreateOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CreateOrder(ctx, req.(*Order))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_UpdateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).UpdateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_UpdateOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).UpdateOrder(ctx, req.(*UpdateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_DeleteOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).DeleteOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_DeleteOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).DeleteOrder(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "logistics.v1.OrderService",
+	HandlerType: (*OrderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListOrders",
+			Handler:    _OrderService_ListOrders_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _OrderService_GetOrder_Handler,
+		},
+		{
+			MethodName: "CreateOrder",
+			Handler:    _OrderService_CreateOrder_Handler,
+		},
+		{
+			MethodName: "UpdateOrder",
+			Handler:    _OrderService_UpdateOrder_Handler,
+		},
+		{
+			MethodName: "DeleteOrder",
+			Handler:    _OrderService_DeleteOrder_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "logistics.proto",
+}
+
+const (
+	DispatchService_ListDispatches_FullMethodName  = "/logistics.v1.DispatchService/ListDispatches"
+	DispatchService_GetDispatch_FullMethodName     = "/logistics.v1.DispatchService/GetDispatch"
+	DispatchService_CreateDispatch_FullMethodName  = "/logistics.v1.DispatchService/CreateDispatch"
+	DispatchService_UpdateDispatch_FullMethodName  = "/logistics.v1.DispatchService/UpdateDispatch"
+	DispatchService_DeleteDispatch_FullMethodName  = "/logistics.v1.DispatchService/DeleteDispatch"
+	DispatchService_WatchDispatches_FullMethodName = "/logistics.v1.DispatchService/WatchDispatches"
+)
+
+// DispatchServiceClient is the client API for DispatchService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DispatchServiceClient interface {
+	ListDispatches(ctx context.Context, in *ListDispatchesRequest, opts ...grpc.CallOption) (*ListDispatchesResponse, error)
+	GetDispatch(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Dispatch, error)
+	CreateDispatch(ctx context.Context, in *Dispatch, opts ...grpc.CallOption) (*Dispatch, error)
+	UpdateDispatch(ctx context.Context, in *UpdateDispatchRequest, opts ...grpc.CallOption) (*Dispatch, error)
+	DeleteDispatch(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	WatchDispatches(ctx context.Context, in *WatchDispatchesRequest, opts ...grpc.CallOption) (DispatchService_WatchDispatchesClient, error)
+}
+
+type dispatchServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDispatchServiceClient(cc grpc.ClientConnInterface) DispatchServiceClient {
+	return &dispatchServiceClient{cc}
+}
+
+func (c *dispatchServiceClient) ListDispatches(ctx context.Context, in *ListDispatchesRequest, opts ...grpc.CallOption) (*ListDispatchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDispatchesResponse)
+	err := c.cc.Invoke(ctx, DispatchService_ListDispatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dispatchServiceClient) GetDispatch(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Dispatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Dispatch)
+	err := c.cc.Invoke(ctx, DispatchService_GetDispatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dispatchServiceClient) CreateDispatch(ctx context.Context, in *Dispatch, opts ...grpc.CallOption) (*Dispatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Dispatch)
+	err := c.cc.Invoke(ctx, DispatchService_CreateDispatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dispatchServiceClient) UpdateDispatch(ctx context.Context, in *UpdateDispatchRequest, opts ...grpc.CallOption) (*Dispatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Dispatch)
+	err := c.cc.Invoke(ctx, DispatchService_UpdateDispatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dispatchServiceClient) DeleteDispatch(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, DispatchService_DeleteDispatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dispatchServiceClient) WatchDispatches(ctx context.Context, in *WatchDispatchesRequest, opts ...grpc.CallOption) (DispatchService_WatchDispatchesClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DispatchService_ServiceDesc.Streams[0], DispatchService_WatchDispatches_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dispatchServiceWatchDispatchesClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DispatchService_WatchDispatchesClient interface {
+	Recv() (*DispatchEvent, error)
+	grpc.ClientStream
+}
+
+type dispatchServiceWatchDispatchesClient struct {
+	grpc.ClientStream
+}
+
+func (x *dispatchServiceWatchDispatchesClient) Recv() (*DispatchEvent, error) {
+	m := new(DispatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DispatchServiceServer is the server API for DispatchService service.
+// All implementations must embed UnimplementedDispatchServiceServer
+// for forward compatibility
+type DispatchServiceServer interface {
+	ListDispatches(context.Context, *ListDispatchesRequest) (*ListDispatchesResponse, error)
+	GetDispatch(context.Context, *IDRequest) (*Dispatch, error)
+	CreateDispatch(context.Context, *Dispatch) (*Dispatch, error)
+	UpdateDispatch(context.Context, *UpdateDispatchRequest) (*Dispatch, error)
+	DeleteDispatch(context.Context, *IDRequest) (*DeleteResponse, error)
+	WatchDispatches(*WatchDispatchesRequest, DispatchService_WatchDispatchesServer) error
+	mustEmbedUnimplementedDispatchServiceServer()
+}
+
+// UnimplementedDispatchServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDispatchServiceServer struct {
+}
+
+func (UnimplementedDispatchServiceServer) ListDispatches(context.Context, *ListDispatchesRequest) (*ListDispatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDispatches not implemented")
+}
+func (UnimplementedDispatchServiceServer) GetDispatch(context.Context, *IDRequest) (*Dispatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDispatch not implemented")
+}
+func (UnimplementedDispatchServiceServer) CreateDispatch(context.Context, *Dispatch) (*Dispatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDispatch not implemented")
+}
+func (UnimplementedDispatchServiceServer) UpdateDispatch(context.Context, *UpdateDispatchRequest) (*Dispatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDispatch not implemented")
+}
+func (UnimplementedDispatchServiceServer) DeleteDispatch(context.Context, *IDRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDispatch not implemented")
+}
+func (UnimplementedDispatchServiceServer) WatchDispatches(*WatchDispatchesRequest, DispatchService_WatchDispatchesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchDispatches not implemented")
+}
+func (UnimplementedDispatchServiceServer) mustEmbedUnimplementedDispatchServiceServer() {}
+
+// UnsafeDispatchServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DispatchServiceServer will
+// result in compilation errors.
+type UnsafeDispatchServiceServer interface {
+	mustEmbedUnimplementedDispatchServiceServer()
+}
+
+func RegisterDispatchServiceServer(s grpc.ServiceRegistrar, srv DispatchServiceServer) {
+	s.RegisterService(&DispatchService_ServiceDesc, srv)
+}
+
+func _DispatchService_ListDispatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDispatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispatchServiceServer).ListDispatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DispatchService_ListDispatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispatchServiceServer).ListDispatches(ctx, req.(*ListDispatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DispatchService_GetDispatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispatchServiceServer).GetDispatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DispatchService_GetDispatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispatchServiceServer).GetDispatch(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DispatchService_CreateDispatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Dispatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispatchServiceServer).CreateDispatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DispatchService_CreateDispatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispatchServiceServer).CreateDispatch(ctx, req.(*Dispatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DispatchService_UpdateDispatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDispatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispatchServiceServer).UpdateDispatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DispatchService_UpdateDispatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispatchServiceServer).UpdateDispatch(ctx, req.(*UpdateDispatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DispatchService_DeleteDispatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispatchServiceServer).DeleteDispatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DispatchService_DeleteDispatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispatchServiceServer).DeleteDispatch(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DispatchService_WatchDispatches_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDispatchesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DispatchServiceServer).WatchDispatches(m, &dispatchServiceWatchDispatchesServer{ServerStream: stream})
+}
+
+type DispatchService_WatchDispatchesServer interface {
+	Send(*DispatchEvent) error
+	grpc.ServerStream
+}
+
+type dispatchServiceWatchDispatchesServer struct {
+	grpc.ServerStream
+}
+
+func (x *dispatchServiceWatchDispatchesServer) Send(m *DispatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// DispatchService_ServiceDesc is the grpc.ServiceDesc for DispatchService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DispatchService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "logistics.v1.DispatchService",
+	HandlerType: (*DispatchServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDispatches",
+			Handler:    _DispatchService_ListDispatches_Handler,
+		},
+		{
+			MethodName: "GetDispatch",
+			Handler:    _DispatchService_GetDispatch_Handler,
+		},
+		{
+			MethodName: "CreateDispatch",
+			Handler:    _DispatchService_CreateDispatch_Handler,
+		},
+		{
+			MethodName: "UpdateDispatch",
+			Handler:    _DispatchService_UpdateDispatch_Handler,
+		},
+		{
+			MethodName: "DeleteDispatch",
+			Handler:    _DispatchService_DeleteDispatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchDispatches",
+			Handler:       _DispatchService_WatchDispatches_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "logistics.proto",
+}
+
+const (
+	CarrierService_ListCarriers_FullMethodName  = "/logistics.v1.CarrierService/ListCarriers"
+	CarrierService_GetCarrier_FullMethodName    = "/logistics.v1.CarrierService/GetCarrier"
+	CarrierService_CreateCarrier_FullMethodName = "/logistics.v1.CarrierService/CreateCarrier"
+	CarrierService_UpdateCarrier_FullMethodName = "/logistics.v1.CarrierService/UpdateCarrier"
+	CarrierService_DeleteCarrier_FullMethodName = "/logistics.v1.CarrierService/DeleteCarrier"
+)
+
+// CarrierServiceClient is the client API for CarrierService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CarrierServiceClient interface {
+	ListCarriers(ctx context.Context, in *ListCarriersRequest, opts ...grpc.CallOption) (*ListCarriersResponse, error)
+	GetCarrier(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Carrier, error)
+	CreateCarrier(ctx context.Context, in *Carrier, opts ...grpc.CallOption) (*Carrier, error)
+	UpdateCarrier(ctx context.Context, in *UpdateCarrierRequest, opts ...grpc.CallOption) (*Carrier, error)
+	DeleteCarrier(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+}
+
+type carrierServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCarrierServiceClient(cc grpc.ClientConnInterface) CarrierServiceClient {
+	return &carrierServiceClient{cc}
+}
+
+func (c *carrierServiceClient) ListCarriers(ctx context.Context, in *ListCarriersRequest, opts ...grpc.CallOption) (*ListCarriersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCarriersResponse)
+	err := c.cc.Invoke(ctx, CarrierService_ListCarriers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carrierServiceClient) GetCarrier(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Carrier, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Carrier)
+	err := c.cc.Invoke(ctx, CarrierService_GetCarrier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carrierServiceClient) CreateCarrier(ctx context.Context, in *Carrier, opts ...grpc.CallOption) (*Carrier, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Carrier)
+	err := c.cc.Invoke(ctx, CarrierService_CreateCarrier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carrierServiceClient) UpdateCarrier(ctx context.Context, in *UpdateCarrierRequest, opts ...grpc.CallOption) (*Carrier, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Carrier)
+	err := c.cc.Invoke(ctx, CarrierService_UpdateCarrier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carrierServiceClient) DeleteCarrier(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, CarrierService_DeleteCarrier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CarrierServiceServer is the server API for CarrierService service.
+// All implementations must embed UnimplementedCarrierServiceServer
+// for forward compatibility
+type CarrierServiceServer interface {
+	ListCarriers(context.Context, *ListCarriersRequest) (*ListCarriersResponse, error)
+	GetCarrier(context.Context, *IDRequest) (*Carrier, error)
+	CreateCarrier(context.Context, *Carrier) (*Carrier, error)
+	UpdateCarrier(context.Context, *UpdateCarrierRequest) (*Carrier, error)
+	DeleteCarrier(context.Context, *IDRequest) (*DeleteResponse, error)
+	mustEmbedUnimplementedCarrierServiceServer()
+}
+
+// UnimplementedCarrierServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCarrierServiceServer struct {
+}
+
+func (UnimplementedCarrierServiceServer) ListCarriers(context.Context, *ListCarriersRequest) (*ListCarriersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCarriers not implemented")
+}
+func (UnimplementedCarrierServiceServer) GetCarrier(context.Context, *IDRequest) (*Carrier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCarrier not implemented")
+}
+func (UnimplementedCarrierServiceServer) CreateCarrier(context.Context, *Carrier) (*Carrier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCarrier not implemented")
+}
+func (UnimplementedCarrierServiceServer) UpdateCarrier(context.Context, *UpdateCarrierRequest) (*Carrier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCarrier not implemented")
+}
+func (UnimplementedCarrierServiceServer) DeleteCarrier(context.Context, *IDRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCarrier not implemented")
+}
+func (UnimplementedCarrierServiceServer) mustEmbedUnimplementedCarrierServiceServer() {}
+
+// UnsafeCarrierServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CarrierServiceServer will
+// result in compilation errors.
+type UnsafeCarrierServiceServer interface {
+	mustEmbedUnimplementedCarrierServiceServer()
+}
+
+func RegisterCarrierServiceServer(s grpc.ServiceRegistrar, srv CarrierServiceServer) {
+	s.RegisterService(&CarrierService_ServiceDesc, srv)
+}
+
+func _CarrierService_ListCarriers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCarriersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarrierServiceServer).ListCarriers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarrierService_ListCarriers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarrierServiceServer).ListCarriers(ctx, req.(*ListCarriersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarrierService_GetCarrier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarrierServiceServer).GetCarrier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarrierService_GetCarrier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarrierServiceServer).GetCarrier(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarrierService_CreateCarrier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Carrier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarrierServiceServer).CreateCarrier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarrierService_CreateCarrier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarrierServiceServer).CreateCarrier(ctx, req.(*Carrier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarrierService_UpdateCarrier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCarrierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarrierServiceServer).UpdateCarrier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarrierService_UpdateCarrier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarrierServiceServer).UpdateCarrier(ctx, req.(*UpdateCarrierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarrierService_DeleteCarrier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarrierServiceServer).DeleteCarrier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarrierService_DeleteCarrier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarrierServiceServer).DeleteCarrier(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CarrierService_ServiceDesc is the grpc.ServiceDesc for CarrierService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CarrierService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "logistics.v1.CarrierService",
+	HandlerType: (*CarrierServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListCarriers",
+			Handler:    _CarrierService_ListCarriers_Handler,
+		},
+		{
+			MethodName: "GetCarrier",
+			Handler:    _CarrierService_GetCarrier_Handler,
+		},
+		{
+			MethodName: "CreateCarrier",
+			Handler:    _CarrierService_CreateCarrier_Handler,
+		},
+		{
+			MethodName: "UpdateCarrier",
+			Handler:    _CarrierService_UpdateCarrier_Handler,
+		},
+		{
+			MethodName: "DeleteCarrier",
+			Handler:    _CarrierService_DeleteCarrier_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "logistics.proto",
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"everflown-logistics/database"
+	"everflown-logistics/dates"
+	"everflown-logistics/models"
+	"gorm.io/gorm"
+)
+
+// archivable lists the tables whose records are moved to an archive table
+// along with their order.
+var archivable = map[string]bool{
+	"orders":     true,
+	"dispatches": true,
+	"invoices":   true,
+	"follow_ups": true,
+}
+
+// ArchiveTable returns the name of the archive table for a live table.
+func ArchiveTable(table string) string {
+	return "archived_" + table
+}
+
+// ArchiveRepository moves closed orders and the records that belong to them
+// between the live tables and the archive tables.
+type ArchiveRepository interface {
+	// Eligible returns the IDs of up to limit orders that can be archived:
+	// delivered or cancelled before cutoff, with every dispatch finished,
+	// every invoice paid or cancelled, a paid customer invoice if delivered,
+	// and no open follow-ups.
+	Eligible(ctx context.Context, cutoff dates.Date, limit int) ([]uint, error)
+	// Archive moves the orders with their dispatches, invoices and follow-ups
+	// to the archive tables, stamped with archivedAt. Each order is logged in
+	// the audit log.
+	Archive(ctx context.Context, ids []uint, archivedAt time.Time) error
+	// List returns the archived orders, most recently archived first.
+	List(ctx context.Context) ([]models.ArchivedOrder, error)
+	// Get returns an archived order with its dispatches, invoices and follow-ups.
+	Get(ctx context.Context, id uint) (*models.ArchivedOrder, error)
+	// Restore moves an archived order and its records back to the live tables
+	// with their original IDs.
+	Restore(ctx context.Context, id uint) error
+}
+
+type archiveRepository struct {
+	conn
+}
+
+func (r archiveRepository) Eligible(ctx context.Context, cutoff dates.Date, limit int) ([]uint, error) {
+	closed := []string{"delivered", "cancelled"}
+	settled := []string{"paid", "cancelled"}
+	var ids []uint
+	err := r.read(ctx, func(db *gorm.DB) error {
+		ids = nil
+		ownDispatches := db.Table("dispatches").Select("id").Where("order_id = o.id")
+		return db.Table("orders AS o").
+			Where("o.status IN ?", closed).
+			Where("COALESCE(o.delivery_date, o.pickup_date) < ?", cutoff).
+			Where("NOT EXISTS (?)", db.Table("dispatches AS d").Select("1").
+				Where("d.order_id = o.id AND d.status NOT IN ?", closed)).
+			Where("NOT EXISTS (?)", db.Table("follow_ups AS f").Select("1").
+				Where("f.order_id = o.id AND f.completed = ?", false)).
+			// Invoices are archived with the order, so they must be settled
+			// and must not also belong to another order.
+			Where("NOT EXISTS (?)", db.Table("invoices AS i").Select("1").
+				Where("i.order_id = o.id OR i.dispatch_id IN (?)", ownDispatches).
+				Where("i.status NOT IN ? OR i.order_id <> o.id OR i.dispatch_id NOT IN (?)", settled, ownDispatches)).
+			Where("o.status = ? OR EXISTS (?)", "cancelled", db.Table("invoices AS p").Select("1").
+				Where("p.order_id = o.id AND p.type = ? AND p.status = ?", "customer", "paid")).
+			Order("o.id").Limit(limit).Pluck("o.id", &ids).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r archiveRepository) Archive(ctx context.Context, ids []uint, archivedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var dispatchIDs []uint
+		if err := tx.Table("dispatches").Where("order_id IN ?", ids).Pluck("id", &dispatchIDs).Error; err != nil {
+			return err
+		}
+		invoices := tx.Where("order_id IN ? OR dispatch_id IN ?", ids, dispatchIDs)
+		moves := []struct {
+			model interface{}
+			where *gorm.DB
+		}{
+			{&models.Order{}, tx.Where("id IN ?", ids)},
+			{&models.Dispatch{}, tx.Where("order_id IN ?", ids)},
+			{&models.Invoice{}, invoices},
+			{&models.FollowUp{}, tx.Where("order_id IN ?", ids)},
+		}
+		for _, m := range moves {
+			if err := copyRows(tx, m.model, m.where, true, archivedAt); err != nil {
+				return err
+			}
+		}
+		// Children first, so nothing depends on the foreign keys cascading.
+		for i := len(moves) - 1; i >= 0; i-- {
+			if err := tx.Where(moves[i].where).Delete(moves[i].model).Error; err != nil {
+				return err
+			}
+		}
+		for _, id := range ids {
+			customerID, err := orderCustomer(tx, id)
+			if err != nil {
+				return err
+			}
+			if err := logEntry(tx, "orders", fmt.Sprint(id), ActionArchive, customerID, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r archiveRepository) List(ctx context.Context) ([]models.ArchivedOrder, error) {
+	var orders []models.ArchivedOrder
+	err := r.read(ctx, func(db *gorm.DB) error {
+		orders = nil
+		return db.Order("archived_at DESC, id").Find(&orders).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r archiveRepository) Get(ctx context.Context, id uint) (*models.ArchivedOrder, error) {
+	var order models.ArchivedOrder
+	err := r.read(ctx, func(db *gorm.DB) error {
+		order = models.ArchivedOrder{}
+		if err := db.First(&order, id).Error; err != nil {
+			return err
+		}
+		if err := db.Where("order_id = ?", id).Order("id").Find(&order.Dispatches).Error; err != nil {
+			return err
+		}
+		if err := db.Where("order_id = ?", id).Order("id").Find(&order.FollowUps).Error; err != nil {
+			return err
+		}
+		return db.Where("order_id = ? OR dispatch_id IN (?)", id,
+			db.Model(&models.ArchivedDispatch{}).Select("id").Where("order_id = ?", id)).
+			Order("id").Find(&order.Invoices).Error
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &order, nil
+}
+
+func (r archiveRepository) Restore(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var order models.ArchivedOrder
+		if err := tx.Select("id").First(&order, id).Error; err != nil {
+			return translateError(err)
+		}
+		var dispatchIDs []uint
+		if err := tx.Model(&models.ArchivedDispatch{}).Where("order_id = ?", id).Pluck("id", &dispatchIDs).Error; err != nil {
+			return err
+		}
+		moves := []struct {
+			model, archived interface{}
+			where           *gorm.DB
+		}{
+			{&models.Order{}, &models.ArchivedOrder{}, tx.Where("id = ?", id)},
+			{&models.Dispatch{}, &models.ArchivedDispatch{}, tx.Where("order_id = ?", id)},
+			{&models.Invoice{}, &models.ArchivedInvoice{}, tx.Where("order_id = ? OR dispatch_id IN ?", id, dispatchIDs)},
+			{&models.FollowUp{}, &models.ArchivedFollowUp{}, tx.Where("order_id = ?", id)},
+		}
+		for _, m := range moves {
+			if err := copyRows(tx, m.model, m.where, false, time.Time{}); err != nil {
+				return translateRestoreError(err)
+			}
+		}
+		for i := len(moves) - 1; i >= 0; i-- {
+			if err := tx.Where(moves[i].where).Delete(moves[i].archived).Error; err != nil {
+				return err
+			}
+		}
+		customerID, err := orderCustomer(tx, id)
+		if err != nil {
+			return err
+		}
+		return logEntry(tx, "orders", fmt.Sprint(id), ActionRestore, customerID, nil)
+	})
+}
+
+// copyRows copies the rows of model's table matching where into its archive
+// table, stamped with archivedAt, or with toArchive false copies them from
+// the archive table back to the live one. Every column, including the ID,
+// is copied.
+func copyRows(tx *gorm.DB, model interface{}, where *gorm.DB, toArchive bool, archivedAt time.Time) error {
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+	columns := make([]string, 0, len(stmt.Schema.DBNames))
+	for _, name := range stmt.Schema.DBNames {
+		columns = append(columns, stmt.Quote(name))
+	}
+	list := strings.Join(columns, ", ")
+
+	from, to := stmt.Schema.Table, ArchiveTable(stmt.Schema.Table)
+	target, source := list, list
+	var args []interface{}
+	if toArchive {
+		target += ", " + stmt.Quote("archived_at")
+		source += ", ?"
+		args = append(args, archivedAt)
+	} else {
+		from, to = to, from
+	}
+	query := tx.Session(&gorm.Session{NewDB: true}).Table(from).Select(source, args...).Where(where)
+	return tx.Exec(fmt.Sprintf("INSERT INTO %s (%s) ?", stmt.Quote(to), target), query).Error
+}
+
+// translateRestoreError maps the failures of putting archived records back:
+// a live record that has since taken an order or invoice number, or a
+// reference to a record that no longer exists.
+func translateRestoreError(err error) error {
+	if database.IsUniqueViolation(err) {
+		return fmt.Errorf("%w: %v", ErrConflict, err)
+	}
+	return translateWriteError(err)
+}
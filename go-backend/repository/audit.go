@@ -0,0 +1,391 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"everflown-logistics/audit"
+	"everflown-logistics/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Audit log actions.
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionArchive = "archive"
+	ActionRestore = "restore"
+)
+
+// AuditFilter narrows an audit log query. Zero fields match everything.
+type AuditFilter struct {
+	Entity   string
+	EntityID string
+	UserID   string
+	// From and To bound when the change was made; To is exclusive.
+	From time.Time
+	To   time.Time
+	// BeforeID pages back through the log, returning only older entries.
+	BeforeID uint
+	Limit    int
+}
+
+// AuditRepository reads the audit log. Entries are written by the other
+// repositories, in the same transaction as the change they record.
+type AuditRepository interface {
+	// List returns the entries matching filter, newest first.
+	List(ctx context.Context, filter AuditFilter) ([]models.AuditEntry, error)
+	// ForRecord returns every entry for one record, oldest first.
+	ForRecord(ctx context.Context, entity, entityID string) ([]models.AuditEntry, error)
+	// After returns up to limit entries with IDs above afterID, oldest first,
+	// for the given entities or for all of them if there are none.
+	After(ctx context.Context, afterID uint, entities []string, limit int) ([]models.AuditEntry, error)
+	// LatestID returns the ID of the newest entry, or 0 if there are none.
+	LatestID(ctx context.Context) (uint, error)
+}
+
+type auditRepository struct {
+	conn
+}
+
+func (r auditRepository) List(ctx context.Context, filter AuditFilter) ([]models.AuditEntry, error) {
+	var entries []models.AuditEntry
+	err := r.read(ctx, func(db *gorm.DB) error {
+		entries = nil
+		query := db.Order("id DESC").Limit(filter.Limit)
+		if filter.Entity != "" {
+			query = query.Where("entity = ?", filter.Entity)
+		}
+		if filter.EntityID != "" {
+			query = query.Where("entity_id = ?", filter.EntityID)
+		}
+		if filter.UserID != "" {
+			query = query.Where("user_id = ?", filter.UserID)
+		}
+		if !filter.From.IsZero() {
+			query = query.Where("occurred_at >= ?", filter.From)
+		}
+		if !filter.To.IsZero() {
+			query = query.Where("occurred_at < ?", filter.To)
+		}
+		if filter.BeforeID != 0 {
+			query = query.Where("id < ?", filter.BeforeID)
+		}
+		return query.Find(&entries).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r auditRepository) ForRecord(ctx context.Context, entity, entityID string) ([]models.AuditEntry, error) {
+	var entries []models.AuditEntry
+	err := r.read(ctx, func(db *gorm.DB) error {
+		entries = nil
+		return db.Where("entity = ? AND entity_id = ?", entity, entityID).Order("id").Find(&entries).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r auditRepository) After(ctx context.Context, afterID uint, entities []string, limit int) ([]models.AuditEntry, error) {
+	var entries []models.AuditEntry
+	err := r.read(ctx, func(db *gorm.DB) error {
+		entries = nil
+		query := db.Where("id > ?", afterID)
+		if len(entities) > 0 {
+			query = query.Where("entity IN ?", entities)
+		}
+		return query.Order("id").Limit(limit).Find(&entries).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r auditRepository) LatestID(ctx context.Context) (uint, error) {
+	var latest uint
+	err := r.read(ctx, func(db *gorm.DB) error {
+		return db.Model(&models.AuditEntry{}).Select("COALESCE(MAX(id), 0)").Scan(&latest).Error
+	})
+	return latest, err
+}
+
+// logChange appends an audit entry for a change to a record, attributed to
+// the actor in tx's context. before is nil for a create and after for a
+// delete; both point to a model. An update that changed nothing is not
+// logged.
+func logChange(tx *gorm.DB, action string, before, after interface{}) error {
+	record := after
+	if record == nil {
+		record = before
+	}
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(record); err != nil {
+		return err
+	}
+	customerID, err := recordCustomer(tx, stmt.Schema, record)
+	if err != nil {
+		return err
+	}
+	return logOwnedChange(tx, stmt.Schema, action, before, after, customerID)
+}
+
+// logOwnedChange is logChange for a record of s whose owning customer is
+// already known.
+func logOwnedChange(tx *gorm.DB, s *schema.Schema, action string, before, after interface{}, customerID *uint) error {
+	record := after
+	if record == nil {
+		record = before
+	}
+	ctx := tx.Statement.Context
+	id, _ := s.PrioritizedPrimaryField.ValueOf(ctx, reflect.ValueOf(record).Elem())
+	changes := diff(ctx, s, before, after)
+	if action == ActionUpdate && len(changes) == 0 {
+		return nil
+	}
+	return logEntry(tx, s.Table, fmt.Sprint(id), action, customerID, changes)
+}
+
+// logEntry appends an audit entry for the record of table with the given ID,
+// owned by customerID.
+func logEntry(tx *gorm.DB, table, id, action string, customerID *uint, changes models.Changes) error {
+	actor := audit.ActorFrom(tx.Statement.Context)
+	var tenantID *string
+	if actor.TenantID != "" {
+		tenantID = &actor.TenantID
+	}
+	entry := models.AuditEntry{
+		OccurredAt: time.Now().UTC(),
+		UserID:     actor.UserID,
+		TenantID:   tenantID,
+		RequestID:  actor.RequestID,
+		CustomerID: customerID,
+		Entity:     table,
+		EntityID:   id,
+		Action:     action,
+		Changes:    changes,
+	}
+	if len(entry.Changes) == 0 {
+		entry.Changes = nil
+	}
+	return tx.Create(&entry).Error
+}
+
+// recordCustomer returns the customer owning record, a pointer to a model of
+// s: a customer owns itself, records with a customer belong to it, and the
+// rest of an order's records belong to the order's customer.
+func recordCustomer(tx *gorm.DB, s *schema.Schema, record interface{}) (*uint, error) {
+	ctx := tx.Statement.Context
+	value := reflect.ValueOf(record).Elem()
+	if s.Table == "customers" {
+		id, _ := s.PrioritizedPrimaryField.ValueOf(ctx, value)
+		return uintValue(id), nil
+	}
+	if field := s.LookUpField("customer_id"); field != nil {
+		if v, _ := field.ValueOf(ctx, value); uintValue(v) != nil {
+			return uintValue(v), nil
+		}
+	}
+	if field := s.LookUpField("order_id"); field != nil {
+		if v, _ := field.ValueOf(ctx, value); uintValue(v) != nil {
+			return orderCustomer(tx, *uintValue(v))
+		}
+	}
+	return nil, nil
+}
+
+// rowCustomer returns the customer owning the row of table with the given
+// ID, or nil if it has none or the table has no model.
+func rowCustomer(tx *gorm.DB, table string, id uint) (*uint, error) {
+	modelType, ok := modelTypes()[table]
+	if !ok {
+		return nil, nil
+	}
+	record := reflect.New(modelType).Interface()
+	if err := tx.First(record, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(record); err != nil {
+		return nil, err
+	}
+	return recordCustomer(tx, stmt.Schema, record)
+}
+
+// orderCustomer returns the customer of an order, live or archived.
+func orderCustomer(tx *gorm.DB, orderID uint) (*uint, error) {
+	var owners []struct{ CustomerID *uint }
+	err := tx.Raw("SELECT customer_id FROM orders WHERE id = ? UNION ALL SELECT customer_id FROM archived_orders WHERE id = ?",
+		orderID, orderID).Scan(&owners).Error
+	if err != nil || len(owners) == 0 {
+		return nil, err
+	}
+	return owners[0].CustomerID, nil
+}
+
+// uintValue returns an ID field's value, a uint or *uint, or nil if it's
+// unset.
+func uintValue(v interface{}) *uint {
+	switch id := v.(type) {
+	case uint:
+		if id != 0 {
+			return &id
+		}
+	case *uint:
+		if id != nil && *id != 0 {
+			return id
+		}
+	}
+	return nil
+}
+
+// diff returns the fields of s that differ between before and after, either
+// of which may be nil. For a create or delete, fields with zero values are
+// left out. The primary key and the timestamps GORM maintains are never
+// included, and fields tagged secret are reported without their values.
+func diff(ctx context.Context, s *schema.Schema, before, after interface{}) models.Changes {
+	changes := models.Changes{}
+	for _, field := range s.Fields {
+		if field.DBName == "" || field.PrimaryKey || field.AutoCreateTime != 0 || field.AutoUpdateTime != 0 {
+			continue
+		}
+		from, fromZero := fieldJSON(ctx, field, before)
+		to, toZero := fieldJSON(ctx, field, after)
+		if bytes.Equal(from, to) || (before == nil && toZero) || (after == nil && fromZero) {
+			continue
+		}
+		change := models.FieldChange{From: from, To: to}
+		if field.Tag.Get("secret") == "true" {
+			change = models.FieldChange{}
+		}
+		changes[JSONName(field)] = change
+	}
+	return changes
+}
+
+// fieldJSON returns field's value in record, a pointer to a model, as JSON.
+func fieldJSON(ctx context.Context, field *schema.Field, record interface{}) (json.RawMessage, bool) {
+	if record == nil {
+		return nil, true
+	}
+	value, isZero := field.ValueOf(ctx, reflect.ValueOf(record).Elem())
+	data, err := json.Marshal(value)
+	if err != nil {
+		return json.RawMessage(fmt.Sprintf("%q", fmt.Sprint(value))), isZero
+	}
+	return data, isZero
+}
+
+// JSONName returns the name the API uses for field.
+func JSONName(field *schema.Field) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return field.DBName
+	}
+	return name
+}
+
+// modelTypes maps each table to its model type, for loading rows a delete
+// cascades to.
+var modelTypes = sync.OnceValue(func() map[string]reflect.Type {
+	types := map[string]reflect.Type{}
+	cache := &sync.Map{}
+	for _, model := range append(models.All(), models.Archived()...) {
+		s, err := schema.Parse(model, cache, schema.NamingStrategy{})
+		if err != nil {
+			panic(fmt.Sprintf("repository: parsing %T: %v", model, err))
+		}
+		types[s.Table] = s.ModelType
+	}
+	return types
+})
+
+// cascadedRow is a row a delete will cascade to, with its owning customer,
+// which can't be looked up once its parent is gone.
+type cascadedRow struct {
+	schema     *schema.Schema
+	record     interface{}
+	customerID *uint
+}
+
+// snapshotCascaded loads the rows a delete will cascade to, so they can be
+// logged once it has happened.
+func snapshotCascaded(tx *gorm.DB, effects *deleteEffects) ([]cascadedRow, error) {
+	tables := make([]string, 0, len(effects.cascaded))
+	for table := range effects.cascaded {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	var rows []cascadedRow
+	for _, table := range tables {
+		ids := effects.cascaded[table]
+		modelType, ok := modelTypes()[table]
+		if !ok {
+			continue
+		}
+		records := reflect.New(reflect.SliceOf(modelType))
+		if err := tx.Where("id IN ?", ids).Find(records.Interface()).Error; err != nil {
+			return nil, err
+		}
+		stmt := &gorm.Statement{DB: tx}
+		if err := stmt.Parse(records.Interface()); err != nil {
+			return nil, err
+		}
+		for i := 0; i < records.Elem().Len(); i++ {
+			record := records.Elem().Index(i).Addr().Interface()
+			customerID, err := recordCustomer(tx, stmt.Schema, record)
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, cascadedRow{schema: stmt.Schema, record: record, customerID: customerID})
+		}
+	}
+	return rows, nil
+}
+
+// logDeleteEffects logs the deletes a delete cascaded to and the links it
+// cleared.
+func logDeleteEffects(tx *gorm.DB, cascaded []cascadedRow, effects *deleteEffects) error {
+	for _, row := range cascaded {
+		if err := logOwnedChange(tx, row.schema, ActionDelete, row.record, nil, row.customerID); err != nil {
+			return err
+		}
+	}
+	for _, links := range effects.cleared {
+		name := links.Column
+		if modelType, ok := modelTypes()[links.Table]; ok {
+			stmt := &gorm.Statement{DB: tx}
+			if err := stmt.Parse(reflect.New(modelType).Interface()); err != nil {
+				return err
+			}
+			if field := stmt.Schema.LookUpField(links.Column); field != nil {
+				name = JSONName(field)
+			}
+		}
+		for _, row := range links.Rows {
+			customerID, err := rowCustomer(tx, links.Table, row.ID)
+			if err != nil {
+				return err
+			}
+			changes := models.Changes{name: {From: json.RawMessage(fmt.Sprint(row.Ref)), To: json.RawMessage("null")}}
+			if err := logEntry(tx, links.Table, fmt.Sprint(row.ID), ActionUpdate, customerID, changes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"everflown-logistics/database"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// conn is the database handle shared by a set of repositories.
+type conn struct {
+	db *gorm.DB
+	// readRetry applies to idempotent reads; it is disabled inside
+	// transactions, where a failed statement aborts the whole transaction.
+	readRetry database.RetryPolicy
+}
+
+// read runs an idempotent query, retrying transient failures such as a
+// dropped connection. query must reset anything it scans into.
+func (c conn) read(ctx context.Context, query func(db *gorm.DB) error) error {
+	return c.readRetry.Do(ctx, func() error {
+		return query(c.db.WithContext(ctx))
+	})
+}
+
+// report runs a heavy, idempotent read on the reporting replica when one is
+// configured. Results may lag the primary slightly, so never use it to read
+// back a write. If the replica is unavailable the query falls back to the
+// primary.
+func (c conn) report(ctx context.Context, query func(db *gorm.DB) error) error {
+	err := c.read(ctx, func(db *gorm.DB) error {
+		return query(db.Clauses(dbresolver.Use(database.Reporting)).Session(&gorm.Session{}))
+	})
+	if err != nil && database.IsTransient(err) && database.HasReplica(c.db) {
+		slog.Warn("Read replica unavailable, falling back to the primary", "error", err)
+		return c.read(ctx, query)
+	}
+	return err
+}
+
+// gormRepository implements CRUD for any model with a numeric primary key.
+type gormRepository[T any] struct {
+	conn
+}
+
+func (r gormRepository[T]) List(ctx context.Context) ([]T, error) {
+	var records []T
+	err := r.read(ctx, func(db *gorm.DB) error {
+		records = nil
+		return db.Find(&records).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (r gormRepository[T]) Get(ctx context.Context, id uint) (*T, error) {
+	var record T
+	err := r.read(ctx, func(db *gorm.DB) error {
+		record = *new(T)
+		return db.First(&record, id).Error
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &record, nil
+}
+
+// Create inserts the record and logs it in the audit log.
+func (r gormRepository[T]) Create(ctx context.Context, record *T) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(record).Error; err != nil {
+			return translateWriteError(err)
+		}
+		return logChange(tx, ActionCreate, nil, record)
+	})
+}
+
+// Update applies the non-zero fields of changes and logs the fields that
+// changed in the audit log.
+func (r gormRepository[T]) Update(ctx context.Context, id uint, changes *T) (*T, error) {
+	var after T
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before T
+		if err := tx.First(&before, id).Error; err != nil {
+			return translateError(err)
+		}
+		record := before
+		// The path ID wins over any ID in the body, and creation time is immutable.
+		if err := tx.Model(&record).Omit("ID", "CreatedAt").Updates(changes).Error; err != nil {
+			return translateWriteError(err)
+		}
+		if err := tx.First(&after, id).Error; err != nil {
+			return err
+		}
+		return logChange(tx, ActionUpdate, &before, &after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &after, nil
+}
+
+// Delete removes the record along with the records its foreign keys cascade
+// to, logging each of them and every link cleared in the audit log. It
+// returns a DependentsError, and deletes nothing, if any record that would be
+// left behind still refers to it.
+func (r gormRepository[T]) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before T
+		if err := tx.First(&before, id).Error; err != nil {
+			return translateError(err)
+		}
+		stmt := &gorm.Statement{DB: tx}
+		if err := stmt.Parse(new(T)); err != nil {
+			return err
+		}
+		effects, err := checkDependents(tx, stmt.Schema.Table, id)
+		if err != nil {
+			return err
+		}
+		cascaded, err := snapshotCascaded(tx, effects)
+		if err != nil {
+			return err
+		}
+		result := tx.Delete(new(T), id)
+		if result.Error != nil {
+			// A dependent added since the check still fails the foreign key.
+			if database.IsForeignKeyViolation(result.Error) {
+				return &DependentsError{Table: stmt.Schema.Table, ID: id}
+			}
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		if err := logChange(tx, ActionDelete, &before, nil); err != nil {
+			return err
+		}
+		return logDeleteEffects(tx, cascaded, effects)
+	})
+}
+
+type quoteRepository struct {
+	gormRepository[models.Quote]
+}
+
+func (r quoteRepository) GetWithParties(ctx context.Context, id uint) (*models.Quote, error) {
+	var quote models.Quote
+	err := r.read(ctx, func(db *gorm.DB) error {
+		quote = models.Quote{}
+		return db.Preload("Lead").Preload("Customer").First(&quote, id).Error
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &quote, nil
+}
+
+type invoiceRepository struct {
+	gormRepository[models.Invoice]
+}
+
+func (r invoiceRepository) GetWithParties(ctx context.Context, id uint) (*models.Invoice, error) {
+	var invoice models.Invoice
+	err := r.read(ctx, func(db *gorm.DB) error {
+		invoice = models.Invoice{}
+		return db.Preload("Order").Preload("Customer").First(&invoice, id).Error
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &invoice, nil
+}
+
+type followUpRepository struct {
+	gormRepository[models.FollowUp]
+}
+
+func (r followUpRepository) ListUrgent(ctx context.Context) ([]models.FollowUp, error) {
+	var followUps []models.FollowUp
+	err := r.read(ctx, func(db *gorm.DB) error {
+		followUps = nil
+		return db.Where("priority = ? AND completed = ?", "high", false).Find(&followUps).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return followUps, nil
+}
+
+type userRepository struct {
+	conn
+}
+
+func (r userRepository) List(ctx context.Context) ([]models.User, error) {
+	var users []models.User
+	err := r.read(ctx, func(db *gorm.DB) error {
+		users = nil
+		return db.Find(&users).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r userRepository) Get(ctx context.Context, id string) (*models.User, error) {
+	return r.findOne(ctx, "id = ?", id)
+}
+
+func (r userRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	return r.findOne(ctx, "username = ?", username)
+}
+
+func (r userRepository) findOne(ctx context.Context, query string, arg string) (*models.User, error) {
+	var user models.User
+	err := r.read(ctx, func(db *gorm.DB) error {
+		user = models.User{}
+		return db.Where(query, arg).First(&user).Error
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &user, nil
+}
+
+func (r userRepository) Create(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return logChange(tx, ActionCreate, nil, user)
+	})
+}
+
+func (r userRepository) Update(ctx context.Context, id string, changes *models.User) (*models.User, error) {
+	var after models.User
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before models.User
+		if err := tx.Where("id = ?", id).First(&before).Error; err != nil {
+			return translateError(err)
+		}
+		user := before
+		if err := tx.Model(&user).Omit("ID", "CreatedAt").Updates(changes).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", id).First(&after).Error; err != nil {
+			return err
+		}
+		return logChange(tx, ActionUpdate, &before, &after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &after, nil
+}
+
+func (r userRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before models.User
+		if err := tx.Where("id = ?", id).First(&before).Error; err != nil {
+			return translateError(err)
+		}
+		if err := tx.Delete(&before).Error; err != nil {
+			return err
+		}
+		return logChange(tx, ActionDelete, &before, nil)
+	})
+}
+
+type dashboardRepository struct {
+	conn
+}
+
+func (r dashboardRepository) Stats(ctx context.Context) (DashboardStats, error) {
+	var stats DashboardStats
+	err := r.report(ctx, func(db *gorm.DB) error {
+		stats = DashboardStats{}
+		// Revenue includes the invoices of archived orders.
+		var revenue, archivedRevenue []struct {
+			Currency string
+			Total    money.Amount
+		}
+		err := errors.Join(
+			db.Model(&models.Order{}).Where("status IN ?", []string{"dispatched", "in_transit", "needs_truck"}).Count(&stats.ActiveOrders).Error,
+			db.Model(&models.Order{}).Where("status = ?", "in_transit").Count(&stats.InTransit).Error,
+			db.Model(&models.Quote{}).Where("status = ?", "pending").Count(&stats.PendingQuotes).Error,
+			db.Model(&models.Invoice{}).Where("type = ? AND status = ?", "customer", "paid").Select("currency, SUM(amount) AS total").Group("currency").Scan(&revenue).Error,
+			db.Model(&models.ArchivedInvoice{}).Where("type = ? AND status = ?", "customer", "paid").Select("currency, SUM(amount) AS total").Group("currency").Scan(&archivedRevenue).Error,
+		)
+		stats.Revenue = make(map[string]money.Amount, len(revenue))
+		for _, r := range append(revenue, archivedRevenue...) {
+			stats.Revenue[r.Currency] = stats.Revenue[r.Currency].Add(r.Total)
+		}
+		return err
+	})
+	return stats, err
+}
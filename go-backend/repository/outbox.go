@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"everflown-logistics/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PendingEvent is an event a subscriber hasn't handled yet.
+type PendingEvent struct {
+	models.OutboxEvent
+	// Attempts counts the subscriber's failed attempts at the event so far.
+	Attempts int
+}
+
+// OutboxRepository stores domain events until every subscriber has handled
+// them, and which events each subscriber has handled.
+type OutboxRepository interface {
+	// Append adds events to the outbox. Call it on repositories bound to the
+	// transaction making the change, so the events commit with it.
+	Append(ctx context.Context, events ...models.OutboxEvent) error
+	// Pending returns up to limit events subscriber hasn't handled, oldest
+	// first. Since events are found by what was handled rather than by a
+	// position, one whose transaction committed after later events is still
+	// returned.
+	Pending(ctx context.Context, subscriber string, limit int) ([]PendingEvent, error)
+	// Handled records that subscriber has handled the events.
+	Handled(ctx context.Context, subscriber string, at time.Time, eventIDs ...uint) error
+	// Failed records a failed attempt by subscriber at an event, leaving it
+	// pending.
+	Failed(ctx context.Context, subscriber string, eventID uint, attempts int, reason string) error
+	// GiveUp records that subscriber gave up on an event after its last
+	// attempt, and keeps the event as a dead letter.
+	GiveUp(ctx context.Context, subscriber string, eventID uint, attempts int, reason string, at time.Time) error
+	// DeadLetters returns up to limit dead letters with IDs below beforeID (or
+	// the newest, if beforeID is 0), newest first, with their events.
+	DeadLetters(ctx context.Context, beforeID uint, limit int) ([]models.EventDeadLetter, error)
+	// RetryDeadLetter deletes a dead letter and its subscriber's record of
+	// the event, so the subscriber is handed the event again.
+	RetryDeadLetter(ctx context.Context, id uint) (*models.EventDeadLetter, error)
+	// Register creates a cursor for subscriber unless it already has one. A
+	// new subscriber is handed every event still in the outbox.
+	Register(ctx context.Context, subscriber string) error
+	// Claim leases subscriber's cursor to owner until until. It returns false
+	// if another owner holds the lease, or a retry isn't due yet, at now.
+	Claim(ctx context.Context, subscriber, owner string, now, until time.Time) (bool, error)
+	// Release ends owner's lease on subscriber's cursor. A non-zero retryAt
+	// holds off the next claim until then.
+	Release(ctx context.Context, subscriber, owner string, retryAt time.Time) error
+	// Prune deletes events that occurred before cutoff and that every
+	// subscriber has handled, other than dead letters, returning how many
+	// were deleted.
+	Prune(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type outboxRepository struct {
+	conn
+}
+
+func (r outboxRepository) Append(ctx context.Context, events ...models.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&events).Error
+}
+
+func (r outboxRepository) Pending(ctx context.Context, subscriber string, limit int) ([]PendingEvent, error) {
+	var events []PendingEvent
+	err := r.read(ctx, func(db *gorm.DB) error {
+		events = nil
+		return db.Table("event_outbox").
+			Select("event_outbox.*, COALESCE(d.attempts, 0) AS attempts").
+			Joins("LEFT JOIN event_deliveries d ON d.event_id = event_outbox.id AND d.subscriber = ?", subscriber).
+			Where("d.handled_at IS NULL").
+			Order("event_outbox.id").Limit(limit).
+			Scan(&events).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r outboxRepository) Handled(ctx context.Context, subscriber string, at time.Time, eventIDs ...uint) error {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+	handledAt := at.UTC()
+	deliveries := make([]models.EventDelivery, len(eventIDs))
+	for i, id := range eventIDs {
+		deliveries[i] = models.EventDelivery{Subscriber: subscriber, EventID: id, HandledAt: &handledAt}
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subscriber"}, {Name: "event_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"handled_at"}),
+	}).Create(&deliveries).Error
+}
+
+func (r outboxRepository) Failed(ctx context.Context, subscriber string, eventID uint, attempts int, reason string) error {
+	delivery := models.EventDelivery{Subscriber: subscriber, EventID: eventID, Attempts: attempts, LastError: &reason}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subscriber"}, {Name: "event_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"attempts", "last_error"}),
+	}).Create(&delivery).Error
+}
+
+func (r outboxRepository) GiveUp(ctx context.Context, subscriber string, eventID uint, attempts int, reason string, at time.Time) error {
+	at = at.UTC()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		delivery := models.EventDelivery{Subscriber: subscriber, EventID: eventID, Attempts: attempts, LastError: &reason, HandledAt: &at}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subscriber"}, {Name: "event_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"attempts", "last_error", "handled_at"}),
+		}).Create(&delivery).Error
+		if err != nil {
+			return err
+		}
+		letter := models.EventDeadLetter{Subscriber: subscriber, EventID: eventID, Attempts: attempts, LastError: reason, FailedAt: at}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subscriber"}, {Name: "event_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"attempts", "last_error", "failed_at"}),
+		}).Create(&letter).Error
+	})
+}
+
+func (r outboxRepository) DeadLetters(ctx context.Context, beforeID uint, limit int) ([]models.EventDeadLetter, error) {
+	var letters []models.EventDeadLetter
+	err := r.read(ctx, func(db *gorm.DB) error {
+		letters = nil
+		query := db.Preload("Event")
+		if beforeID != 0 {
+			query = query.Where("id < ?", beforeID)
+		}
+		return query.Order("id DESC").Limit(limit).Find(&letters).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return letters, nil
+}
+
+func (r outboxRepository) RetryDeadLetter(ctx context.Context, id uint) (*models.EventDeadLetter, error) {
+	var letter models.EventDeadLetter
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Preload("Event").First(&letter, id).Error; err != nil {
+			return translateError(err)
+		}
+		if err := tx.Delete(&letter).Error; err != nil {
+			return err
+		}
+		return tx.Where("subscriber = ? AND event_id = ?", letter.Subscriber, letter.EventID).
+			Delete(&models.EventDelivery{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &letter, nil
+}
+
+func (r outboxRepository) Register(ctx context.Context, subscriber string) error {
+	cursor := models.EventCursor{Subscriber: subscriber}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&cursor).Error
+}
+
+func (r outboxRepository) Claim(ctx context.Context, subscriber, owner string, now, until time.Time) (bool, error) {
+	// Times are compared in UTC, since SQLite compares them as text.
+	result := r.db.WithContext(ctx).Model(&models.EventCursor{}).
+		Where("subscriber = ? AND (locked_until IS NULL OR locked_until <= ?)", subscriber, now.UTC()).
+		Updates(map[string]interface{}{"locked_by": owner, "locked_until": until.UTC()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r outboxRepository) Release(ctx context.Context, subscriber, owner string, retryAt time.Time) error {
+	var lockedUntil *time.Time
+	if !retryAt.IsZero() {
+		retryAt = retryAt.UTC()
+		lockedUntil = &retryAt
+	}
+	result := r.db.WithContext(ctx).Model(&models.EventCursor{}).
+		Where("subscriber = ? AND locked_by = ?", subscriber, owner).
+		Updates(map[string]interface{}{"locked_by": nil, "locked_until": lockedUntil})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		// The lease expired and another owner took over.
+		return ErrConflict
+	}
+	return nil
+}
+
+func (r outboxRepository) Prune(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("occurred_at < ?", cutoff.UTC()).
+		Where(`NOT EXISTS (SELECT 1 FROM event_cursors c WHERE NOT EXISTS (
+			SELECT 1 FROM event_deliveries d
+			WHERE d.subscriber = c.subscriber AND d.event_id = event_outbox.id AND d.handled_at IS NOT NULL))`).
+		Where("NOT EXISTS (SELECT 1 FROM event_dead_letters l WHERE l.event_id = event_outbox.id)").
+		Delete(&models.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"everflown-logistics/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Delete behaviors, as set with constraint:OnDelete on the model relations
+// and enforced by the foreign keys in the migrations.
+const (
+	Restrict = "RESTRICT"
+	Cascade  = "CASCADE"
+	SetNull  = "SET NULL"
+)
+
+// Reference is a foreign key from Table.Column to the Parent table's ID, and
+// what deleting a parent row does to the rows that refer to it.
+type Reference struct {
+	Table    string
+	Column   string
+	Parent   string
+	OnDelete string
+}
+
+// References lists every foreign key between the models, read from their
+// constraint tags, followed by the matching keys of the archive tables.
+var References = sync.OnceValue(func() []Reference {
+	var refs []Reference
+	cache := &sync.Map{}
+	for _, model := range models.All() {
+		s, err := schema.Parse(model, cache, schema.NamingStrategy{})
+		if err != nil {
+			panic(fmt.Sprintf("repository: parsing %T: %v", model, err))
+		}
+		for _, rel := range s.Relationships.BelongsTo {
+			constraint := rel.ParseConstraint()
+			if constraint == nil || constraint.OnDelete == "" {
+				continue
+			}
+			refs = append(refs, Reference{
+				Table:    constraint.Schema.Table,
+				Column:   constraint.ForeignKeys[0].DBName,
+				Parent:   constraint.ReferenceSchema.Table,
+				OnDelete: strings.ToUpper(constraint.OnDelete),
+			})
+		}
+	}
+
+	// The archive tables mirror the live keys, with references between
+	// archived records pointing at the archive tables.
+	for _, ref := range refs {
+		if !archivable[ref.Table] {
+			continue
+		}
+		archived := ref
+		archived.Table = ArchiveTable(ref.Table)
+		if archivable[ref.Parent] {
+			archived.Parent = ArchiveTable(ref.Parent)
+		}
+		refs = append(refs, archived)
+	}
+	return refs
+})
+
+// maxDependentIDs caps the IDs listed per table in a DependentsError.
+const maxDependentIDs = 20
+
+// Dependent is a set of records that stop a delete.
+type Dependent struct {
+	Table string `json:"table"`
+	Count int    `json:"count"`
+	// IDs lists up to 20 of the records, lowest first.
+	IDs []uint `json:"ids"`
+}
+
+// DependentsError is returned when deleting a record would orphan others
+// that refer to it, either directly or through records the delete cascades to.
+type DependentsError struct {
+	Table      string
+	ID         uint
+	Dependents []Dependent
+}
+
+func (e *DependentsError) Error() string {
+	if len(e.Dependents) == 0 {
+		return fmt.Sprintf("%s %d is still referenced by other records", e.Table, e.ID)
+	}
+	parts := make([]string, len(e.Dependents))
+	for i, d := range e.Dependents {
+		parts[i] = fmt.Sprintf("%d %s", d.Count, d.Table)
+	}
+	return fmt.Sprintf("%s %d is still referenced by %s", e.Table, e.ID, strings.Join(parts, ", "))
+}
+
+// deleteEffects are the rows a delete reaches through foreign keys.
+type deleteEffects struct {
+	// blocking holds, by table, the rows whose RESTRICT key refuses the delete.
+	blocking map[string]map[uint]bool
+	// cascaded holds, by table, the rows deleted along with it.
+	cascaded map[string][]uint
+	// cleared lists the rows whose SET NULL key is cleared.
+	cleared []clearedLinks
+}
+
+// clearedLinks are rows of Table whose Column is set to null, with the IDs
+// they referred to.
+type clearedLinks struct {
+	Table  string
+	Column string
+	Rows   []clearedRow
+}
+
+type clearedRow struct {
+	ID  uint
+	Ref uint
+}
+
+// checkDependents returns what deleting the row of table with the given id
+// would do to the rows that refer to it, or a DependentsError if a RESTRICT
+// foreign key would refuse the delete.
+func checkDependents(db *gorm.DB, table string, id uint) (*deleteEffects, error) {
+	effects := &deleteEffects{blocking: map[string]map[uint]bool{}, cascaded: map[string][]uint{}}
+	if err := effects.collect(db, table, []uint{id}); err != nil {
+		return nil, err
+	}
+	if len(effects.blocking) == 0 {
+		return effects, nil
+	}
+
+	dependentsErr := &DependentsError{Table: table, ID: id}
+	for child, set := range effects.blocking {
+		ids := make([]uint, 0, len(set))
+		for childID := range set {
+			ids = append(ids, childID)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		dependent := Dependent{Table: child, Count: len(ids), IDs: ids}
+		if len(ids) > maxDependentIDs {
+			dependent.IDs = ids[:maxDependentIDs]
+		}
+		dependentsErr.Dependents = append(dependentsErr.Dependents, dependent)
+	}
+	sort.Slice(dependentsErr.Dependents, func(i, j int) bool {
+		return dependentsErr.Dependents[i].Table < dependentsErr.Dependents[j].Table
+	})
+	return nil, dependentsErr
+}
+
+// collect adds the rows that refer to ids in table, following cascades to the
+// rows they would delete.
+func (e *deleteEffects) collect(db *gorm.DB, table string, ids []uint) error {
+	for _, ref := range References() {
+		if ref.Parent != table {
+			continue
+		}
+		var rows []clearedRow
+		if err := db.Table(ref.Table).Select("id, "+ref.Column+" AS ref").Where(ref.Column+" IN ?", ids).Order("id").Scan(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		childIDs := make([]uint, len(rows))
+		for i, row := range rows {
+			childIDs[i] = row.ID
+		}
+		switch ref.OnDelete {
+		case SetNull:
+			e.cleared = append(e.cleared, clearedLinks{Table: ref.Table, Column: ref.Column, Rows: rows})
+		case Cascade:
+			e.cascaded[ref.Table] = append(e.cascaded[ref.Table], childIDs...)
+			if err := e.collect(db, ref.Table, childIDs); err != nil {
+				return err
+			}
+		default:
+			if e.blocking[ref.Table] == nil {
+				e.blocking[ref.Table] = map[uint]bool{}
+			}
+			for _, childID := range childIDs {
+				e.blocking[ref.Table][childID] = true
+			}
+		}
+	}
+	return nil
+}
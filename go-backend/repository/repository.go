@@ -0,0 +1,179 @@
+// Package repository defines the persistence interfaces for each aggregate
+// and their GORM implementations. Handlers and services depend on the
+// interfaces, so tests can substitute fakes or an isolated SQLite database.
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"everflown-logistics/database"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned when the requested record does not exist.
+var ErrNotFound = errors.New("record not found")
+
+// ErrInvalidReference is returned when a write refers to a record that does
+// not exist, such as an order for a deleted customer.
+var ErrInvalidReference = errors.New("referenced record does not exist")
+
+// ErrConflict is returned when a write would duplicate a unique value held by
+// another record, such as restoring an archived order whose number has since
+// been reused.
+var ErrConflict = errors.New("record conflicts with an existing one")
+
+// CRUD is the set of operations shared by every aggregate keyed by a numeric ID.
+type CRUD[T any] interface {
+	List(ctx context.Context) ([]T, error)
+	Get(ctx context.Context, id uint) (*T, error)
+	Create(ctx context.Context, record *T) error
+	// Update applies the non-zero fields of changes and returns the stored result.
+	Update(ctx context.Context, id uint, changes *T) (*T, error)
+	Delete(ctx context.Context, id uint) error
+}
+
+type LeadRepository interface {
+	CRUD[models.Lead]
+}
+
+type CustomerRepository interface {
+	CRUD[models.Customer]
+}
+
+type CarrierRepository interface {
+	CRUD[models.Carrier]
+}
+
+type OrderRepository interface {
+	CRUD[models.Order]
+}
+
+type DispatchRepository interface {
+	CRUD[models.Dispatch]
+}
+
+type QuoteRepository interface {
+	CRUD[models.Quote]
+	// GetWithParties loads the quote with its lead and customer.
+	GetWithParties(ctx context.Context, id uint) (*models.Quote, error)
+}
+
+type InvoiceRepository interface {
+	CRUD[models.Invoice]
+	// GetWithParties loads the invoice with its order and customer.
+	GetWithParties(ctx context.Context, id uint) (*models.Invoice, error)
+}
+
+type FollowUpRepository interface {
+	CRUD[models.FollowUp]
+	// ListUrgent returns open high-priority follow-ups.
+	ListUrgent(ctx context.Context) ([]models.FollowUp, error)
+}
+
+// UserRepository manages users, which are keyed by string IDs.
+type UserRepository interface {
+	List(ctx context.Context) ([]models.User, error)
+	Get(ctx context.Context, id string) (*models.User, error)
+	FindByUsername(ctx context.Context, username string) (*models.User, error)
+	Create(ctx context.Context, user *models.User) error
+	Update(ctx context.Context, id string, changes *models.User) (*models.User, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// DashboardStats are the headline figures shown on the dashboard.
+type DashboardStats struct {
+	ActiveOrders  int64
+	InTransit     int64
+	PendingQuotes int64
+	// Revenue is paid customer invoices, totalled per currency.
+	Revenue map[string]money.Amount
+}
+
+type DashboardRepository interface {
+	Stats(ctx context.Context) (DashboardStats, error)
+}
+
+// Repositories bundles one repository per aggregate.
+type Repositories struct {
+	Users      UserRepository
+	Leads      LeadRepository
+	Customers  CustomerRepository
+	Carriers   CarrierRepository
+	Orders     OrderRepository
+	Dispatches DispatchRepository
+	Quotes     QuoteRepository
+	Invoices   InvoiceRepository
+	FollowUps  FollowUpRepository
+	Dashboard  DashboardRepository
+	Archive    ArchiveRepository
+	Audit      AuditRepository
+	Outbox     OutboxRepository
+	Webhooks   WebhookRepository
+
+	db *gorm.DB
+}
+
+// New returns GORM-backed repositories using db. Reads are retried on
+// transient errors according to database.DefaultReadRetry.
+func New(db *gorm.DB) *Repositories {
+	return newRepositories(conn{db: db, readRetry: database.DefaultReadRetry})
+}
+
+// WithReadRetry returns repositories on the same database whose reads are
+// retried according to policy instead.
+func (r *Repositories) WithReadRetry(policy database.RetryPolicy) *Repositories {
+	return newRepositories(conn{db: r.db, readRetry: policy})
+}
+
+func newRepositories(c conn) *Repositories {
+	return &Repositories{
+		Users:      userRepository{c},
+		Leads:      gormRepository[models.Lead]{c},
+		Customers:  gormRepository[models.Customer]{c},
+		Carriers:   gormRepository[models.Carrier]{c},
+		Orders:     gormRepository[models.Order]{c},
+		Dispatches: gormRepository[models.Dispatch]{c},
+		Quotes:     quoteRepository{gormRepository[models.Quote]{c}},
+		Invoices:   invoiceRepository{gormRepository[models.Invoice]{c}},
+		FollowUps:  followUpRepository{gormRepository[models.FollowUp]{c}},
+		Dashboard:  dashboardRepository{c},
+		Archive:    archiveRepository{c},
+		Audit:      auditRepository{c},
+		Outbox:     outboxRepository{c},
+		Webhooks:   webhookRepository{gormRepository[models.WebhookSubscription]{c}},
+		db:         c.db,
+	}
+}
+
+// translateError maps GORM errors onto the repository errors callers check for.
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// translateWriteError maps foreign key failures on create and update onto
+// ErrInvalidReference.
+func translateWriteError(err error) error {
+	if database.IsForeignKeyViolation(err) {
+		return fmt.Errorf("%w: %v", ErrInvalidReference, err)
+	}
+	return err
+}
+
+// Transaction runs fn with repositories bound to a single database
+// transaction, committing when fn returns nil and rolling back otherwise.
+// Repositories without a database, such as test fakes, run fn directly.
+func (r *Repositories) Transaction(ctx context.Context, fn func(tx *Repositories) error) error {
+	if r.db == nil {
+		return fn(r)
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(newRepositories(conn{db: tx}))
+	})
+}
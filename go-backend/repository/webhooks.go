@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"everflown-logistics/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookRepository manages webhook subscriptions and their delivery log.
+type WebhookRepository interface {
+	CRUD[models.WebhookSubscription]
+	// ForEvent returns the active subscriptions that receive events of
+	// eventType about a record of customerID: the customer's own and every
+	// API client's. A nil customerID matches API clients only.
+	ForEvent(ctx context.Context, eventType string, customerID *uint) ([]models.WebhookSubscription, error)
+	// SetActive enables or disables a subscription. Enabling it clears its
+	// failures; reason explains a disable.
+	SetActive(ctx context.Context, id uint, active bool, reason string) (*models.WebhookSubscription, error)
+
+	// Enqueue adds deliveries, skipping any already queued for the same
+	// subscription and event.
+	Enqueue(ctx context.Context, deliveries ...models.WebhookDelivery) error
+	// ClaimDue returns up to limit pending deliveries to active subscriptions
+	// that are due at now, postponing each to until so that no other sender
+	// picks it up meanwhile.
+	ClaimDue(ctx context.Context, now, until time.Time, limit int) ([]models.WebhookDelivery, error)
+	// RecordAttempt stores the outcome of an attempt at delivery and updates
+	// the subscription's consecutive failures, which it returns.
+	RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery, succeeded bool) (int, error)
+	// Deliveries returns a subscription's deliveries, newest first, with IDs
+	// below beforeID if it isn't zero.
+	Deliveries(ctx context.Context, subscriptionID, beforeID uint, limit int) ([]models.WebhookDelivery, error)
+	// Redeliver queues a delivery to be sent again at at, with a fresh count
+	// of attempts.
+	Redeliver(ctx context.Context, subscriptionID, id uint, at time.Time) (*models.WebhookDelivery, error)
+	// PruneDeliveries deletes finished deliveries created before cutoff.
+	PruneDeliveries(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type webhookRepository struct {
+	gormRepository[models.WebhookSubscription]
+}
+
+func (r webhookRepository) ForEvent(ctx context.Context, eventType string, customerID *uint) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := r.read(ctx, func(db *gorm.DB) error {
+		subscriptions = nil
+		query := db.Where("active = ?", true)
+		if customerID != nil {
+			query = query.Where("customer_id IS NULL OR customer_id = ?", *customerID)
+		} else {
+			query = query.Where("customer_id IS NULL")
+		}
+		return query.Order("id").Find(&subscriptions).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	wanted := subscriptions[:0]
+	for _, s := range subscriptions {
+		if s.Wants(eventType) {
+			wanted = append(wanted, s)
+		}
+	}
+	return wanted, nil
+}
+
+func (r webhookRepository) SetActive(ctx context.Context, id uint, active bool, reason string) (*models.WebhookSubscription, error) {
+	var after models.WebhookSubscription
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before models.WebhookSubscription
+		if err := tx.First(&before, id).Error; err != nil {
+			return translateError(err)
+		}
+		changes := map[string]interface{}{"active": active}
+		if active {
+			changes["consecutive_failures"] = 0
+			changes["disabled_at"] = nil
+			changes["disabled_reason"] = nil
+		} else if before.Active {
+			changes["disabled_at"] = time.Now().UTC()
+			changes["disabled_reason"] = reason
+		}
+		record := before
+		if err := tx.Model(&record).Updates(changes).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&after, id).Error; err != nil {
+			return err
+		}
+		return logChange(tx, ActionUpdate, &before, &after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &after, nil
+}
+
+func (r webhookRepository) Enqueue(ctx context.Context, deliveries ...models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error
+}
+
+func (r webhookRepository) ClaimDue(ctx context.Context, now, until time.Time, limit int) ([]models.WebhookDelivery, error) {
+	// Times are compared in UTC, since SQLite compares them as text.
+	now, until = now.UTC(), until.UTC()
+	db := r.db.WithContext(ctx)
+	var due []models.WebhookDelivery
+	err := db.Where("status = ? AND next_attempt_at <= ?", models.DeliveryPending, now).
+		Where("subscription_id IN (?)", db.Model(&models.WebhookSubscription{}).Select("id").Where("active = ?", true)).
+		Order("next_attempt_at, id").Limit(limit).Find(&due).Error
+	if err != nil {
+		return nil, err
+	}
+	claimed := due[:0]
+	for _, d := range due {
+		// Another sender may have claimed it since it was read.
+		result := db.Model(&models.WebhookDelivery{}).
+			Where("id = ? AND status = ? AND next_attempt_at <= ?", d.ID, models.DeliveryPending, now).
+			Update("next_attempt_at", until)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			d.NextAttemptAt = &until
+			claimed = append(claimed, d)
+		}
+	}
+	return claimed, nil
+}
+
+func (r webhookRepository) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery, succeeded bool) (int, error) {
+	var failures int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(delivery).Select("status", "attempts", "next_attempt_at", "last_attempt_at",
+			"response_status", "last_error", "delivered_at").Updates(delivery).Error
+		if err != nil {
+			return err
+		}
+		failed := gorm.Expr("consecutive_failures + 1")
+		if succeeded {
+			failed = gorm.Expr("0")
+		}
+		subscription := tx.Model(&models.WebhookSubscription{}).Where("id = ?", delivery.SubscriptionID)
+		if err := subscription.UpdateColumn("consecutive_failures", failed).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.WebhookSubscription{}).Where("id = ?", delivery.SubscriptionID).
+			Pluck("consecutive_failures", &failures).Error
+	})
+	return failures, err
+}
+
+func (r webhookRepository) Deliveries(ctx context.Context, subscriptionID, beforeID uint, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.read(ctx, func(db *gorm.DB) error {
+		deliveries = nil
+		query := db.Where("subscription_id = ?", subscriptionID)
+		if beforeID != 0 {
+			query = query.Where("id < ?", beforeID)
+		}
+		return query.Order("id DESC").Limit(limit).Find(&deliveries).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r webhookRepository) Redeliver(ctx context.Context, subscriptionID, id uint, at time.Time) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", subscriptionID).First(&delivery, id).Error; err != nil {
+			return translateError(err)
+		}
+		err := tx.Model(&delivery).Updates(map[string]interface{}{
+			"status":          models.DeliveryPending,
+			"attempts":        0,
+			"next_attempt_at": at.UTC(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.First(&delivery, id).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r webhookRepository) PruneDeliveries(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("status <> ? AND created_at < ?", models.DeliveryPending, cutoff.UTC()).
+		Delete(&models.WebhookDelivery{})
+	return result.RowsAffected, result.Error
+}
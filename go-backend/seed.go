@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"everflown-logistics/seed"
+	"gorm.io/gorm"
+)
+
+// runSeed executes the seed subcommand, writing results to out.
+func runSeed(ctx context.Context, db *gorm.DB, args []string, out io.Writer) error {
+	opts := seed.DefaultOptions()
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.IntVar(&opts.Orders, "orders", opts.Orders, "number of orders to generate; other records scale with it")
+	flags.Int64Var(&opts.Seed, "seed", opts.Seed, "random seed; the same seed, volume and start date reproduce the same data")
+	start := flags.String("start", opts.Start.Format("2006-01-02"), "date that order history and statuses are laid out around")
+	flags.BoolVar(&opts.Reset, "reset", false, "delete all business records (users are kept) and seed from scratch")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+	var err error
+	if opts.Start, err = time.Parse("2006-01-02", *start); err != nil {
+		return fmt.Errorf("invalid -start %q, want YYYY-MM-DD", *start)
+	}
+
+	counts, err := seed.Run(ctx, db, opts)
+	if errors.Is(err, seed.ErrAlreadySeeded) {
+		fmt.Fprintln(out, "Sample data is already present; nothing to do (use -reset to recreate it)")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Seeded %s\n", counts)
+	return nil
+}
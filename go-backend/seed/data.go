@@ -0,0 +1,157 @@
+package seed
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"everflown-logistics/money"
+)
+
+type city struct {
+	Name, State, Zip string
+	Lat, Lon         float64
+	Zone             string
+}
+
+var cities = []city{
+	{"Los Angeles", "CA", "90021", 34.05, -118.24, "America/Los_Angeles"},
+	{"Chicago", "IL", "60609", 41.88, -87.63, "America/Chicago"},
+	{"Dallas", "TX", "75207", 32.78, -96.80, "America/Chicago"},
+	{"Atlanta", "GA", "30318", 33.75, -84.39, "America/New_York"},
+	{"Houston", "TX", "77029", 29.76, -95.37, "America/Chicago"},
+	{"Phoenix", "AZ", "85043", 33.45, -112.07, "America/Phoenix"},
+	{"Denver", "CO", "80216", 39.74, -104.99, "America/Denver"},
+	{"Memphis", "TN", "38118", 35.15, -90.05, "America/Chicago"},
+	{"Columbus", "OH", "43228", 39.96, -83.00, "America/New_York"},
+	{"Indianapolis", "IN", "46241", 39.77, -86.16, "America/Indiana/Indianapolis"},
+	{"Kansas City", "MO", "64120", 39.10, -94.58, "America/Chicago"},
+	{"Nashville", "TN", "37210", 36.16, -86.78, "America/Chicago"},
+	{"Charlotte", "NC", "28208", 35.23, -80.84, "America/New_York"},
+	{"Jacksonville", "FL", "32254", 30.33, -81.66, "America/New_York"},
+	{"Seattle", "WA", "98108", 47.61, -122.33, "America/Los_Angeles"},
+	{"Salt Lake City", "UT", "84104", 40.76, -111.89, "America/Denver"},
+	{"Newark", "NJ", "07105", 40.74, -74.17, "America/New_York"},
+	{"Harrisburg", "PA", "17111", 40.27, -76.88, "America/New_York"},
+	{"Laredo", "TX", "78045", 27.53, -99.49, "America/Chicago"},
+	{"Fresno", "CA", "93725", 36.74, -119.79, "America/Los_Angeles"},
+}
+
+var (
+	companyPrefixes = []string{"Summit", "Pioneer", "Blue Ridge", "Great Lakes", "Lone Star", "Pacific", "Heartland", "Keystone", "Cascade", "Gulf Coast", "Prairie", "Ironwood", "Redline", "Silver State", "Evergreen", "Northstar"}
+	shipperSuffixes = []string{"Foods", "Manufacturing", "Building Supply", "Distribution", "Paper Co", "Beverages", "Plastics", "Steel", "Farms", "Home Goods"}
+	carrierSuffixes = []string{"Transport", "Freight Lines", "Trucking", "Logistics", "Carriers", "Express"}
+	firstNames      = []string{"Maria", "James", "Aisha", "Robert", "Linda", "Carlos", "Emily", "David", "Priya", "Michael", "Sarah", "Tyrone", "Jennifer", "Wei", "Kevin", "Angela"}
+	lastNames       = []string{"Garcia", "Smith", "Johnson", "Nguyen", "Brown", "Martinez", "Davis", "Patel", "Wilson", "Thompson", "Lee", "Clark", "Lopez", "Walker", "Hall", "Young"}
+	streets         = []string{"Industrial Pkwy", "Commerce Dr", "Distribution Way", "Freight Blvd", "Warehouse Rd", "Logistics Ln", "Terminal St", "Depot Ave"}
+	brokers         = []string{"Dana Reyes", "Sam Whitaker", "Jordan Blake", "Alex Morgan"}
+	paymentTerms    = []string{"Net 15", "Net 30", "Net 30", "Net 45"}
+
+	freight = []struct {
+		Commodity, Equipment string
+		MinWeight, MaxWeight int
+	}{
+		{"Frozen poultry", "Reefer", 30000, 42000},
+		{"Fresh produce", "Reefer", 28000, 40000},
+		{"Canned goods", "Dry Van", 35000, 44000},
+		{"Paper products", "Dry Van", 18000, 30000},
+		{"Household appliances", "Dry Van", 12000, 26000},
+		{"Beverages", "Dry Van", 38000, 44000},
+		{"Steel coils", "Flatbed", 40000, 46000},
+		{"Lumber", "Flatbed", 36000, 45000},
+		{"Construction equipment", "Step Deck", 25000, 45000},
+		{"Plastic resin", "Dry Van", 40000, 44000},
+	}
+
+	followUpTemplates = []struct {
+		Type, Title string
+	}{
+		{"call", "Check call with driver"},
+		{"email", "Send rate confirmation"},
+		{"call", "Follow up on quote"},
+		{"meeting", "Quarterly business review"},
+		{"email", "Request updated insurance certificate"},
+		{"call", "Collect past-due payment"},
+	}
+)
+
+// pick returns a random element of items.
+func pick[T any](rng *rand.Rand, items []T) T {
+	return items[rng.Intn(len(items))]
+}
+
+// between returns a random integer in [lo, hi].
+func between(rng *rand.Rand, lo, hi int) int {
+	return lo + rng.Intn(hi-lo+1)
+}
+
+func personName(rng *rand.Rand) string {
+	return pick(rng, firstNames) + " " + pick(rng, lastNames)
+}
+
+func phone(rng *rand.Rand) string {
+	return fmt.Sprintf("(555) %03d-%04d", between(rng, 200, 999), rng.Intn(10000))
+}
+
+func streetAddress(rng *rand.Rand) string {
+	return fmt.Sprintf("%d %s", between(rng, 100, 9999), pick(rng, streets))
+}
+
+// emailFor derives a mailbox from a company name, e.g. "dispatch@summittransport.com".
+func emailFor(mailbox, company string) string {
+	domain := make([]rune, 0, len(company))
+	for _, r := range company {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			domain = append(domain, r+'a'-'A')
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			domain = append(domain, r)
+		}
+	}
+	return mailbox + "@" + string(domain) + ".com"
+}
+
+// lane picks two different cities.
+func lane(rng *rand.Rand) (city, city) {
+	origin := pick(rng, cities)
+	destination := pick(rng, cities)
+	for destination.Name == origin.Name {
+		destination = pick(rng, cities)
+	}
+	return origin, destination
+}
+
+// miles approximates road miles between two cities from their great-circle distance.
+func miles(a, b city) float64 {
+	const earthRadiusMiles = 3959
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(b.Lat - a.Lat)
+	dLon := toRad(b.Lon - a.Lon)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(toRad(a.Lat))*math.Cos(toRad(b.Lat))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 1.2 * 2 * earthRadiusMiles * math.Asin(math.Sqrt(h))
+}
+
+// lineHaulRate prices a lane at a per-mile rate with a minimum charge,
+// rounded to the nearest $25.
+func lineHaulRate(rng *rand.Rand, distance float64, equipment string) money.Amount {
+	perMile := 2.10 + rng.Float64()*0.9
+	if equipment == "Reefer" || equipment == "Flatbed" || equipment == "Step Deck" {
+		perMile += 0.35
+	}
+	return money.New(int64(math.Round(math.Max(distance*perMile, 650)/25))*25, 0)
+}
+
+// mustLoadLocation loads one of the zones in cities, which are all valid.
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+// transitDays estimates days on the road at roughly 500 miles a day.
+func transitDays(distance float64) int {
+	return int(distance/500) + 1
+}
@@ -0,0 +1,643 @@
+// Package seed fills the database with realistic, linked sample data for
+// demos and load testing.
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"everflown-logistics/database"
+	"everflown-logistics/dates"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"gorm.io/gorm"
+)
+
+// orderPrefix numbers seeded orders (ORD-DEMO-000001, ...) and marks a
+// database as already seeded.
+const orderPrefix = "ORD-DEMO-"
+
+const batchSize = 500
+
+// ErrAlreadySeeded is returned when sample data exists and Reset is not set.
+var ErrAlreadySeeded = errors.New("sample data is already present")
+
+// Options controls what Run generates.
+type Options struct {
+	// Orders sets the volume. Leads, customers, carriers, quotes, invoices
+	// and follow-ups scale with it.
+	Orders int
+	// Seed drives the random generator: the same Seed, Orders and Start
+	// always produce the same data.
+	Seed int64
+	// Start anchors every date. Orders are spread over the months before it
+	// and the two weeks after, and their statuses follow from those dates.
+	Start time.Time
+	// Reset deletes all leads, customers, carriers, orders, dispatches,
+	// quotes, invoices and follow-ups before seeding. Users are kept.
+	Reset bool
+}
+
+// DefaultOptions returns a small demo dataset anchored to today.
+func DefaultOptions() Options {
+	return Options{Orders: 40, Seed: 1, Start: time.Now()}
+}
+
+// Counts reports how many records of each kind were created.
+type Counts struct {
+	Leads, Customers, Carriers, Orders, Dispatches, Quotes, Invoices, FollowUps int
+}
+
+func (c Counts) String() string {
+	return fmt.Sprintf("%d leads, %d customers, %d carriers, %d orders, %d dispatches, %d quotes, %d invoices, %d follow-ups",
+		c.Leads, c.Customers, c.Carriers, c.Orders, c.Dispatches, c.Quotes, c.Invoices, c.FollowUps)
+}
+
+// Run seeds db in a single transaction. Without Reset it is idempotent: a
+// database that already holds seeded orders is left alone and
+// ErrAlreadySeeded is returned.
+func Run(ctx context.Context, db *gorm.DB, opts Options) (Counts, error) {
+	if opts.Orders <= 0 {
+		return Counts{}, fmt.Errorf("order volume must be positive, got %d", opts.Orders)
+	}
+
+	var counts Counts
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if opts.Reset {
+			if err := reset(tx); err != nil {
+				return fmt.Errorf("reset: %w", err)
+			}
+		} else {
+			var seeded int64
+			if err := tx.Model(&models.Order{}).Where("order_number LIKE ?", orderPrefix+"%").Count(&seeded).Error; err != nil {
+				return err
+			}
+			if seeded > 0 {
+				return ErrAlreadySeeded
+			}
+		}
+
+		start := opts.Start.UTC()
+		g := &generator{
+			tx:    tx,
+			rng:   rand.New(rand.NewSource(opts.Seed)),
+			start: time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC),
+		}
+		var err error
+		counts, err = g.run(opts.Orders)
+		return err
+	})
+	return counts, err
+}
+
+// reset empties the business tables, archived orders included, and restarts
+// their ID sequences, so a reseed reproduces the same IDs.
+func reset(tx *gorm.DB) error {
+	var tables []string
+	for _, model := range append(models.All(), models.Archived()...) {
+		stmt := &gorm.Statement{DB: tx}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		if stmt.Schema.Table != "users" {
+			tables = append(tables, stmt.Schema.Table)
+		}
+	}
+
+	if tx.Dialector.Name() == database.Postgres {
+		return tx.Exec("TRUNCATE " + strings.Join(tables, ", ") + " RESTART IDENTITY CASCADE").Error
+	}
+	for i := len(tables) - 1; i >= 0; i-- {
+		if err := tx.Exec("DELETE FROM " + tables[i]).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Exec("DELETE FROM sqlite_sequence WHERE name IN ?", tables).Error
+}
+
+type generator struct {
+	tx    *gorm.DB
+	rng   *rand.Rand
+	start time.Time
+
+	customers  []models.Customer
+	carriers   []models.Carrier
+	leads      []models.Lead
+	orders     []models.Order
+	dispatches []models.Dispatch
+	quotes     []models.Quote
+	invoices   []models.Invoice
+	followUps  []models.FollowUp
+
+	invoiceNumber int
+}
+
+func (g *generator) run(orders int) (Counts, error) {
+	steps := []func() error{
+		func() error { return g.seedCustomers(max(3, orders/10)) },
+		func() error { return g.seedCarriers(max(3, orders/8)) },
+		func() error { return g.seedLeads(max(4, orders/5)) },
+		func() error { return g.seedOrders(orders) },
+		g.seedDispatches,
+		g.seedInvoices,
+		func() error { return g.seedQuotes(max(3, len(g.leads)*3/4)) },
+		func() error { return g.seedFollowUps(max(4, orders/2)) },
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return Counts{}, err
+		}
+	}
+	return Counts{
+		Leads:      len(g.leads),
+		Customers:  len(g.customers),
+		Carriers:   len(g.carriers),
+		Orders:     len(g.orders),
+		Dispatches: len(g.dispatches),
+		Quotes:     len(g.quotes),
+		Invoices:   len(g.invoices),
+		FollowUps:  len(g.followUps),
+	}, nil
+}
+
+// day returns the start date shifted by offset days.
+func (g *generator) day(offset int) time.Time {
+	return g.start.AddDate(0, 0, offset)
+}
+
+// chance reports true with probability p.
+func (g *generator) chance(p float64) bool {
+	return g.rng.Float64() < p
+}
+
+// deactivate clears is_active on the given rows. GORM skips false when
+// inserting into a column that defaults to true, so it is set afterwards.
+func (g *generator) deactivate(model interface{}, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return g.tx.Model(model).Where("id IN ?", ids).Update("is_active", false).Error
+}
+
+func (g *generator) seedCustomers(n int) error {
+	var inactive []int
+	for i := 0; i < n; i++ {
+		name := pick(g.rng, companyPrefixes) + " " + pick(g.rng, shipperSuffixes)
+		c := pick(g.rng, cities)
+		address := streetAddress(g.rng)
+		creditLimit := money.New(int64(between(g.rng, 1, 20)*5000), 0)
+		created := g.day(-between(g.rng, 120, 720))
+		g.customers = append(g.customers, models.Customer{
+			CompanyName:    name,
+			ContactPerson:  personName(g.rng),
+			Email:          emailFor("shipping", name),
+			Phone:          phone(g.rng),
+			Address:        &address,
+			City:           &c.Name,
+			State:          &c.State,
+			ZipCode:        &c.Zip,
+			BillingAddress: &address,
+			BillingCity:    &c.Name,
+			BillingState:   &c.State,
+			BillingZipCode: &c.Zip,
+			CreditLimit:    &creditLimit,
+			PaymentTerms:   pick(g.rng, paymentTerms),
+			IsActive:       true,
+			CreatedAt:      created,
+			UpdatedAt:      created,
+		})
+		if g.chance(0.08) {
+			inactive = append(inactive, i)
+		}
+	}
+	if err := g.tx.CreateInBatches(&g.customers, batchSize).Error; err != nil {
+		return err
+	}
+	for _, i := range inactive {
+		g.customers[i].IsActive = false
+	}
+	return g.deactivate(&models.Customer{}, idsAt(g.customers, inactive, func(c models.Customer) uint { return c.ID }))
+}
+
+func (g *generator) seedCarriers(n int) error {
+	equipment := []string{"Dry Van", "Reefer", "Flatbed", "Step Deck"}
+	var inactive []int
+	for i := 0; i < n; i++ {
+		name := pick(g.rng, companyPrefixes) + " " + pick(g.rng, carrierSuffixes)
+		c := pick(g.rng, cities)
+		address := streetAddress(g.rng)
+		mc := fmt.Sprintf("MC-%06d", between(g.rng, 100000, 999999))
+		dot := strconv.Itoa(between(g.rng, 1000000, 3999999))
+		insurance := dates.DateOf(g.day(between(g.rng, -20, 365)))
+		origin, destination := lane(g.rng)
+		lanes := fmt.Sprintf("%s, %s - %s, %s", origin.Name, origin.State, destination.Name, destination.State)
+		types := pick(g.rng, equipment)
+		if g.chance(0.4) {
+			types += ", " + pick(g.rng, equipment)
+		}
+		created := g.day(-between(g.rng, 60, 720))
+		g.carriers = append(g.carriers, models.Carrier{
+			CompanyName:       name,
+			ContactPerson:     personName(g.rng),
+			Email:             emailFor("dispatch", name),
+			Phone:             phone(g.rng),
+			Address:           &address,
+			City:              &c.Name,
+			State:             &c.State,
+			ZipCode:           &c.Zip,
+			MCNumber:          &mc,
+			DOTNumber:         &dot,
+			InsuranceExpiry:   &insurance,
+			W9OnFile:          g.chance(0.9),
+			PerformanceRating: math.Round((3.2+g.rng.Float64()*1.8)*10) / 10,
+			PreferredLanes:    &lanes,
+			EquipmentTypes:    &types,
+			IsActive:          true,
+			CreatedAt:         created,
+			UpdatedAt:         created,
+		})
+		if i > 0 && g.chance(0.05) {
+			inactive = append(inactive, i)
+		}
+	}
+	if err := g.tx.CreateInBatches(&g.carriers, batchSize).Error; err != nil {
+		return err
+	}
+	for _, i := range inactive {
+		g.carriers[i].IsActive = false
+	}
+	return g.deactivate(&models.Carrier{}, idsAt(g.carriers, inactive, func(c models.Carrier) uint { return c.ID }))
+}
+
+func (g *generator) seedLeads(n int) error {
+	statuses := []string{"new", "new", "contacted", "contacted", "quoted", "converted", "won", "lost"}
+	for i := 0; i < n; i++ {
+		name := pick(g.rng, companyPrefixes) + " " + pick(g.rng, shipperSuffixes)
+		origin, destination := lane(g.rng)
+		f := pick(g.rng, freight)
+		pickup := dates.DateOf(g.day(between(g.rng, 3, 30)))
+		weight := between(g.rng, f.MinWeight, f.MaxWeight)
+		created := g.day(-between(g.rng, 1, 60))
+		g.leads = append(g.leads, models.Lead{
+			CompanyName:      name,
+			ContactPerson:    personName(g.rng),
+			Email:            emailFor("logistics", name),
+			Phone:            phone(g.rng),
+			OriginCity:       &origin.Name,
+			OriginState:      &origin.State,
+			DestinationCity:  &destination.Name,
+			DestinationState: &destination.State,
+			PickupDate:       &pickup,
+			EquipmentType:    &f.Equipment,
+			Commodity:        &f.Commodity,
+			Weight:           &weight,
+			Status:           pick(g.rng, statuses),
+			CreatedAt:        created,
+			UpdatedAt:        created,
+		})
+	}
+	return g.tx.CreateInBatches(&g.leads, batchSize).Error
+}
+
+func (g *generator) seedOrders(n int) error {
+	// Spread pickups over the past months, about four orders a day at
+	// volume, plus two weeks of upcoming loads.
+	history := min(365, max(30, n/4))
+	for i := 0; i < n; i++ {
+		customer := g.customers[g.rng.Intn(len(g.customers))]
+		origin, destination := lane(g.rng)
+		f := pick(g.rng, freight)
+		distance := miles(origin, destination)
+		pickupOffset := between(g.rng, -history, 14)
+		pickup := g.day(pickupOffset)
+		delivery := pickup.AddDate(0, 0, transitDays(distance))
+
+		var status string
+		switch {
+		case g.chance(0.04):
+			status = "cancelled"
+		case !delivery.After(g.start):
+			status = "delivered"
+		case pickup.Equal(g.start):
+			status = "picked_up"
+		case pickup.Before(g.start):
+			status = "in_transit"
+		case pickupOffset <= 3 && g.chance(0.7):
+			status = "dispatched"
+		default:
+			status = "needs_truck"
+		}
+
+		receiver := pick(g.rng, companyPrefixes) + " Distribution Center"
+		weight := float64(between(g.rng, f.MinWeight, f.MaxWeight))
+		deliveryDate := dates.DateOf(delivery)
+		created := pickup.AddDate(0, 0, -between(g.rng, 2, 10))
+		g.orders = append(g.orders, models.Order{
+			OrderNumber:        fmt.Sprintf("%s%06d", orderPrefix, i+1),
+			CustomerID:         &customer.ID,
+			CustomerName:       &customer.CompanyName,
+			OriginCompany:      &customer.CompanyName,
+			OriginAddress:      streetAddress(g.rng),
+			OriginCity:         origin.Name,
+			OriginState:        origin.State,
+			OriginZipCode:      origin.Zip,
+			DestinationCompany: &receiver,
+			DestinationAddress: streetAddress(g.rng),
+			DestinationCity:    destination.Name,
+			DestinationState:   destination.State,
+			DestinationZipCode: destination.Zip,
+			PickupDate:         dates.DateOf(pickup),
+			DeliveryDate:       &deliveryDate,
+			EquipmentType:      f.Equipment,
+			Weight:             &weight,
+			Commodity:          &f.Commodity,
+			CustomerRate:       lineHaulRate(g.rng, distance, f.Equipment),
+			Status:             status,
+			CreatedAt:          created,
+			UpdatedAt:          created,
+		})
+	}
+	return g.tx.CreateInBatches(&g.orders, batchSize).Error
+}
+
+func (g *generator) seedDispatches() error {
+	var active []models.Carrier
+	for _, c := range g.carriers {
+		if c.IsActive {
+			active = append(active, c)
+		}
+	}
+
+	for _, order := range g.orders {
+		status := order.Status
+		switch status {
+		case "dispatched":
+			status = "assigned"
+		case "picked_up", "in_transit", "delivered":
+		default:
+			continue
+		}
+
+		// Appointments are at local times in the origin and destination zones.
+		origin, destination := cityNamed(order.OriginCity), cityNamed(order.DestinationCity)
+		pickup := order.PickupDate.In(mustLoadLocation(origin.Zone))
+		delivery := order.DeliveryDate.In(mustLoadLocation(destination.Zone))
+		estimatedPickup := dates.At(pickup.Add(8 * time.Hour))
+		estimatedDelivery := dates.At(delivery.Add(14 * time.Hour))
+		driver := personName(g.rng)
+		driverPhone := phone(g.rng)
+		truck := strconv.Itoa(between(g.rng, 100, 999))
+		trailer := fmt.Sprintf("TR-%04d", between(g.rng, 1, 9999))
+		created := order.CreatedAt.AddDate(0, 0, 1)
+		dispatch := models.Dispatch{
+			OrderID:                order.ID,
+			CarrierID:              pick(g.rng, active).ID,
+			CarrierRate:            money.New(int64(math.Round(order.CustomerRate.Float64()*(0.78+g.rng.Float64()*0.1)/5))*5, 0),
+			DriverName:             &driver,
+			DriverPhone:            &driverPhone,
+			TruckNumber:            &truck,
+			TrailerNumber:          &trailer,
+			Status:                 status,
+			RateConfirmationSent:   true,
+			RateConfirmationSigned: status != "assigned" || g.chance(0.5),
+			EstimatedPickupTime:    &estimatedPickup,
+			EstimatedDeliveryTime:  &estimatedDelivery,
+			PickupTimeZone:         &origin.Zone,
+			DeliveryTimeZone:       &destination.Zone,
+			CreatedAt:              created,
+			UpdatedAt:              created,
+		}
+		if status != "assigned" {
+			actual := dates.At(pickup.Add(8*time.Hour + time.Duration(between(g.rng, -30, 150))*time.Minute))
+			dispatch.ActualPickupTime = &actual
+		}
+		if status == "delivered" {
+			actual := dates.At(delivery.Add(14*time.Hour + time.Duration(between(g.rng, -120, 240))*time.Minute))
+			dispatch.ActualDeliveryTime = &actual
+		}
+		g.dispatches = append(g.dispatches, dispatch)
+	}
+	if len(g.dispatches) == 0 {
+		return nil
+	}
+	return g.tx.CreateInBatches(&g.dispatches, batchSize).Error
+}
+
+// seedInvoices bills the customer and pays the carrier for every load that
+// is on the road or delivered.
+func (g *generator) seedInvoices() error {
+	orders := make(map[uint]models.Order, len(g.orders))
+	for _, o := range g.orders {
+		orders[o.ID] = o
+	}
+	terms := make(map[uint]int, len(g.customers))
+	for _, c := range g.customers {
+		days, err := strconv.Atoi(strings.TrimPrefix(c.PaymentTerms, "Net "))
+		if err != nil {
+			days = 30
+		}
+		terms[c.ID] = days
+	}
+
+	for _, dispatch := range g.dispatches {
+		if dispatch.Status != "in_transit" && dispatch.Status != "delivered" {
+			continue
+		}
+		order := orders[dispatch.OrderID]
+		delivery := order.DeliveryDate.In(time.UTC)
+		customerTerms := terms[*order.CustomerID]
+		g.invoices = append(g.invoices,
+			g.invoice("customer", order, dispatch, order.CustomerRate, delivery, customerTerms),
+			g.invoice("carrier", order, dispatch, dispatch.CarrierRate, delivery, 15),
+		)
+	}
+	if len(g.invoices) == 0 {
+		return nil
+	}
+	return g.tx.CreateInBatches(&g.invoices, batchSize).Error
+}
+
+func (g *generator) invoice(kind string, order models.Order, dispatch models.Dispatch, amount money.Amount, delivery time.Time, termDays int) models.Invoice {
+	orderID, dispatchID := order.ID, dispatch.ID
+	g.invoiceNumber++
+	due := delivery.AddDate(0, 0, termDays)
+	invoice := models.Invoice{
+		InvoiceNumber: fmt.Sprintf("INV-DEMO-%06d", g.invoiceNumber),
+		Type:          kind,
+		OrderID:       &orderID,
+		DispatchID:    &dispatchID,
+		Amount:        amount,
+		DueDate:       dates.DateOf(due),
+		CreatedAt:     delivery,
+		UpdatedAt:     delivery,
+	}
+	if kind == "customer" {
+		invoice.CustomerID = order.CustomerID
+	} else {
+		carrierID := dispatch.CarrierID
+		invoice.CarrierID = &carrierID
+	}
+
+	switch {
+	case dispatch.Status != "delivered":
+		invoice.Status = "draft"
+		invoice.CreatedAt = dispatch.CreatedAt
+		invoice.UpdatedAt = dispatch.CreatedAt
+	case due.Before(g.start) && g.chance(0.85), !due.Before(g.start) && g.chance(0.3):
+		paid := delivery.AddDate(0, 0, between(g.rng, 3, termDays))
+		if paid.After(g.start) {
+			paid = g.start
+		}
+		paidDate := dates.DateOf(paid)
+		invoice.Status = "paid"
+		invoice.PaidDate = &paidDate
+		invoice.UpdatedAt = paid
+	case due.Before(g.start):
+		invoice.Status = "overdue"
+	default:
+		invoice.Status = "sent"
+	}
+	return invoice
+}
+
+func (g *generator) seedQuotes(n int) error {
+	leadQuoteStatus := map[string]string{"converted": "accepted", "won": "accepted", "lost": "rejected", "quoted": "sent"}
+	customerQuoteStatuses := []string{"draft", "pending", "sent", "accepted", "accepted", "expired"}
+	for i := 0; i < n; i++ {
+		f := pick(g.rng, freight)
+		origin, destination := lane(g.rng)
+		created := g.day(-between(g.rng, 0, 45))
+		quote := models.Quote{
+			QuoteNumber:      fmt.Sprintf("QTE-DEMO-%06d", i+1),
+			OriginCity:       origin.Name,
+			OriginState:      origin.State,
+			DestinationCity:  destination.Name,
+			DestinationState: destination.State,
+			EquipmentType:    f.Equipment,
+			Commodity:        &f.Commodity,
+			CreatedAt:        created,
+			UpdatedAt:        created,
+		}
+
+		if i%2 == 0 {
+			lead := g.lead(i / 2)
+			quote.LeadID = &lead.ID
+			quote.OriginCity, quote.OriginState = *lead.OriginCity, *lead.OriginState
+			quote.DestinationCity, quote.DestinationState = *lead.DestinationCity, *lead.DestinationState
+			quote.EquipmentType, quote.Commodity = *lead.EquipmentType, lead.Commodity
+			quote.PickupDate = lead.PickupDate
+			weight := float64(*lead.Weight)
+			quote.Weight = &weight
+			quote.Status = "pending"
+			if status, ok := leadQuoteStatus[lead.Status]; ok {
+				quote.Status = status
+			}
+		} else {
+			customer := g.customers[g.rng.Intn(len(g.customers))]
+			quote.CustomerID = &customer.ID
+			weight := float64(between(g.rng, f.MinWeight, f.MaxWeight))
+			quote.Weight = &weight
+			quote.Status = pick(g.rng, customerQuoteStatuses)
+		}
+
+		quote.QuotedRate = lineHaulRate(g.rng, miles(cityNamed(quote.OriginCity), cityNamed(quote.DestinationCity)), quote.EquipmentType)
+		quote.ValidUntil = dates.DateOf(created.AddDate(0, 0, 14))
+		g.quotes = append(g.quotes, quote)
+	}
+	return g.tx.CreateInBatches(&g.quotes, batchSize).Error
+}
+
+// lead returns the i-th lead, wrapping around when there are fewer leads.
+func (g *generator) lead(i int) models.Lead {
+	return g.leads[i%len(g.leads)]
+}
+
+func (g *generator) seedFollowUps(n int) error {
+	priorities := []string{"low", "medium", "medium", "high", "urgent"}
+	descriptions := map[string]string{
+		"Check call with driver":                "Confirm location and ETA with the driver",
+		"Send rate confirmation":                "Email the signed rate confirmation to the carrier",
+		"Follow up on quote":                    "Ask whether the quote works for their upcoming loads",
+		"Quarterly business review":             "Review volume, on-time performance and upcoming lanes",
+		"Request updated insurance certificate": "Certificate of insurance on file expires soon",
+		"Collect past-due payment":              "Invoice is past due; confirm payment date with accounts payable",
+	}
+	// Check calls and rate confirmations concern loads that are still moving.
+	open := g.orders[:0:0]
+	for _, o := range g.orders {
+		if o.Status != "delivered" && o.Status != "cancelled" {
+			open = append(open, o)
+		}
+	}
+	if len(open) == 0 {
+		open = g.orders
+	}
+
+	for i := 0; i < n; i++ {
+		template := pick(g.rng, followUpTemplates)
+		description := descriptions[template.Title]
+		assignee := pick(g.rng, brokers)
+		due := g.day(between(g.rng, -10, 10)).Add(time.Duration(between(g.rng, 9, 16)) * time.Hour)
+		created := due.AddDate(0, 0, -between(g.rng, 1, 7))
+		followUp := models.FollowUp{
+			Title:       template.Title,
+			Description: &description,
+			Type:        template.Type,
+			DueDate:     due,
+			Priority:    pick(g.rng, priorities),
+			AssignedTo:  &assignee,
+			CreatedAt:   created,
+			UpdatedAt:   created,
+		}
+
+		switch template.Title {
+		case "Check call with driver", "Send rate confirmation":
+			order := pick(g.rng, open)
+			followUp.OrderID = &order.ID
+			followUp.CustomerID = order.CustomerID
+		case "Follow up on quote":
+			lead := g.lead(g.rng.Intn(len(g.leads)))
+			followUp.LeadID = &lead.ID
+		case "Request updated insurance certificate":
+			carrier := g.carriers[g.rng.Intn(len(g.carriers))]
+			followUp.CarrierID = &carrier.ID
+		default:
+			customer := g.customers[g.rng.Intn(len(g.customers))]
+			followUp.CustomerID = &customer.ID
+		}
+
+		if due.Before(g.start) && g.chance(0.75) {
+			completed := dates.At(due.Add(time.Duration(between(g.rng, 0, 48)) * time.Hour))
+			followUp.Completed = true
+			followUp.CompletedAt = &completed
+			followUp.UpdatedAt = due
+		}
+		g.followUps = append(g.followUps, followUp)
+	}
+	return g.tx.CreateInBatches(&g.followUps, batchSize).Error
+}
+
+// cityNamed looks up a city from the seed list.
+func cityNamed(name string) city {
+	for _, c := range cities {
+		if c.Name == name {
+			return c
+		}
+	}
+	return cities[0]
+}
+
+// idsAt returns the IDs of records at the given indexes.
+func idsAt[T any](records []T, indexes []int, id func(T) uint) []uint {
+	ids := make([]uint, 0, len(indexes))
+	for _, i := range indexes {
+		ids = append(ids, id(records[i]))
+	}
+	return ids
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"everflown-logistics/dates"
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+)
+
+// archiveBatchSize is how many orders are moved per transaction.
+const archiveBatchSize = 500
+
+// ArchiveService moves closed orders out of the live tables and back.
+type ArchiveService struct {
+	repos *repository.Repositories
+}
+
+func NewArchiveService(repos *repository.Repositories) *ArchiveService {
+	return &ArchiveService{repos: repos}
+}
+
+// ArchiveClosedOrders archives every order that was delivered or cancelled
+// more than afterDays days ago and is fully settled, together with its
+// dispatches, invoices and follow-ups. It returns how many orders were moved.
+func (s *ArchiveService) ArchiveClosedOrders(ctx context.Context, afterDays int) (int, error) {
+	cutoff := dates.Today(time.UTC).AddDays(-afterDays)
+	archived := 0
+	for {
+		ids, err := s.repos.Archive.Eligible(ctx, cutoff, archiveBatchSize)
+		if err != nil {
+			return archived, err
+		}
+		if len(ids) == 0 {
+			return archived, nil
+		}
+		if err := s.repos.Archive.Archive(ctx, ids, time.Now().UTC()); err != nil {
+			return archived, err
+		}
+		archived += len(ids)
+		if len(ids) < archiveBatchSize {
+			return archived, nil
+		}
+	}
+}
+
+func (s *ArchiveService) List(ctx context.Context) ([]models.ArchivedOrder, error) {
+	return s.repos.Archive.List(ctx)
+}
+
+// Get returns an archived order with its dispatches, invoices and follow-ups.
+func (s *ArchiveService) Get(ctx context.Context, id uint) (*models.ArchivedOrder, error) {
+	return s.repos.Archive.Get(ctx, id)
+}
+
+// Restore moves an archived order and its records back to the live tables.
+// It returns ErrConflict if a live order or invoice has since taken one of
+// their numbers.
+func (s *ArchiveService) Restore(ctx context.Context, id uint) error {
+	return s.repos.Archive.Restore(ctx, id)
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"everflown-logistics/audit"
+	"everflown-logistics/dates"
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 500
+)
+
+// AuditFilter narrows an audit log query. From and To are inclusive dates in
+// UTC.
+type AuditFilter struct {
+	Entity   string
+	EntityID string
+	UserID   string
+	From     dates.Date
+	To       dates.Date
+	BeforeID uint
+	Limit    int
+}
+
+// AuditService reads the audit log and the change history built from it.
+type AuditService struct {
+	repos *repository.Repositories
+}
+
+func NewAuditService(repos *repository.Repositories) *AuditService {
+	return &AuditService{repos: repos}
+}
+
+// List returns the audit entries matching filter, newest first. Pass the ID
+// of the last entry as BeforeID to fetch the next page.
+func (s *AuditService) List(ctx context.Context, filter AuditFilter) ([]models.AuditEntry, error) {
+	v := &validator{}
+	v.check(filter.Limit >= 0 && filter.Limit <= maxAuditLimit, "limit", "must be between 1 and 500")
+	v.check(filter.From.IsZero() || filter.To.IsZero() || !filter.To.Before(filter.From), "to", "must not be before from")
+	if v.err != nil {
+		return nil, v.err
+	}
+	query := repository.AuditFilter{
+		Entity:   filter.Entity,
+		EntityID: filter.EntityID,
+		UserID:   filter.UserID,
+		BeforeID: filter.BeforeID,
+		Limit:    filter.Limit,
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultAuditLimit
+	}
+	if !filter.From.IsZero() {
+		query.From = filter.From.In(time.UTC)
+	}
+	if !filter.To.IsZero() {
+		query.To = filter.To.AddDays(1).In(time.UTC)
+	}
+	entries, err := s.repos.Audit.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// HistoryEntry is one change in a record's history.
+type HistoryEntry struct {
+	ID         uint      `json:"id"`
+	OccurredAt time.Time `json:"occurredAt"`
+	UserID     string    `json:"userId"`
+	// UserName is the user's full name, or their username without one.
+	UserName string         `json:"userName"`
+	Action   string         `json:"action"`
+	Changes  []FieldHistory `json:"changes"`
+}
+
+// FieldHistory is one field's change, labeled for display.
+type FieldHistory struct {
+	Field string          `json:"field"`
+	Label string          `json:"label"`
+	From  json.RawMessage `json:"from,omitempty"`
+	To    json.RawMessage `json:"to,omitempty"`
+}
+
+// historyRecords lists the records with a history endpoint, by table: the
+// model the labels come from and how to check that a record exists.
+var historyRecords = map[string]struct {
+	model  interface{}
+	exists func(ctx context.Context, repos *repository.Repositories, id uint) error
+}{
+	"orders": {&models.Order{}, func(ctx context.Context, repos *repository.Repositories, id uint) error {
+		_, err := repos.Orders.Get(ctx, id)
+		return err
+	}},
+	"dispatches": {&models.Dispatch{}, func(ctx context.Context, repos *repository.Repositories, id uint) error {
+		_, err := repos.Dispatches.Get(ctx, id)
+		return err
+	}},
+	"invoices": {&models.Invoice{}, func(ctx context.Context, repos *repository.Repositories, id uint) error {
+		_, err := repos.Invoices.Get(ctx, id)
+		return err
+	}},
+	"quotes": {&models.Quote{}, func(ctx context.Context, repos *repository.Repositories, id uint) error {
+		_, err := repos.Quotes.Get(ctx, id)
+		return err
+	}},
+}
+
+// History returns the changes made to a record of table, which is orders,
+// dispatches, invoices or quotes, oldest first. A deleted record keeps its
+// history; a record with no history that doesn't exist is ErrNotFound.
+func (s *AuditService) History(ctx context.Context, table string, id uint) ([]HistoryEntry, error) {
+	record, ok := historyRecords[table]
+	if !ok {
+		return nil, fmt.Errorf("services: no history for %s", table)
+	}
+	entries, err := s.repos.Audit.ForRecord(ctx, table, strconv.FormatUint(uint64(id), 10))
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		if err := record.exists(ctx, s.repos, id); err != nil {
+			return nil, err
+		}
+	}
+
+	names, err := s.userNames(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+	fields := models.FieldLabels(record.model)
+	history := make([]HistoryEntry, 0, len(entries))
+	for _, e := range entries {
+		entry := HistoryEntry{
+			ID:         e.ID,
+			OccurredAt: e.OccurredAt,
+			UserID:     e.UserID,
+			UserName:   names[e.UserID],
+			Action:     e.Action,
+			Changes:    []FieldHistory{},
+		}
+		// Changes follow the model's field order. Fields the model no longer
+		// has come last, labeled with their names.
+		seen := 0
+		for _, f := range fields {
+			if change, ok := e.Changes[f.Name]; ok {
+				entry.Changes = append(entry.Changes, FieldHistory{Field: f.Name, Label: f.Label, From: change.From, To: change.To})
+				seen++
+			}
+		}
+		if seen < len(e.Changes) {
+			known := make(map[string]bool, len(fields))
+			for _, f := range fields {
+				known[f.Name] = true
+			}
+			var removed []string
+			for name := range e.Changes {
+				if !known[name] {
+					removed = append(removed, name)
+				}
+			}
+			sort.Strings(removed)
+			for _, name := range removed {
+				change := e.Changes[name]
+				entry.Changes = append(entry.Changes, FieldHistory{Field: name, Label: name, From: change.From, To: change.To})
+			}
+		}
+		history = append(history, entry)
+	}
+	return history, nil
+}
+
+// userNames maps the users behind entries to their display names. Users who
+// have since been deleted are shown by ID.
+func (s *AuditService) userNames(ctx context.Context, entries []models.AuditEntry) (map[string]string, error) {
+	names := map[string]string{audit.System: "System"}
+	admin := DefaultAdmin()
+	for _, e := range entries {
+		if _, ok := names[e.UserID]; ok {
+			continue
+		}
+		user := &admin
+		if e.UserID != admin.ID {
+			var err error
+			user, err = s.repos.Users.Get(ctx, e.UserID)
+			if errors.Is(err, ErrNotFound) {
+				names[e.UserID] = e.UserID
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		names[e.UserID] = displayName(user)
+	}
+	return names, nil
+}
+
+func displayName(user *models.User) string {
+	var parts []string
+	for _, p := range []*string{user.FirstName, user.LastName} {
+		if p != nil && strings.TrimSpace(*p) != "" {
+			parts = append(parts, strings.TrimSpace(*p))
+		}
+	}
+	if len(parts) == 0 {
+		return user.Username
+	}
+	return strings.Join(parts, " ")
+}
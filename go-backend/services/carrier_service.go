@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+)
+
+type CarrierService struct {
+	repos *repository.Repositories
+}
+
+func NewCarrierService(repos *repository.Repositories) *CarrierService {
+	return &CarrierService{repos: repos}
+}
+
+func (s *CarrierService) List(ctx context.Context) ([]models.Carrier, error) {
+	return s.repos.Carriers.List(ctx)
+}
+
+func (s *CarrierService) Get(ctx context.Context, id uint) (*models.Carrier, error) {
+	return s.repos.Carriers.Get(ctx, id)
+}
+
+func (s *CarrierService) Create(ctx context.Context, carrier *models.Carrier) error {
+	v := validator{}
+	v.required("companyName", carrier.CompanyName)
+	v.required("email", carrier.Email)
+	validateCarrier(&v, carrier)
+	if v.err != nil {
+		return v.err
+	}
+	return s.repos.Carriers.Create(ctx, carrier)
+}
+
+// Update applies the non-zero fields of changes to the carrier and returns the stored result.
+func (s *CarrierService) Update(ctx context.Context, id uint, changes *models.Carrier) (*models.Carrier, error) {
+	v := validator{}
+	validateCarrier(&v, changes)
+	if v.err != nil {
+		return nil, v.err
+	}
+	return s.repos.Carriers.Update(ctx, id, changes)
+}
+
+func (s *CarrierService) Delete(ctx context.Context, id uint) error {
+	return s.repos.Carriers.Delete(ctx, id)
+}
+
+func validateCarrier(v *validator, carrier *models.Carrier) {
+	v.email("email", carrier.Email)
+	v.check(carrier.PerformanceRating >= 0 && carrier.PerformanceRating <= 5, "performanceRating", "must be between 0 and 5")
+}
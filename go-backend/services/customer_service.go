@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+)
+
+type CustomerService struct {
+	repos *repository.Repositories
+}
+
+func NewCustomerService(repos *repository.Repositories) *CustomerService {
+	return &CustomerService{repos: repos}
+}
+
+func (s *CustomerService) List(ctx context.Context) ([]models.Customer, error) {
+	return s.repos.Customers.List(ctx)
+}
+
+func (s *CustomerService) Get(ctx context.Context, id uint) (*models.Customer, error) {
+	return s.repos.Customers.Get(ctx, id)
+}
+
+func (s *CustomerService) Create(ctx context.Context, customer *models.Customer) error {
+	v := validator{}
+	v.required("companyName", customer.CompanyName)
+	v.required("email", customer.Email)
+	validateCustomer(&v, customer)
+	if v.err != nil {
+		return v.err
+	}
+	return s.repos.Customers.Create(ctx, customer)
+}
+
+// Update applies the non-zero fields of changes to the customer and returns the stored result.
+func (s *CustomerService) Update(ctx context.Context, id uint, changes *models.Customer) (*models.Customer, error) {
+	v := validator{}
+	validateCustomer(&v, changes)
+	if v.err != nil {
+		return nil, v.err
+	}
+	return s.repos.Customers.Update(ctx, id, changes)
+}
+
+func (s *CustomerService) Delete(ctx context.Context, id uint) error {
+	return s.repos.Customers.Delete(ctx, id)
+}
+
+func validateCustomer(v *validator, customer *models.Customer) {
+	v.email("email", customer.Email)
+	if customer.CreditLimit != nil {
+		v.nonNegativeAmount("creditLimit", *customer.CreditLimit)
+	}
+	v.currency("currency", customer.Currency)
+}
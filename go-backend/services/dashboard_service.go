@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+
+	"everflown-logistics/money"
+	"everflown-logistics/repository"
+)
+
+// DashboardStats are the headline figures shown on the dashboard.
+type DashboardStats struct {
+	ActiveOrders  int64 `json:"activeOrders"`
+	InTransit     int64 `json:"inTransit"`
+	PendingQuotes int64 `json:"pendingQuotes"`
+	// TotalRevenue is paid customer invoices in the default currency;
+	// RevenueByCurrency breaks revenue down across every currency invoiced.
+	TotalRevenue      money.Amount            `json:"totalRevenue"`
+	RevenueByCurrency map[string]money.Amount `json:"revenueByCurrency"`
+	AvgDeliveryTime   float64                 `json:"avgDeliveryTime"`
+}
+
+type DashboardService struct {
+	repos *repository.Repositories
+}
+
+func NewDashboardService(repos *repository.Repositories) *DashboardService {
+	return &DashboardService{repos: repos}
+}
+
+func (s *DashboardService) Stats(ctx context.Context) (*DashboardStats, error) {
+	stats, err := s.repos.Dashboard.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &DashboardStats{
+		ActiveOrders:      stats.ActiveOrders,
+		InTransit:         stats.InTransit,
+		PendingQuotes:     stats.PendingQuotes,
+		TotalRevenue:      stats.Revenue[money.DefaultCurrency],
+		RevenueByCurrency: stats.Revenue,
+		// Average delivery time is not tracked yet (mock value)
+		AvgDeliveryTime: 3.2,
+	}, nil
+}
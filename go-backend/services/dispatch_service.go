@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"everflown-logistics/dates"
+	"everflown-logistics/events"
+	"everflown-logistics/metrics"
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+)
+
+// Dispatch change types delivered to watchers.
+const (
+	DispatchCreated = "created"
+	DispatchUpdated = "updated"
+	DispatchDeleted = "deleted"
+)
+
+// DispatchEvent describes a single change to a dispatch.
+type DispatchEvent struct {
+	Type     string
+	Dispatch models.Dispatch
+}
+
+// mirroredOnOrder lists the dispatch statuses that also become the order's status.
+var mirroredOnOrder = map[string]bool{"picked_up": true, "in_transit": true, "delivered": true}
+
+// dispatchActions maps audit log actions to the change types watchers see.
+var dispatchActions = map[string]string{
+	repository.ActionCreate: DispatchCreated,
+	repository.ActionUpdate: DispatchUpdated,
+	repository.ActionDelete: DispatchDeleted,
+}
+
+type DispatchService struct {
+	repos  *repository.Repositories
+	stream *StreamService
+}
+
+func NewDispatchService(repos *repository.Repositories, stream *StreamService) *DispatchService {
+	return &DispatchService{repos: repos, stream: stream}
+}
+
+func (s *DispatchService) List(ctx context.Context) ([]models.Dispatch, error) {
+	return s.repos.Dispatches.List(ctx)
+}
+
+func (s *DispatchService) Get(ctx context.Context, id uint) (*models.Dispatch, error) {
+	return s.repos.Dispatches.Get(ctx, id)
+}
+
+// Create assigns a carrier to an order. The order and carrier must exist,
+// and an order still waiting for a truck moves to dispatched in the same
+// transaction.
+func (s *DispatchService) Create(ctx context.Context, dispatch *models.Dispatch) error {
+	v := validator{}
+	v.check(dispatch.OrderID != 0, "orderId", "is required")
+	v.check(dispatch.CarrierID != 0, "carrierId", "is required")
+	v.oneOf("status", dispatch.Status, dispatchStatuses)
+	v.nonNegativeAmount("carrierRate", dispatch.CarrierRate)
+	v.currency("currency", dispatch.Currency)
+	validateAppointmentZones(&v, dispatch)
+	if v.err != nil {
+		return v.err
+	}
+	placeAppointments(dispatch, dispatch)
+
+	err := s.repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		order, err := tx.Orders.Get(ctx, dispatch.OrderID)
+		if err != nil {
+			return existenceError(err, "orderId", "order")
+		}
+		if _, err := tx.Carriers.Get(ctx, dispatch.CarrierID); err != nil {
+			return existenceError(err, "carrierId", "carrier")
+		}
+		if dispatch.Currency == "" {
+			dispatch.Currency = order.Currency
+		}
+		if err := tx.Dispatches.Create(ctx, dispatch); err != nil {
+			return err
+		}
+		if order.Status == "needs_truck" {
+			_, err = tx.Orders.Update(ctx, order.ID, &models.Order{Status: "dispatched"})
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	metrics.DispatchStatusChanges.WithLabelValues(dispatch.Status).Inc()
+	return nil
+}
+
+// Update applies the non-zero fields of changes to the dispatch and returns
+// the stored result. Pickup and delivery progress is mirrored onto the order,
+// and a status change raises DispatchStatusChanged.
+func (s *DispatchService) Update(ctx context.Context, id uint, changes *models.Dispatch) (*models.Dispatch, error) {
+	v := validator{}
+	v.oneOf("status", changes.Status, dispatchStatuses)
+	v.nonNegativeAmount("carrierRate", changes.CarrierRate)
+	v.currency("currency", changes.Currency)
+	validateAppointmentZones(&v, changes)
+	if v.err != nil {
+		return nil, v.err
+	}
+
+	var before, dispatch *models.Dispatch
+	err := s.repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		var err error
+		if before, err = tx.Dispatches.Get(ctx, id); err != nil {
+			return err
+		}
+		placeAppointments(changes, mergeZones(changes, before))
+		if dispatch, err = tx.Dispatches.Update(ctx, id, changes); err != nil {
+			return err
+		}
+		if dispatch.Status == before.Status {
+			return nil
+		}
+		if mirroredOnOrder[dispatch.Status] {
+			_, err = tx.Orders.Update(ctx, dispatch.OrderID, &models.Order{Status: dispatch.Status})
+			if errors.Is(err, ErrNotFound) {
+				// The order was removed after dispatch; nothing to mirror onto.
+				err = nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return events.Record(ctx, tx.Outbox, events.DispatchStatusChanged,
+			events.DispatchStatusChangedData{Dispatch: *dispatch, From: before.Status, To: dispatch.Status})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if dispatch.Status != before.Status {
+		metrics.DispatchStatusChanges.WithLabelValues(dispatch.Status).Inc()
+	}
+	return dispatch, nil
+}
+
+func validateAppointmentZones(v *validator, dispatch *models.Dispatch) {
+	v.timeZone("pickupTimeZone", dispatch.PickupTimeZone)
+	v.timeZone("deliveryTimeZone", dispatch.DeliveryTimeZone)
+}
+
+// mergeZones returns the appointment zones that apply after changes: the
+// new ones if given, otherwise those already stored.
+func mergeZones(changes, stored *models.Dispatch) *models.Dispatch {
+	zones := &models.Dispatch{PickupTimeZone: stored.PickupTimeZone, DeliveryTimeZone: stored.DeliveryTimeZone}
+	if changes.PickupTimeZone != nil {
+		zones.PickupTimeZone = changes.PickupTimeZone
+	}
+	if changes.DeliveryTimeZone != nil {
+		zones.DeliveryTimeZone = changes.DeliveryTimeZone
+	}
+	return zones
+}
+
+// placeAppointments reads appointment times given without a UTC offset in
+// the pickup or delivery location's zone, or in UTC when it isn't known.
+func placeAppointments(dispatch, zones *models.Dispatch) {
+	pickup, delivery := location(zones.PickupTimeZone), location(zones.DeliveryTimeZone)
+	for _, appointment := range []struct {
+		ts  *dates.Timestamp
+		loc *time.Location
+	}{
+		{dispatch.EstimatedPickupTime, pickup},
+		{dispatch.ActualPickupTime, pickup},
+		{dispatch.EstimatedDeliveryTime, delivery},
+		{dispatch.ActualDeliveryTime, delivery},
+	} {
+		if appointment.ts != nil && appointment.ts.IsLocal() {
+			*appointment.ts = appointment.ts.In(appointment.loc)
+		}
+	}
+}
+
+// location loads a zone that has already been validated, defaulting to UTC.
+func location(name *string) *time.Location {
+	if name == nil || *name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(*name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func (s *DispatchService) Delete(ctx context.Context, id uint) error {
+	return s.repos.Dispatches.Delete(ctx, id)
+}
+
+// Watch streams changes to dispatches, made through any instance, until ctx
+// is cancelled. It follows the change stream, so changes arrive after its
+// next poll. Only changes made for tenantID are sent, and customerID, if
+// set, limits them to the customer's dispatches.
+// The returned channel is also closed if the watcher falls too far behind.
+func (s *DispatchService) Watch(ctx context.Context, tenantID string, customerID *uint) (<-chan DispatchEvent, error) {
+	filter := StreamFilter{Entities: map[string]bool{"dispatches": true}, TenantID: tenantID, CustomerID: customerID}
+	sub, err := s.stream.Subscribe(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan DispatchEvent)
+	go func() {
+		defer close(ch)
+		defer s.stream.Unsubscribe(sub)
+		for {
+			var change StreamEvent
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case change, ok = <-sub.Events:
+				if !ok {
+					return
+				}
+			}
+			event, found, err := s.dispatchEvent(ctx, change)
+			if err != nil {
+				slog.Error("Failed to load watched dispatch", "dispatch_id", change.EntityID, "error", err)
+				continue
+			}
+			if !found {
+				continue
+			}
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// dispatchEvent turns a change to a dispatch into the event watchers get:
+// the dispatch as it is now, or as it was for a delete. It reports false
+// for a dispatch that has since been deleted, whose delete follows.
+func (s *DispatchService) dispatchEvent(ctx context.Context, change StreamEvent) (DispatchEvent, bool, error) {
+	event := DispatchEvent{Type: dispatchActions[change.Action]}
+	id, err := strconv.ParseUint(change.EntityID, 10, 64)
+	if err != nil || event.Type == "" {
+		return event, false, err
+	}
+	if event.Type == DispatchDeleted {
+		values := map[string]json.RawMessage{}
+		for name, field := range change.Changes {
+			values[name] = field.From
+		}
+		data, err := json.Marshal(values)
+		if err == nil {
+			err = json.Unmarshal(data, &event.Dispatch)
+		}
+		event.Dispatch.ID = uint(id)
+		return event, true, err
+	}
+	dispatch, err := s.Get(ctx, uint(id))
+	if errors.Is(err, ErrNotFound) {
+		return event, false, nil
+	}
+	if err != nil {
+		return event, false, err
+	}
+	event.Dispatch = *dispatch
+	return event, true, nil
+}
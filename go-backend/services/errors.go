@@ -0,0 +1,18 @@
+package services
+
+import "everflown-logistics/repository"
+
+// ErrNotFound is returned when the requested record does not exist.
+var ErrNotFound = repository.ErrNotFound
+
+// ErrInvalidReference is returned when a record refers to another that does
+// not exist.
+var ErrInvalidReference = repository.ErrInvalidReference
+
+// ErrConflict is returned when a record would duplicate a unique value held
+// by another, such as an order number.
+var ErrConflict = repository.ErrConflict
+
+// DependentsError is returned when a record can't be deleted because others
+// still refer to it. Dependents lists them by table.
+type DependentsError = repository.DependentsError
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+)
+
+// EventService looks after the domain events subscribers gave up on.
+type EventService struct {
+	repos *repository.Repositories
+}
+
+func NewEventService(repos *repository.Repositories) *EventService {
+	return &EventService{repos: repos}
+}
+
+// DeadLetters returns the events subscribers gave up on, newest first. Pass
+// the ID of the last one as beforeID to fetch the next page.
+func (s *EventService) DeadLetters(ctx context.Context, beforeID uint, limit int) ([]models.EventDeadLetter, error) {
+	v := validator{}
+	v.check(limit >= 0 && limit <= maxAuditLimit, "limit", "must be between 1 and 500")
+	if v.err != nil {
+		return nil, v.err
+	}
+	if limit == 0 {
+		limit = defaultAuditLimit
+	}
+	return s.repos.Outbox.DeadLetters(ctx, beforeID, limit)
+}
+
+// RetryDeadLetter hands a dead letter's event to its subscriber again on the
+// dispatcher's next run.
+func (s *EventService) RetryDeadLetter(ctx context.Context, id uint) (*models.EventDeadLetter, error) {
+	return s.repos.Outbox.RetryDeadLetter(ctx, id)
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+
+	"everflown-logistics/dates"
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+)
+
+type FollowUpService struct {
+	repos *repository.Repositories
+}
+
+func NewFollowUpService(repos *repository.Repositories) *FollowUpService {
+	return &FollowUpService{repos: repos}
+}
+
+func (s *FollowUpService) List(ctx context.Context) ([]models.FollowUp, error) {
+	return s.repos.FollowUps.List(ctx)
+}
+
+// ListUrgent returns open high-priority follow-ups.
+func (s *FollowUpService) ListUrgent(ctx context.Context) ([]models.FollowUp, error) {
+	return s.repos.FollowUps.ListUrgent(ctx)
+}
+
+func (s *FollowUpService) Get(ctx context.Context, id uint) (*models.FollowUp, error) {
+	return s.repos.FollowUps.Get(ctx, id)
+}
+
+func (s *FollowUpService) Create(ctx context.Context, followUp *models.FollowUp) error {
+	v := validator{}
+	v.required("title", followUp.Title)
+	v.required("type", followUp.Type)
+	v.check(!followUp.DueDate.IsZero(), "dueDate", "is required")
+	v.oneOf("priority", followUp.Priority, priorities)
+	if v.err != nil {
+		return v.err
+	}
+	stampCompletedAt(followUp)
+	return s.repos.FollowUps.Create(ctx, followUp)
+}
+
+// Update applies the non-zero fields of changes to the follow-up and returns
+// the stored result. Completing a follow-up records when it was completed.
+func (s *FollowUpService) Update(ctx context.Context, id uint, changes *models.FollowUp) (*models.FollowUp, error) {
+	v := validator{}
+	v.oneOf("priority", changes.Priority, priorities)
+	if v.err != nil {
+		return nil, v.err
+	}
+
+	var followUp *models.FollowUp
+	err := s.repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		before, err := tx.FollowUps.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !before.Completed {
+			stampCompletedAt(changes)
+		}
+		followUp, err = tx.FollowUps.Update(ctx, id, changes)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return followUp, nil
+}
+
+func (s *FollowUpService) Delete(ctx context.Context, id uint) error {
+	return s.repos.FollowUps.Delete(ctx, id)
+}
+
+func stampCompletedAt(followUp *models.FollowUp) {
+	if followUp.Completed && followUp.CompletedAt == nil {
+		now := dates.Now()
+		followUp.CompletedAt = &now
+	}
+}
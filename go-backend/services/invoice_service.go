@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"everflown-logistics/dates"
+	"everflown-logistics/events"
+	"everflown-logistics/metrics"
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+)
+
+type InvoiceService struct {
+	repos *repository.Repositories
+}
+
+func NewInvoiceService(repos *repository.Repositories) *InvoiceService {
+	return &InvoiceService{repos: repos}
+}
+
+func (s *InvoiceService) List(ctx context.Context) ([]models.Invoice, error) {
+	return s.repos.Invoices.List(ctx)
+}
+
+func (s *InvoiceService) Get(ctx context.Context, id uint) (*models.Invoice, error) {
+	return s.repos.Invoices.Get(ctx, id)
+}
+
+// GetWithParties returns the invoice with its order and customer loaded.
+func (s *InvoiceService) GetWithParties(ctx context.Context, id uint) (*models.Invoice, error) {
+	return s.repos.Invoices.GetWithParties(ctx, id)
+}
+
+func (s *InvoiceService) Create(ctx context.Context, invoice *models.Invoice) error {
+	v := validator{}
+	v.required("invoiceNumber", invoice.InvoiceNumber)
+	v.required("type", invoice.Type)
+	v.check(!invoice.DueDate.IsZero(), "dueDate", "is required")
+	v.check(invoice.Type != "customer" || invoice.CustomerID != nil, "customerId", "is required for customer invoices")
+	v.check(invoice.Type != "carrier" || invoice.CarrierID != nil, "carrierId", "is required for carrier invoices")
+	validateInvoice(&v, invoice)
+	if v.err != nil {
+		return v.err
+	}
+
+	stampPaidDate(invoice, "")
+	err := s.repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		if err := tx.Invoices.Create(ctx, invoice); err != nil {
+			return err
+		}
+		if invoice.Status == "paid" {
+			return events.Record(ctx, tx.Outbox, events.InvoicePaid, events.InvoicePaidData{Invoice: *invoice})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.InvoicesIssued.WithLabelValues(invoice.Type).Inc()
+	if invoice.Status == "paid" {
+		metrics.InvoicesPaid.WithLabelValues(invoice.Type).Inc()
+	}
+	return nil
+}
+
+// Update applies the non-zero fields of changes to the invoice and returns
+// the stored result. Marking an invoice paid records the payment date and
+// raises InvoicePaid.
+func (s *InvoiceService) Update(ctx context.Context, id uint, changes *models.Invoice) (*models.Invoice, error) {
+	v := validator{}
+	validateInvoice(&v, changes)
+	if v.err != nil {
+		return nil, v.err
+	}
+
+	var before, invoice *models.Invoice
+	err := s.repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		var err error
+		if before, err = tx.Invoices.Get(ctx, id); err != nil {
+			return err
+		}
+		stampPaidDate(changes, before.Status)
+		if invoice, err = tx.Invoices.Update(ctx, id, changes); err != nil {
+			return err
+		}
+		if before.Status != "paid" && invoice.Status == "paid" {
+			return events.Record(ctx, tx.Outbox, events.InvoicePaid, events.InvoicePaidData{Invoice: *invoice})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if before.Status != "paid" && invoice.Status == "paid" {
+		metrics.InvoicesPaid.WithLabelValues(invoice.Type).Inc()
+	}
+	return invoice, nil
+}
+
+func (s *InvoiceService) Delete(ctx context.Context, id uint) error {
+	return s.repos.Invoices.Delete(ctx, id)
+}
+
+func validateInvoice(v *validator, invoice *models.Invoice) {
+	v.oneOf("type", invoice.Type, invoiceTypes)
+	v.oneOf("status", invoice.Status, invoiceStatuses)
+	v.nonNegativeAmount("amount", invoice.Amount)
+	v.currency("currency", invoice.Currency)
+}
+
+// stampPaidDate sets today's date as the paid date when invoice moves to
+// paid from previousStatus without one.
+func stampPaidDate(invoice *models.Invoice, previousStatus string) {
+	if invoice.Status == "paid" && previousStatus != "paid" && invoice.PaidDate == nil {
+		today := dates.Today(time.Local)
+		invoice.PaidDate = &today
+	}
+}
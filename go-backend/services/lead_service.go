@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+)
+
+type LeadService struct {
+	repos *repository.Repositories
+}
+
+func NewLeadService(repos *repository.Repositories) *LeadService {
+	return &LeadService{repos: repos}
+}
+
+func (s *LeadService) List(ctx context.Context) ([]models.Lead, error) {
+	return s.repos.Leads.List(ctx)
+}
+
+func (s *LeadService) Get(ctx context.Context, id uint) (*models.Lead, error) {
+	return s.repos.Leads.Get(ctx, id)
+}
+
+func (s *LeadService) Create(ctx context.Context, lead *models.Lead) error {
+	v := validator{}
+	v.required("companyName", lead.CompanyName)
+	v.required("email", lead.Email)
+	validateLead(&v, lead)
+	if v.err != nil {
+		return v.err
+	}
+	return s.repos.Leads.Create(ctx, lead)
+}
+
+// Update applies the non-zero fields of changes to the lead and returns the stored result.
+func (s *LeadService) Update(ctx context.Context, id uint, changes *models.Lead) (*models.Lead, error) {
+	v := validator{}
+	validateLead(&v, changes)
+	if v.err != nil {
+		return nil, v.err
+	}
+	return s.repos.Leads.Update(ctx, id, changes)
+}
+
+func (s *LeadService) Delete(ctx context.Context, id uint) error {
+	return s.repos.Leads.Delete(ctx, id)
+}
+
+func validateLead(v *validator, lead *models.Lead) {
+	v.email("email", lead.Email)
+	v.oneOf("status", lead.Status, leadStatuses)
+	if lead.Weight != nil {
+		v.check(*lead.Weight >= 0, "weight", "must not be negative")
+	}
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+
+	"everflown-logistics/events"
+	"everflown-logistics/metrics"
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+)
+
+type OrderService struct {
+	repos *repository.Repositories
+}
+
+func NewOrderService(repos *repository.Repositories) *OrderService {
+	return &OrderService{repos: repos}
+}
+
+func (s *OrderService) List(ctx context.Context) ([]models.Order, error) {
+	return s.repos.Orders.List(ctx)
+}
+
+func (s *OrderService) Get(ctx context.Context, id uint) (*models.Order, error) {
+	return s.repos.Orders.Get(ctx, id)
+}
+
+func (s *OrderService) Create(ctx context.Context, order *models.Order) error {
+	v := validator{}
+	v.required("orderNumber", order.OrderNumber)
+	v.required("originCity", order.OriginCity)
+	v.required("originState", order.OriginState)
+	v.required("destinationCity", order.DestinationCity)
+	v.required("destinationState", order.DestinationState)
+	v.check(!order.PickupDate.IsZero(), "pickupDate", "is required")
+	v.required("equipmentType", order.EquipmentType)
+	validateOrder(&v, order)
+	if v.err != nil {
+		return v.err
+	}
+
+	err := s.repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		if err := tx.Orders.Create(ctx, order); err != nil {
+			return err
+		}
+		return events.Record(ctx, tx.Outbox, events.OrderCreated, events.OrderCreatedData{Order: *order})
+	})
+	if err != nil {
+		return err
+	}
+	metrics.OrdersCreated.Inc()
+	return nil
+}
+
+// Update applies the non-zero fields of changes to the order and returns the stored result.
+func (s *OrderService) Update(ctx context.Context, id uint, changes *models.Order) (*models.Order, error) {
+	v := validator{}
+	validateOrder(&v, changes)
+	if v.err != nil {
+		return nil, v.err
+	}
+	return s.repos.Orders.Update(ctx, id, changes)
+}
+
+func (s *OrderService) Delete(ctx context.Context, id uint) error {
+	return s.repos.Orders.Delete(ctx, id)
+}
+
+// validateOrder checks the rules that apply to both new orders and changes.
+func validateOrder(v *validator, order *models.Order) {
+	v.oneOf("status", order.Status, orderStatuses)
+	v.nonNegativeAmount("customerRate", order.CustomerRate)
+	v.currency("currency", order.Currency)
+	if order.Weight != nil {
+		v.nonNegative("weight", *order.Weight)
+	}
+}
@@ -2,34 +2,82 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"strconv"
-	"time"
 
 	"everflown-logistics/models"
 	"github.com/jung-kurt/gofpdf"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
-type PDFService struct{}
+// tracer is looked up on each use so a tracer provider installed after
+// package initialisation is honoured.
+func tracer() trace.Tracer {
+	return otel.Tracer("everflown-logistics/services")
+}
+
+// Branding is the company identity printed on generated documents.
+type Branding struct {
+	Name    string
+	Tagline string
+	Motto   string
+}
+
+// DefaultBranding is used when no branding is configured.
+var DefaultBranding = Branding{
+	Name:    "EverFlown Logistics",
+	Tagline: "Professional Freight Brokerage Services",
+	Motto:   "Your Trusted Freight Partner",
+}
+
+type PDFService struct {
+	branding Branding
+}
+
+func NewPDFService(branding Branding) *PDFService {
+	return &PDFService{branding: branding}
+}
+
+// endSpan records err on span, if any, and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
 
-func NewPDFService() *PDFService {
-	return &PDFService{}
+// stringValue returns the value of an optional model field, or "" when unset.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
 
-func (s *PDFService) GenerateInvoicePDF(invoice models.Invoice, order models.Order, customer models.Customer) ([]byte, error) {
+func (s *PDFService) GenerateInvoicePDF(ctx context.Context, invoice models.Invoice, order models.Order, customer models.Customer) (_ []byte, err error) {
+	_, span := tracer().Start(ctx, "PDFService.GenerateInvoicePDF", trace.WithAttributes(
+		attribute.String("invoice.number", invoice.InvoiceNumber),
+	))
+	defer func() { endSpan(span, err) }()
+
 	pdf := gofpdf.New("P", "mm", "A4", "")
 	pdf.AddPage()
 
-	// EverFlown Logistics Header
+	// Company header
 	pdf.SetFont("Arial", "B", 24)
 	pdf.SetTextColor(41, 128, 185) // Blue color
-	pdf.Cell(0, 15, "EverFlown Logistics")
+	pdf.Cell(0, 15, s.branding.Name)
 	pdf.Ln(10)
 
 	// Subtitle
 	pdf.SetFont("Arial", "", 12)
 	pdf.SetTextColor(128, 128, 128)
-	pdf.Cell(0, 8, "Professional Freight Brokerage Services")
+	pdf.Cell(0, 8, s.branding.Tagline)
 	pdf.Ln(15)
 
 	// Invoice Title
@@ -77,9 +125,9 @@ func (s *PDFService) GenerateInvoicePDF(invoice models.Invoice, order models.Ord
 		pdf.Cell(0, 6, "Attn: "+customer.ContactPerson)
 		pdf.Ln(6)
 	}
-	pdf.Cell(0, 6, customer.BillingAddress)
+	pdf.Cell(0, 6, stringValue(customer.BillingAddress))
 	pdf.Ln(6)
-	pdf.Cell(0, 6, fmt.Sprintf("%s, %s %s", customer.BillingCity, customer.BillingState, customer.BillingZipCode))
+	pdf.Cell(0, 6, fmt.Sprintf("%s, %s %s", stringValue(customer.BillingCity), stringValue(customer.BillingState), stringValue(customer.BillingZipCode)))
 	pdf.Ln(15)
 
 	// Shipment Details
@@ -106,11 +154,11 @@ func (s *PDFService) GenerateInvoicePDF(invoice models.Invoice, order models.Ord
 	pdf.SetFont("Arial", "", 9)
 	
 	route := fmt.Sprintf("%s, %s - %s, %s", order.OriginCity, order.OriginState, order.DestinationCity, order.DestinationState)
-	commodity := order.Commodity
+	commodity := stringValue(order.Commodity)
 	if commodity == "" {
 		commodity = "General Freight"
 	}
-	amount := fmt.Sprintf("$%.2f", invoice.Amount)
+	amount := invoice.Amount.Format(invoice.Currency)
 	
 	values := []string{route, order.EquipmentType, commodity, amount}
 	
@@ -128,7 +176,7 @@ func (s *PDFService) GenerateInvoicePDF(invoice models.Invoice, order models.Ord
 	totalY := pdf.GetY()
 	pdf.SetXY(140, totalY)
 	pdf.Cell(30, 8, "Total Amount:")
-	pdf.Cell(20, 8, fmt.Sprintf("$%.2f", invoice.Amount))
+	pdf.Cell(20, 8, invoice.Amount.Format(invoice.Currency))
 	pdf.Ln(15)
 
 	// Payment Terms
@@ -140,12 +188,12 @@ func (s *PDFService) GenerateInvoicePDF(invoice models.Invoice, order models.Ord
 	pdf.Ln(10)
 
 	// Notes
-	if invoice.Notes != "" {
+	if invoice.Notes != nil && *invoice.Notes != "" {
 		pdf.SetFont("Arial", "B", 10)
 		pdf.Cell(0, 8, "Notes:")
 		pdf.Ln(6)
 		pdf.SetFont("Arial", "", 10)
-		pdf.MultiCell(0, 6, invoice.Notes, "", "", false)
+		pdf.MultiCell(0, 6, *invoice.Notes, "", "", false)
 		pdf.Ln(5)
 	}
 
@@ -155,31 +203,37 @@ func (s *PDFService) GenerateInvoicePDF(invoice models.Invoice, order models.Ord
 	pdf.SetTextColor(128, 128, 128)
 	pdf.Cell(0, 6, "Thank you for your business! For questions about this invoice, please contact us.")
 	pdf.Ln(4)
-	pdf.Cell(0, 6, "EverFlown Logistics - Your Trusted Freight Partner")
+	pdf.Cell(0, 6, fmt.Sprintf("%s - %s", s.branding.Name, s.branding.Motto))
 
 	var buf bytes.Buffer
-	err := pdf.Output(&buf)
+	err = pdf.Output(&buf)
 	if err != nil {
 		return nil, err
 	}
 
+	span.SetAttributes(attribute.Int("pdf.size_bytes", buf.Len()))
 	return buf.Bytes(), nil
 }
 
-func (s *PDFService) GenerateQuotePDF(quote models.Quote, lead models.Lead) ([]byte, error) {
+func (s *PDFService) GenerateQuotePDF(ctx context.Context, quote models.Quote, lead models.Lead) (_ []byte, err error) {
+	_, span := tracer().Start(ctx, "PDFService.GenerateQuotePDF", trace.WithAttributes(
+		attribute.String("quote.number", quote.QuoteNumber),
+	))
+	defer func() { endSpan(span, err) }()
+
 	pdf := gofpdf.New("P", "mm", "A4", "")
 	pdf.AddPage()
 
-	// EverFlown Logistics Header
+	// Company header
 	pdf.SetFont("Arial", "B", 24)
 	pdf.SetTextColor(41, 128, 185) // Blue color
-	pdf.Cell(0, 15, "EverFlown Logistics")
+	pdf.Cell(0, 15, s.branding.Name)
 	pdf.Ln(10)
 
 	// Subtitle
 	pdf.SetFont("Arial", "", 12)
 	pdf.SetTextColor(128, 128, 128)
-	pdf.Cell(0, 8, "Professional Freight Brokerage Services")
+	pdf.Cell(0, 8, s.branding.Tagline)
 	pdf.Ln(15)
 
 	// Quote Title
@@ -261,15 +315,15 @@ func (s *PDFService) GenerateQuotePDF(quote models.Quote, lead models.Lead) ([]b
 	pdf.SetFont("Arial", "", 9)
 	
 	route := fmt.Sprintf("%s, %s - %s, %s", quote.OriginCity, quote.OriginState, quote.DestinationCity, quote.DestinationState)
-	commodity := quote.Commodity
+	commodity := stringValue(quote.Commodity)
 	if commodity == "" {
 		commodity = "General Freight"
 	}
 	weight := ""
-	if quote.Weight > 0 {
-		weight = strconv.FormatFloat(quote.Weight, 'f', 0, 64)
+	if quote.Weight != nil && *quote.Weight > 0 {
+		weight = strconv.FormatFloat(*quote.Weight, 'f', 0, 64)
 	}
-	rate := fmt.Sprintf("$%.2f", quote.QuotedRate)
+	rate := quote.QuotedRate.Format(quote.Currency)
 	
 	values := []string{route, quote.EquipmentType, weight, commodity, rate}
 	
@@ -283,7 +337,7 @@ func (s *PDFService) GenerateQuotePDF(quote models.Quote, lead models.Lead) ([]b
 	pdf.Ln(15)
 
 	// Pickup Date
-	if quote.PickupDate != nil {
+	if quote.PickupDate != nil && !quote.PickupDate.IsZero() {
 		pdf.SetFont("Arial", "B", 10)
 		pdf.Cell(40, 6, "Requested Pickup:")
 		pdf.SetFont("Arial", "", 10)
@@ -296,7 +350,7 @@ func (s *PDFService) GenerateQuotePDF(quote models.Quote, lead models.Lead) ([]b
 	totalY := pdf.GetY()
 	pdf.SetXY(120, totalY)
 	pdf.Cell(40, 10, "Total Quoted Rate:")
-	pdf.Cell(30, 10, fmt.Sprintf("$%.2f", quote.QuotedRate))
+	pdf.Cell(30, 10, quote.QuotedRate.Format(quote.Currency))
 	pdf.Ln(20)
 
 	// Terms and Conditions
@@ -320,12 +374,12 @@ func (s *PDFService) GenerateQuotePDF(quote models.Quote, lead models.Lead) ([]b
 	pdf.Ln(5)
 
 	// Notes
-	if quote.Notes != "" {
+	if quote.Notes != nil && *quote.Notes != "" {
 		pdf.SetFont("Arial", "B", 10)
 		pdf.Cell(0, 8, "Additional Notes:")
 		pdf.Ln(6)
 		pdf.SetFont("Arial", "", 10)
-		pdf.MultiCell(0, 6, quote.Notes, "", "", false)
+		pdf.MultiCell(0, 6, *quote.Notes, "", "", false)
 		pdf.Ln(5)
 	}
 
@@ -333,15 +387,16 @@ func (s *PDFService) GenerateQuotePDF(quote models.Quote, lead models.Lead) ([]b
 	pdf.Ln(10)
 	pdf.SetFont("Arial", "I", 8)
 	pdf.SetTextColor(128, 128, 128)
-	pdf.Cell(0, 6, "Thank you for considering EverFlown Logistics for your freight needs!")
+	pdf.Cell(0, 6, fmt.Sprintf("Thank you for considering %s for your freight needs!", s.branding.Name))
 	pdf.Ln(4)
 	pdf.Cell(0, 6, "Contact us to book this shipment or discuss your requirements.")
 
 	var buf bytes.Buffer
-	err := pdf.Output(&buf)
+	err = pdf.Output(&buf)
 	if err != nil {
 		return nil, err
 	}
 
+	span.SetAttributes(attribute.Int("pdf.size_bytes", buf.Len()))
 	return buf.Bytes(), nil
 }
\ No newline at end of file
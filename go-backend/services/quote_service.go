@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+
+	"everflown-logistics/events"
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+)
+
+type QuoteService struct {
+	repos *repository.Repositories
+}
+
+func NewQuoteService(repos *repository.Repositories) *QuoteService {
+	return &QuoteService{repos: repos}
+}
+
+func (s *QuoteService) List(ctx context.Context) ([]models.Quote, error) {
+	return s.repos.Quotes.List(ctx)
+}
+
+func (s *QuoteService) Get(ctx context.Context, id uint) (*models.Quote, error) {
+	return s.repos.Quotes.Get(ctx, id)
+}
+
+// GetWithParties returns the quote with its lead and customer loaded.
+func (s *QuoteService) GetWithParties(ctx context.Context, id uint) (*models.Quote, error) {
+	return s.repos.Quotes.GetWithParties(ctx, id)
+}
+
+func (s *QuoteService) Create(ctx context.Context, quote *models.Quote) error {
+	v := validator{}
+	v.required("quoteNumber", quote.QuoteNumber)
+	v.required("originCity", quote.OriginCity)
+	v.required("originState", quote.OriginState)
+	v.required("destinationCity", quote.DestinationCity)
+	v.required("destinationState", quote.DestinationState)
+	v.required("equipmentType", quote.EquipmentType)
+	v.check(!quote.ValidUntil.IsZero(), "validUntil", "is required")
+	v.check(quote.LeadID != nil || quote.CustomerID != nil, "leadId", "or customerId is required")
+	validateQuote(&v, quote)
+	if v.err != nil {
+		return v.err
+	}
+	return s.repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		if err := tx.Quotes.Create(ctx, quote); err != nil {
+			return err
+		}
+		if quote.Status == "accepted" {
+			return events.Record(ctx, tx.Outbox, events.QuoteAccepted, events.QuoteAcceptedData{Quote: *quote})
+		}
+		return nil
+	})
+}
+
+// Update applies the non-zero fields of changes to the quote and returns the
+// stored result. Accepting a quote for a lead converts the lead in the same
+// transaction, and accepting any quote raises QuoteAccepted.
+func (s *QuoteService) Update(ctx context.Context, id uint, changes *models.Quote) (*models.Quote, error) {
+	v := validator{}
+	validateQuote(&v, changes)
+	if v.err != nil {
+		return nil, v.err
+	}
+
+	var quote *models.Quote
+	err := s.repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		before, err := tx.Quotes.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		if quote, err = tx.Quotes.Update(ctx, id, changes); err != nil {
+			return err
+		}
+		if quote.Status != "accepted" || before.Status == "accepted" {
+			return nil
+		}
+		if quote.LeadID != nil {
+			_, err = tx.Leads.Update(ctx, *quote.LeadID, &models.Lead{Status: "converted"})
+			if err != nil {
+				return existenceError(err, "leadId", "lead")
+			}
+		}
+		return events.Record(ctx, tx.Outbox, events.QuoteAccepted, events.QuoteAcceptedData{Quote: *quote})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+func (s *QuoteService) Delete(ctx context.Context, id uint) error {
+	return s.repos.Quotes.Delete(ctx, id)
+}
+
+func validateQuote(v *validator, quote *models.Quote) {
+	v.oneOf("status", quote.Status, quoteStatuses)
+	v.nonNegativeAmount("quotedRate", quote.QuotedRate)
+	v.currency("currency", quote.Currency)
+	if quote.Weight != nil {
+		v.nonNegative("weight", *quote.Weight)
+	}
+}
@@ -0,0 +1,48 @@
+package services
+
+import "everflown-logistics/repository"
+
+// Services bundles the domain services. They own validation, transactions
+// and workflows, so the same rules apply to the HTTP and gRPC APIs, CLIs and
+// background jobs.
+type Services struct {
+	Users      *UserService
+	Dashboard  *DashboardService
+	Leads      *LeadService
+	Customers  *CustomerService
+	Carriers   *CarrierService
+	Orders     *OrderService
+	Dispatches *DispatchService
+	Quotes     *QuoteService
+	Invoices   *InvoiceService
+	FollowUps  *FollowUpService
+	PDF        *PDFService
+	Archive    *ArchiveService
+	Audit      *AuditService
+	Events     *EventService
+	Webhooks   *WebhookService
+	Stream     *StreamService
+}
+
+// New builds the services on top of repos; branding is printed on generated PDFs.
+func New(repos *repository.Repositories, branding Branding) *Services {
+	stream := NewStreamService(repos)
+	return &Services{
+		Users:      NewUserService(repos),
+		Dashboard:  NewDashboardService(repos),
+		Leads:      NewLeadService(repos),
+		Customers:  NewCustomerService(repos),
+		Carriers:   NewCarrierService(repos),
+		Orders:     NewOrderService(repos),
+		Dispatches: NewDispatchService(repos, stream),
+		Quotes:     NewQuoteService(repos),
+		Invoices:   NewInvoiceService(repos),
+		FollowUps:  NewFollowUpService(repos),
+		PDF:        NewPDFService(branding),
+		Archive:    NewArchiveService(repos),
+		Audit:      NewAuditService(repos),
+		Events:     NewEventService(repos),
+		Webhooks:   NewWebhookService(repos),
+		Stream:     stream,
+	}
+}
@@ -0,0 +1,348 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"everflown-logistics/metrics"
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+)
+
+// ErrStreamClosed is returned when subscribing after the stream has closed
+// for shutdown.
+var ErrStreamClosed = errors.New("change stream closed")
+
+const (
+	// streamBuffer is how many events a subscriber may fall behind before it
+	// is dropped; it can then reconnect and resume.
+	streamBuffer = 256
+	// streamBatch is how many audit entries are read per query.
+	streamBatch = 500
+	// maxStreamReplay is how far back, in audit log entries, a subscriber can
+	// resume. Beyond that it is told to reload instead.
+	maxStreamReplay = 10000
+	// streamCommitWindow is how long a gap in the audit log is waited on.
+	// Entry IDs are taken before their transaction commits, so an entry can
+	// appear after later ones; a gap still open after this long is taken to
+	// be a rolled back transaction.
+	streamCommitWindow = 2 * time.Minute
+)
+
+// streamEntities are the tables whose changes are streamed, with the roles
+// allowed to see them. Follow-ups are the brokers' own tasks.
+var streamEntities = map[string][]string{
+	"orders":     userRoles,
+	"dispatches": userRoles,
+	"follow_ups": {"admin", "broker"},
+}
+
+// StreamFilter selects the changes a subscriber sees.
+type StreamFilter struct {
+	Entities map[string]bool
+	// TenantID limits changes to those made for the tenant; changes made
+	// without a tenant only match an empty TenantID.
+	TenantID string
+	// CustomerID, if set, limits changes to records the customer owns.
+	CustomerID *uint
+}
+
+// StreamFilterFor returns the filter for a user with role in tenantID,
+// acting for customerID if it's set. It has no entities if the role may not
+// see any.
+func StreamFilterFor(role, tenantID string, customerID *uint) StreamFilter {
+	filter := StreamFilter{Entities: map[string]bool{}, TenantID: tenantID, CustomerID: customerID}
+	for entity, roles := range streamEntities {
+		for _, r := range roles {
+			if r == role {
+				filter.Entities[entity] = true
+			}
+		}
+	}
+	return filter
+}
+
+func (f StreamFilter) matches(entry *models.AuditEntry) bool {
+	if !f.Entities[entry.Entity] {
+		return false
+	}
+	tenantID := ""
+	if entry.TenantID != nil {
+		tenantID = *entry.TenantID
+	}
+	if tenantID != f.TenantID {
+		return false
+	}
+	return f.CustomerID == nil || (entry.CustomerID != nil && *entry.CustomerID == *f.CustomerID)
+}
+
+func (f StreamFilter) entities() []string {
+	entities := make([]string, 0, len(f.Entities))
+	for entity := range f.Entities {
+		entities = append(entities, entity)
+	}
+	return entities
+}
+
+// StreamEvent is a change to a record, taken from the audit log. Its ID is
+// the audit entry's.
+type StreamEvent struct {
+	ID         uint           `json:"id"`
+	Entity     string         `json:"entity"`
+	EntityID   string         `json:"entityId"`
+	Action     string         `json:"action"`
+	Changes    models.Changes `json:"changes,omitempty"`
+	UserID     string         `json:"userId"`
+	OccurredAt time.Time      `json:"occurredAt"`
+	// Position is where a subscriber that got this event resumes from. Every
+	// change up to it had been delivered; since changes can commit out of
+	// order, it may be below ID, and resuming can repeat events.
+	Position uint `json:"-"`
+}
+
+func streamEvent(entry models.AuditEntry, position uint) StreamEvent {
+	return StreamEvent{
+		Position:   position,
+		ID:         entry.ID,
+		Entity:     entry.Entity,
+		EntityID:   entry.EntityID,
+		Action:     entry.Action,
+		Changes:    entry.Changes,
+		UserID:     entry.UserID,
+		OccurredAt: entry.OccurredAt,
+	}
+}
+
+// StreamSubscription receives the changes matching its filter.
+type StreamSubscription struct {
+	// Backlog holds the changes delivered since the position the subscriber
+	// resumed from, oldest first.
+	Backlog []StreamEvent
+	// Reset is set when the subscriber resumed from too far back to replay
+	// what it missed; it should reload its data.
+	Reset bool
+	// Head is the stream's position when it subscribed; Events follow it.
+	Head uint
+	// Events delivers changes as they are made. It is closed when the
+	// subscriber falls too far behind or the stream closes.
+	Events <-chan StreamEvent
+
+	events chan StreamEvent
+	filter StreamFilter
+}
+
+// StreamService fans changes to orders, dispatches and follow-ups out to
+// connected clients. Each instance follows the audit log with Poll, so
+// clients see the changes made through any instance.
+type StreamService struct {
+	repos *repository.Repositories
+
+	mu      sync.Mutex
+	started bool
+	closed  bool
+	// lastID is the stream's position: every entry up to it has been
+	// published. seen holds the entries above it already published, with
+	// when they were read, while the gaps below them may still fill.
+	lastID      uint
+	seen        map[uint]time.Time
+	subscribers map[*StreamSubscription]struct{}
+}
+
+func NewStreamService(repos *repository.Repositories) *StreamService {
+	return &StreamService{repos: repos, seen: map[uint]time.Time{}, subscribers: map[*StreamSubscription]struct{}{}}
+}
+
+// Subscribe starts delivering the changes matching filter made from now on.
+// Call Unsubscribe when done.
+func (s *StreamService) Subscribe(ctx context.Context, filter StreamFilter) (*StreamSubscription, error) {
+	sub, _, err := s.subscribe(ctx, filter)
+	return sub, err
+}
+
+// subscribe is Subscribe, also returning the IDs of the entries above the
+// subscription's Head that were already published.
+func (s *StreamService) subscribe(ctx context.Context, filter StreamFilter) (*StreamSubscription, map[uint]bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, nil, ErrStreamClosed
+	}
+	if err := s.start(ctx); err != nil {
+		return nil, nil, err
+	}
+	sub := &StreamSubscription{events: make(chan StreamEvent, streamBuffer), filter: filter}
+	sub.Events = sub.events
+	s.subscribers[sub] = struct{}{}
+	metrics.StreamSubscribers.Inc()
+	sub.Head = s.lastID
+	published := make(map[uint]bool, len(s.seen))
+	for id := range s.seen {
+		published[id] = true
+	}
+	return sub, published, nil
+}
+
+// Resume subscribes like Subscribe, and replays the changes matching filter
+// delivered after position lastEventID in the subscription's Backlog.
+func (s *StreamService) Resume(ctx context.Context, filter StreamFilter, lastEventID uint) (*StreamSubscription, error) {
+	sub, published, err := s.subscribe(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	// Live events are those published from now on. Before that, everything
+	// up to head was published, and some entries above it.
+	head, last := sub.Head, sub.Head
+	for id := range published {
+		last = max(last, id)
+	}
+	if lastEventID >= last {
+		return sub, nil
+	}
+	if head > lastEventID && head-lastEventID > maxStreamReplay {
+		sub.Reset = true
+		return sub, nil
+	}
+	for after := lastEventID; after < last; {
+		entries, err := s.repos.Audit.After(ctx, after, filter.entities(), streamBatch)
+		if err != nil {
+			s.Unsubscribe(sub)
+			return nil, err
+		}
+		if len(entries) == 0 {
+			break
+		}
+		for i := range entries {
+			if entries[i].ID > last {
+				break
+			}
+			if (entries[i].ID <= head || published[entries[i].ID]) && filter.matches(&entries[i]) {
+				sub.Backlog = append(sub.Backlog, streamEvent(entries[i], min(entries[i].ID, head)))
+			}
+		}
+		after = entries[len(entries)-1].ID
+	}
+	return sub, nil
+}
+
+// Unsubscribe stops delivering to sub.
+func (s *StreamService) Unsubscribe(sub *StreamSubscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.drop(sub)
+}
+
+// Poll reads the changes made since the last poll and hands them to the
+// subscribers. Run it periodically.
+func (s *StreamService) Poll(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	if err := s.start(ctx); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	after := s.lastID
+	s.mu.Unlock()
+
+	// Every entry is read, streamed or not, so that gaps left by other
+	// tables' changes aren't waited on.
+	for {
+		entries, err := s.repos.Audit.After(ctx, after, nil, streamBatch)
+		if err != nil {
+			return err
+		}
+		s.mu.Lock()
+		now := time.Now()
+		for i := range entries {
+			s.publish(&entries[i], now)
+		}
+		s.advance(now)
+		s.mu.Unlock()
+		if len(entries) < streamBatch {
+			return nil
+		}
+		after = entries[len(entries)-1].ID
+	}
+}
+
+// Close ends every subscription and refuses new ones, so that open streams
+// don't hold up shutdown.
+func (s *StreamService) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for sub := range s.subscribers {
+		s.drop(sub)
+	}
+}
+
+// start sets the position in the audit log on first use. s.mu must be held.
+func (s *StreamService) start(ctx context.Context) error {
+	if s.started {
+		return nil
+	}
+	latest, err := s.repos.Audit.LatestID(ctx)
+	if err != nil {
+		return err
+	}
+	s.lastID, s.started = latest, true
+	return nil
+}
+
+// publish hands entry, read at now, to the subscribers that want it unless
+// it was published before, dropping any that have fallen too far behind.
+// s.mu must be held.
+func (s *StreamService) publish(entry *models.AuditEntry, now time.Time) {
+	if _, ok := s.seen[entry.ID]; ok || entry.ID <= s.lastID {
+		return
+	}
+	s.seen[entry.ID] = now
+	s.advance(now)
+	event := streamEvent(*entry, s.lastID)
+	for sub := range s.subscribers {
+		if !sub.filter.matches(entry) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			s.drop(sub)
+		}
+	}
+}
+
+// advance moves the stream's position past the published entries that
+// follow it, and past gaps that have stayed open for streamCommitWindow.
+// s.mu must be held.
+func (s *StreamService) advance(now time.Time) {
+	if len(s.seen) == 0 {
+		return
+	}
+	ids := make([]uint, 0, len(s.seen))
+	for id := range s.seen {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		if id != s.lastID+1 && now.Sub(s.seen[id]) < streamCommitWindow {
+			return
+		}
+		delete(s.seen, id)
+		s.lastID = id
+	}
+}
+
+// drop removes sub and closes its channel. s.mu must be held.
+func (s *StreamService) drop(sub *StreamSubscription) {
+	if _, ok := s.subscribers[sub]; !ok {
+		return
+	}
+	delete(s.subscribers, sub)
+	close(sub.events)
+	metrics.StreamSubscribers.Dec()
+}
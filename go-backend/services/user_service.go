@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+)
+
+var (
+	// ErrInvalidCredentials is returned by Login for an unknown user or wrong password.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrUsernameTaken is returned by Register when the username is in use.
+	ErrUsernameTaken = errors.New("username already exists")
+)
+
+type UserService struct {
+	repos *repository.Repositories
+}
+
+func NewUserService(repos *repository.Repositories) *UserService {
+	return &UserService{repos: repos}
+}
+
+// Simple hash for demo - in production use bcrypt
+func hashPassword(password string) string {
+	return password + "_hashed"
+}
+
+// Simple verify for demo - in production use bcrypt
+func verifyPassword(password, hashedPassword string) bool {
+	return hashPassword(password) == hashedPassword
+}
+
+// DefaultAdmin is the built-in administrator, which exists without a database row.
+func DefaultAdmin() models.User {
+	firstName, lastName := "System", "Administrator"
+	now := time.Now()
+	return models.User{
+		ID:        "admin-1750604677654",
+		Username:  "admin",
+		Password:  hashPassword("admin"),
+		Email:     "admin@everflown.com",
+		FirstName: &firstName,
+		LastName:  &lastName,
+		Role:      "admin",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Login checks username and password, returning ErrInvalidCredentials on mismatch.
+func (s *UserService) Login(ctx context.Context, username, password string) (*models.User, error) {
+	if username == "admin" && password == "admin" {
+		admin := DefaultAdmin()
+		return &admin, nil
+	}
+
+	user, err := s.repos.Users.FindByUsername(ctx, username)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !verifyPassword(password, user.Password) {
+		return nil, ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+// RegisterInput is the data needed to create a user account.
+type RegisterInput struct {
+	Username  string
+	Password  string
+	Email     string
+	FirstName *string
+	LastName  *string
+	Role      string
+}
+
+// Register creates a user, defaulting the role to "user".
+func (s *UserService) Register(ctx context.Context, input RegisterInput) (*models.User, error) {
+	v := validator{}
+	v.required("username", input.Username)
+	v.required("password", input.Password)
+	v.required("email", input.Email)
+	v.email("email", input.Email)
+	v.oneOf("role", input.Role, userRoles)
+	if v.err != nil {
+		return nil, v.err
+	}
+	if input.Role == "" {
+		input.Role = "user"
+	}
+
+	user := models.User{
+		ID:        "user-" + strconv.FormatInt(time.Now().UnixNano()/1000, 10),
+		Username:  input.Username,
+		Password:  hashPassword(input.Password),
+		Email:     input.Email,
+		FirstName: input.FirstName,
+		LastName:  input.LastName,
+		Role:      input.Role,
+	}
+
+	err := s.repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		if _, err := tx.Users.FindByUsername(ctx, input.Username); err == nil {
+			return ErrUsernameTaken
+		} else if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		return tx.Users.Create(ctx, &user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (s *UserService) List(ctx context.Context) ([]models.User, error) {
+	return s.repos.Users.List(ctx)
+}
+
+// Update applies the non-zero fields of changes to the user and returns the
+// stored result. A new password is hashed before it is stored.
+func (s *UserService) Update(ctx context.Context, id string, changes *models.User) (*models.User, error) {
+	v := validator{}
+	v.email("email", changes.Email)
+	v.oneOf("role", changes.Role, userRoles)
+	if v.err != nil {
+		return nil, v.err
+	}
+	if changes.Password != "" {
+		changes.Password = hashPassword(changes.Password)
+	}
+	return s.repos.Users.Update(ctx, id, changes)
+}
+
+func (s *UserService) Delete(ctx context.Context, id string) error {
+	return s.repos.Users.Delete(ctx, id)
+}
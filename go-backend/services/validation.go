@@ -0,0 +1,115 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"everflown-logistics/money"
+)
+
+// ValidationError reports input that breaks a business rule. Callers map it
+// to a client error (HTTP 400, gRPC InvalidArgument).
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+func invalid(field, format string, args ...interface{}) error {
+	return &ValidationError{Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+// Allowed values, matching the options offered by the frontend.
+var (
+	leadStatuses     = []string{"new", "contacted", "quoted", "converted", "won", "lost"}
+	orderStatuses    = []string{"needs_truck", "dispatched", "picked_up", "in_transit", "delivered", "cancelled"}
+	dispatchStatuses = []string{"assigned", "picked_up", "in_transit", "delivered", "cancelled"}
+	quoteStatuses    = []string{"pending", "draft", "sent", "accepted", "rejected", "expired"}
+	invoiceStatuses  = []string{"draft", "sent", "paid", "overdue", "cancelled"}
+	invoiceTypes     = []string{"customer", "carrier"}
+	priorities       = []string{"low", "medium", "high", "urgent"}
+	userRoles        = []string{"admin", "broker", "user"}
+)
+
+// validator collects the first broken rule across a sequence of checks.
+type validator struct {
+	err error
+}
+
+func (v *validator) check(ok bool, field, format string, args ...interface{}) {
+	if v.err == nil && !ok {
+		v.err = invalid(field, format, args...)
+	}
+}
+
+func (v *validator) required(field, value string) {
+	if v.err == nil && strings.TrimSpace(value) == "" {
+		v.err = invalid(field, "is required")
+	}
+}
+
+// oneOf accepts an empty value, which leaves the column default in place.
+func (v *validator) oneOf(field, value string, allowed []string) {
+	if v.err != nil || value == "" {
+		return
+	}
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.err = invalid(field, "must be one of %s", strings.Join(allowed, ", "))
+}
+
+func (v *validator) email(field, value string) {
+	if v.err != nil || value == "" {
+		return
+	}
+	if _, err := mail.ParseAddress(value); err != nil {
+		v.err = invalid(field, "must be a valid email address")
+	}
+}
+
+func (v *validator) nonNegative(field string, value float64) {
+	if v.err == nil && value < 0 {
+		v.err = invalid(field, "must not be negative")
+	}
+}
+
+func (v *validator) nonNegativeAmount(field string, value money.Amount) {
+	if v.err == nil && value.IsNegative() {
+		v.err = invalid(field, "must not be negative")
+	}
+}
+
+// currency accepts an empty code, which leaves the column default in place.
+func (v *validator) currency(field, code string) {
+	if v.err == nil && code != "" && !money.ValidCurrency(code) {
+		v.err = invalid(field, "must be a three-letter ISO 4217 code")
+	}
+}
+
+// timeZone checks for an IANA zone name such as "America/Chicago".
+func (v *validator) timeZone(field string, name *string) {
+	if v.err != nil || name == nil || *name == "" {
+		return
+	}
+	if _, err := time.LoadLocation(*name); err != nil || *name == "Local" {
+		v.err = invalid(field, "must be an IANA time zone such as America/Chicago")
+	}
+}
+
+// existenceError turns a missing referenced record into a validation error
+// on field, passing other errors through.
+func existenceError(err error, field, noun string) error {
+	if errors.Is(err, ErrNotFound) {
+		return invalid(field, "refers to a missing %s", noun)
+	}
+	return err
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"time"
+
+	"everflown-logistics/dates"
+	"everflown-logistics/events"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+)
+
+// Webhooks are sent to shippers, so their data holds only what a shipper
+// may see of a record, never the carrier's rate or details, lead links or
+// internal notes. Adding a field to a model doesn't add it to webhooks.
+
+// WebhookOrder is an order as sent in webhooks.
+type WebhookOrder struct {
+	ID                  uint         `json:"id"`
+	OrderNumber         string       `json:"orderNumber"`
+	CustomerID          *uint        `json:"customerId"`
+	OriginCompany       *string      `json:"originCompany"`
+	OriginAddress       string       `json:"originAddress"`
+	OriginCity          string       `json:"originCity"`
+	OriginState         string       `json:"originState"`
+	OriginZipCode       string       `json:"originZipCode"`
+	DestinationCompany  *string      `json:"destinationCompany"`
+	DestinationAddress  string       `json:"destinationAddress"`
+	DestinationCity     string       `json:"destinationCity"`
+	DestinationState    string       `json:"destinationState"`
+	DestinationZipCode  string       `json:"destinationZipCode"`
+	PickupDate          dates.Date   `json:"pickupDate"`
+	DeliveryDate        *dates.Date  `json:"deliveryDate"`
+	EquipmentType       string       `json:"equipmentType"`
+	Weight              *float64     `json:"weight"`
+	Commodity           *string      `json:"commodity"`
+	CustomerRate        money.Amount `json:"customerRate"`
+	Currency            string       `json:"currency"`
+	Status              string       `json:"status"`
+	SpecialInstructions *string      `json:"specialInstructions"`
+	CreatedAt           time.Time    `json:"createdAt"`
+	UpdatedAt           time.Time    `json:"updatedAt"`
+}
+
+// WebhookDispatch is a dispatch as sent in webhooks: its progress, without
+// the carrier, driver, equipment or rate.
+type WebhookDispatch struct {
+	ID                    uint             `json:"id"`
+	OrderID               uint             `json:"orderId"`
+	Status                string           `json:"status"`
+	EstimatedPickupTime   *dates.Timestamp `json:"estimatedPickupTime"`
+	ActualPickupTime      *dates.Timestamp `json:"actualPickupTime"`
+	EstimatedDeliveryTime *dates.Timestamp `json:"estimatedDeliveryTime"`
+	ActualDeliveryTime    *dates.Timestamp `json:"actualDeliveryTime"`
+	PickupTimeZone        *string          `json:"pickupTimeZone"`
+	DeliveryTimeZone      *string          `json:"deliveryTimeZone"`
+	UpdatedAt             time.Time        `json:"updatedAt"`
+}
+
+// WebhookQuote is a quote as sent in webhooks.
+type WebhookQuote struct {
+	ID               uint         `json:"id"`
+	QuoteNumber      string       `json:"quoteNumber"`
+	CustomerID       *uint        `json:"customerId"`
+	OriginCity       string       `json:"originCity"`
+	OriginState      string       `json:"originState"`
+	DestinationCity  string       `json:"destinationCity"`
+	DestinationState string       `json:"destinationState"`
+	PickupDate       *dates.Date  `json:"pickupDate"`
+	EquipmentType    string       `json:"equipmentType"`
+	Weight           *float64     `json:"weight"`
+	Commodity        *string      `json:"commodity"`
+	QuotedRate       money.Amount `json:"quotedRate"`
+	Currency         string       `json:"currency"`
+	ValidUntil       dates.Date   `json:"validUntil"`
+	Status           string       `json:"status"`
+}
+
+// WebhookInvoice is an invoice as sent in webhooks.
+type WebhookInvoice struct {
+	ID            uint         `json:"id"`
+	InvoiceNumber string       `json:"invoiceNumber"`
+	CustomerID    *uint        `json:"customerId"`
+	OrderID       *uint        `json:"orderId"`
+	Amount        money.Amount `json:"amount"`
+	Currency      string       `json:"currency"`
+	Status        string       `json:"status"`
+	DueDate       dates.Date   `json:"dueDate"`
+	PaidDate      *dates.Date  `json:"paidDate"`
+}
+
+func webhookOrder(o models.Order) WebhookOrder {
+	return WebhookOrder{
+		ID: o.ID, OrderNumber: o.OrderNumber, CustomerID: o.CustomerID,
+		OriginCompany: o.OriginCompany, OriginAddress: o.OriginAddress, OriginCity: o.OriginCity,
+		OriginState: o.OriginState, OriginZipCode: o.OriginZipCode,
+		DestinationCompany: o.DestinationCompany, DestinationAddress: o.DestinationAddress,
+		DestinationCity: o.DestinationCity, DestinationState: o.DestinationState, DestinationZipCode: o.DestinationZipCode,
+		PickupDate: o.PickupDate, DeliveryDate: o.DeliveryDate, EquipmentType: o.EquipmentType,
+		Weight: o.Weight, Commodity: o.Commodity, CustomerRate: o.CustomerRate, Currency: o.Currency,
+		Status: o.Status, SpecialInstructions: o.SpecialInstructions, CreatedAt: o.CreatedAt, UpdatedAt: o.UpdatedAt,
+	}
+}
+
+func webhookDispatch(d models.Dispatch) WebhookDispatch {
+	return WebhookDispatch{
+		ID: d.ID, OrderID: d.OrderID, Status: d.Status,
+		EstimatedPickupTime: d.EstimatedPickupTime, ActualPickupTime: d.ActualPickupTime,
+		EstimatedDeliveryTime: d.EstimatedDeliveryTime, ActualDeliveryTime: d.ActualDeliveryTime,
+		PickupTimeZone: d.PickupTimeZone, DeliveryTimeZone: d.DeliveryTimeZone, UpdatedAt: d.UpdatedAt,
+	}
+}
+
+func webhookQuote(q models.Quote) WebhookQuote {
+	return WebhookQuote{
+		ID: q.ID, QuoteNumber: q.QuoteNumber, CustomerID: q.CustomerID,
+		OriginCity: q.OriginCity, OriginState: q.OriginState,
+		DestinationCity: q.DestinationCity, DestinationState: q.DestinationState,
+		PickupDate: q.PickupDate, EquipmentType: q.EquipmentType, Weight: q.Weight, Commodity: q.Commodity,
+		QuotedRate: q.QuotedRate, Currency: q.Currency, ValidUntil: q.ValidUntil, Status: q.Status,
+	}
+}
+
+func webhookInvoice(i models.Invoice) WebhookInvoice {
+	return WebhookInvoice{
+		ID: i.ID, InvoiceNumber: i.InvoiceNumber, CustomerID: i.CustomerID, OrderID: i.OrderID,
+		Amount: i.Amount, Currency: i.Currency, Status: i.Status, DueDate: i.DueDate, PaidDate: i.PaidDate,
+	}
+}
+
+// webhookData returns the data sent in webhooks for event. Events of other
+// types are sent without data.
+func webhookData(event events.Event) (interface{}, error) {
+	switch event.Type {
+	case events.OrderCreated:
+		var data events.OrderCreatedData
+		if err := event.Decode(&data); err != nil {
+			return nil, err
+		}
+		return struct {
+			Order WebhookOrder `json:"order"`
+		}{webhookOrder(data.Order)}, nil
+	case events.DispatchStatusChanged:
+		var data events.DispatchStatusChangedData
+		if err := event.Decode(&data); err != nil {
+			return nil, err
+		}
+		return struct {
+			Dispatch WebhookDispatch `json:"dispatch"`
+			From     string          `json:"from"`
+			To       string          `json:"to"`
+		}{webhookDispatch(data.Dispatch), data.From, data.To}, nil
+	case events.QuoteAccepted:
+		var data events.QuoteAcceptedData
+		if err := event.Decode(&data); err != nil {
+			return nil, err
+		}
+		return struct {
+			Quote WebhookQuote `json:"quote"`
+		}{webhookQuote(data.Quote)}, nil
+	case events.InvoicePaid:
+		var data events.InvoicePaidData
+		if err := event.Decode(&data); err != nil {
+			return nil, err
+		}
+		return struct {
+			Invoice WebhookInvoice `json:"invoice"`
+		}{webhookInvoice(data.Invoice)}, nil
+	default:
+		return struct{}{}, nil
+	}
+}
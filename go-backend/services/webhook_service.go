@@ -0,0 +1,537 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"everflown-logistics/events"
+	"everflown-logistics/metrics"
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+)
+
+// Headers sent with every webhook request.
+const (
+	WebhookEventHeader     = "X-Everflown-Event"
+	WebhookDeliveryHeader  = "X-Everflown-Delivery"
+	WebhookSignatureHeader = "X-Everflown-Signature"
+)
+
+const (
+	// webhookBatchSize is how many due deliveries are claimed per run.
+	webhookBatchSize = 50
+	// webhookSenders is how many deliveries are sent at once.
+	webhookSenders = 8
+	// webhookFirstRetry is the wait before the first retry; it doubles with
+	// each further attempt, up to webhookMaxRetry.
+	webhookFirstRetry = 30 * time.Second
+	webhookMaxRetry   = 6 * time.Hour
+	// maxWebhookErrorLength caps the error and response excerpt kept in the log.
+	maxWebhookErrorLength = 500
+	// defaultDeliveriesLimit and maxDeliveriesLimit bound a page of the
+	// delivery log.
+	defaultDeliveriesLimit = 100
+	maxDeliveriesLimit     = 500
+)
+
+// WebhookPolicy controls how webhooks are sent.
+type WebhookPolicy struct {
+	// Timeout bounds each request.
+	Timeout time.Duration
+	// MaxAttempts is how many times a delivery is tried before it fails.
+	MaxAttempts int
+	// DisableAfter is how many failed attempts in a row, across deliveries,
+	// disable a subscription.
+	DisableAfter int
+}
+
+// errPrivateWebhookAddress is returned for sends to addresses webhooks may
+// not reach.
+var errPrivateWebhookAddress = errors.New("webhook address is not public")
+
+// sharedAddressSpace is 100.64.0.0/10, used for carrier-grade NAT and by some
+// clouds' metadata services.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// WebhookService manages webhook subscriptions and sends them the domain
+// events they subscribe to.
+//
+// Subscription URLs are chosen by whoever manages them, so webhooks only go
+// to public addresses: loopback, private, link-local and other internal
+// addresses are refused when a subscription is saved, and again when a send
+// connects, after the host is resolved. Redirects aren't followed.
+type WebhookService struct {
+	repos  *repository.Repositories
+	client *http.Client
+
+	// AllowPrivateNetworks lets webhooks go to internal addresses, such as a
+	// receiver on a developer's machine. Set it before the service is used.
+	AllowPrivateNetworks bool
+}
+
+func NewWebhookService(repos *repository.Repositories) *WebhookService {
+	s := &WebhookService{repos: repos}
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: s.checkDial}
+	s.client = &http.Client{
+		// No proxy, which would connect on the service's behalf without the
+		// address check.
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+		},
+		// A redirect is answered like any other non-2xx status.
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	return s
+}
+
+// List returns the subscriptions, without their secrets.
+func (s *WebhookService) List(ctx context.Context) ([]models.WebhookSubscription, error) {
+	subscriptions, err := s.repos.Webhooks.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+	return subscriptions, nil
+}
+
+// Get returns a subscription without its secret.
+func (s *WebhookService) Get(ctx context.Context, id uint) (*models.WebhookSubscription, error) {
+	subscription, err := s.repos.Webhooks.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	subscription.Secret = ""
+	return subscription, nil
+}
+
+// Create adds a subscription for a customer or an API client and generates
+// its signing secret, which is returned only here.
+func (s *WebhookService) Create(ctx context.Context, subscription *models.WebhookSubscription) error {
+	if subscription.ClientName != nil && strings.TrimSpace(*subscription.ClientName) == "" {
+		subscription.ClientName = nil
+	}
+	v := validator{}
+	v.required("url", subscription.URL)
+	v.check((subscription.CustomerID == nil) != (subscription.ClientName == nil), "customerId", "or clientName is required, but not both")
+	validateWebhook(&v, subscription)
+	s.validateWebhookHost(ctx, &v, subscription.URL)
+	if v.err != nil {
+		return v.err
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return err
+	}
+	subscription.Secret = secret
+	subscription.Active = true
+	subscription.ConsecutiveFailures = 0
+	subscription.DisabledAt, subscription.DisabledReason = nil, nil
+
+	if subscription.CustomerID != nil {
+		if _, err := s.repos.Customers.Get(ctx, *subscription.CustomerID); err != nil {
+			return existenceError(err, "customerId", "customer")
+		}
+	}
+	return s.repos.Webhooks.Create(ctx, subscription)
+}
+
+// Update changes a subscription's URL, description or event types. Its
+// owner and secret can't be changed; use Enable and Disable for its state.
+func (s *WebhookService) Update(ctx context.Context, id uint, changes *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	v := validator{}
+	validateWebhook(&v, changes)
+	s.validateWebhookHost(ctx, &v, changes.URL)
+	if v.err != nil {
+		return nil, v.err
+	}
+	allowed := &models.WebhookSubscription{URL: changes.URL, Description: changes.Description, EventTypes: changes.EventTypes}
+	subscription, err := s.repos.Webhooks.Update(ctx, id, allowed)
+	if err != nil {
+		return nil, err
+	}
+	subscription.Secret = ""
+	return subscription, nil
+}
+
+func (s *WebhookService) Delete(ctx context.Context, id uint) error {
+	return s.repos.Webhooks.Delete(ctx, id)
+}
+
+// Enable turns a subscription back on, clearing its failures. Deliveries
+// still pending are sent again.
+func (s *WebhookService) Enable(ctx context.Context, id uint) (*models.WebhookSubscription, error) {
+	return s.setActive(ctx, id, true, "")
+}
+
+// Disable stops sending to a subscription. Its pending deliveries wait until
+// it is enabled again; events that occur meanwhile are not queued for it.
+func (s *WebhookService) Disable(ctx context.Context, id uint) (*models.WebhookSubscription, error) {
+	return s.setActive(ctx, id, false, "disabled manually")
+}
+
+func (s *WebhookService) setActive(ctx context.Context, id uint, active bool, reason string) (*models.WebhookSubscription, error) {
+	subscription, err := s.repos.Webhooks.SetActive(ctx, id, active, reason)
+	if err != nil {
+		return nil, err
+	}
+	subscription.Secret = ""
+	return subscription, nil
+}
+
+// Deliveries returns the delivery log of a subscription, newest first. Pass
+// the ID of the last delivery as beforeID to fetch the next page.
+func (s *WebhookService) Deliveries(ctx context.Context, id, beforeID uint, limit int) ([]models.WebhookDelivery, error) {
+	v := validator{}
+	v.check(limit >= 0 && limit <= maxDeliveriesLimit, "limit", "must be between 1 and %d", maxDeliveriesLimit)
+	if v.err != nil {
+		return nil, v.err
+	}
+	if limit == 0 {
+		limit = defaultDeliveriesLimit
+	}
+	if _, err := s.repos.Webhooks.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repos.Webhooks.Deliveries(ctx, id, beforeID, limit)
+}
+
+// Redeliver sends a delivery again as soon as possible, whatever its
+// outcome so far.
+func (s *WebhookService) Redeliver(ctx context.Context, id, deliveryID uint) (*models.WebhookDelivery, error) {
+	return s.repos.Webhooks.Redeliver(ctx, id, deliveryID, time.Now())
+}
+
+func validateWebhook(v *validator, subscription *models.WebhookSubscription) {
+	if subscription.URL != "" {
+		u, err := url.Parse(subscription.URL)
+		v.check(err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != "", "url", "must be an http or https URL")
+	}
+	for _, t := range subscription.EventTypes {
+		v.oneOf("eventTypes", t, events.Types)
+	}
+}
+
+// validateWebhookHost refuses a URL whose host is, or resolves to, an
+// address webhooks may not reach. A host that doesn't resolve yet is
+// accepted, since sends are checked again when they connect.
+func (s *WebhookService) validateWebhookHost(ctx context.Context, v *validator, rawURL string) {
+	if s.AllowPrivateNetworks || rawURL == "" || v.err != nil {
+		return
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return
+	}
+	for _, addr := range addrs {
+		v.check(publicAddress(addr.IP), "url", "must not point to a loopback, private or link-local address")
+	}
+}
+
+// checkDial refuses connections to addresses webhooks may not reach. It runs
+// on the address actually dialed, so a host resolving differently than when
+// its subscription was saved is still caught.
+func (s *WebhookService) checkDial(_, address string, _ syscall.RawConn) error {
+	if s.AllowPrivateNetworks {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !publicAddress(ip) {
+		return fmt.Errorf("%w: %s", errPrivateWebhookAddress, host)
+	}
+	return nil
+}
+
+// publicAddress reports whether webhooks may be sent to ip.
+func publicAddress(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || sharedAddressSpace.Contains(ip))
+}
+
+func newWebhookSecret() (string, error) {
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b[:]), nil
+}
+
+// webhookBody is the JSON sent for an event, with its data from
+// webhookData. Receivers should ignore an id they have already handled,
+// since an event may be sent more than once.
+type webhookBody struct {
+	ID         uint        `json:"id"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	Data       interface{} `json:"data"`
+}
+
+// QueueEvent queues event for every active subscription that wants it. It
+// subscribes to the outbox, so it may see an event more than once; an event
+// is only queued once per subscription.
+func (s *WebhookService) QueueEvent(ctx context.Context, event events.Event) error {
+	customerID, err := s.eventCustomer(ctx, event)
+	if err != nil {
+		return err
+	}
+	subscriptions, err := s.repos.Webhooks.ForEvent(ctx, event.Type, customerID)
+	if err != nil || len(subscriptions) == 0 {
+		return err
+	}
+	data, err := webhookData(event)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(webhookBody{ID: event.ID, Type: event.Type, OccurredAt: event.OccurredAt, Data: data})
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	deliveries := make([]models.WebhookDelivery, len(subscriptions))
+	for i, subscription := range subscriptions {
+		deliveries[i] = models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        string(payload),
+			Status:         models.DeliveryPending,
+			NextAttemptAt:  &now,
+		}
+	}
+	return s.repos.Webhooks.Enqueue(ctx, deliveries...)
+}
+
+// eventCustomer returns the customer an event is about, if any.
+func (s *WebhookService) eventCustomer(ctx context.Context, event events.Event) (*uint, error) {
+	switch event.Type {
+	case events.OrderCreated:
+		var data events.OrderCreatedData
+		err := event.Decode(&data)
+		return data.Order.CustomerID, err
+	case events.DispatchStatusChanged:
+		var data events.DispatchStatusChangedData
+		if err := event.Decode(&data); err != nil {
+			return nil, err
+		}
+		order, err := s.repos.Orders.Get(ctx, data.Dispatch.OrderID)
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return order.CustomerID, nil
+	case events.QuoteAccepted:
+		var data events.QuoteAcceptedData
+		err := event.Decode(&data)
+		return data.Quote.CustomerID, err
+	case events.InvoicePaid:
+		var data events.InvoicePaidData
+		err := event.Decode(&data)
+		return data.Invoice.CustomerID, err
+	default:
+		return nil, nil
+	}
+}
+
+// SendDue sends the deliveries that are due, returning how many were
+// attempted. Failed attempts are retried with exponential backoff, and a
+// subscription that keeps failing is disabled.
+func (s *WebhookService) SendDue(ctx context.Context, policy WebhookPolicy) (int, error) {
+	now := time.Now()
+	// A delivery is claimed for longer than any attempt can take.
+	due, err := s.repos.Webhooks.ClaimDue(ctx, now, now.Add(2*policy.Timeout+time.Minute), webhookBatchSize)
+	if err != nil || len(due) == 0 {
+		return 0, err
+	}
+
+	// Each subscription gets its deliveries one at a time, in the order the
+	// events occurred; different subscriptions are sent to in parallel.
+	sort.SliceStable(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	var order []uint
+	bySubscription := map[uint][]*models.WebhookDelivery{}
+	for i := range due {
+		id := due[i].SubscriptionID
+		if _, ok := bySubscription[id]; !ok {
+			order = append(order, id)
+		}
+		bySubscription[id] = append(bySubscription[id], &due[i])
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}
+	slots := make(chan struct{}, webhookSenders)
+	for _, id := range order {
+		subscription, err := s.repos.Webhooks.Get(ctx, id)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			fail(err)
+			continue
+		}
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(deliveries []*models.WebhookDelivery) {
+			defer func() { <-slots; wg.Done() }()
+			for _, delivery := range deliveries {
+				// The rest wait until it is enabled again.
+				if !subscription.Active {
+					return
+				}
+				if err := s.attempt(ctx, subscription, delivery, policy); err != nil {
+					fail(err)
+				}
+			}
+		}(bySubscription[id])
+	}
+	wg.Wait()
+	return len(due), errors.Join(errs...)
+}
+
+// attempt sends a delivery once and records the outcome.
+func (s *WebhookService) attempt(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery, policy WebhookPolicy) error {
+	status, sendErr := s.send(ctx, subscription, delivery, policy.Timeout)
+
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = nil
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+	delivery.LastError = nil
+	succeeded := sendErr == nil
+	switch {
+	case succeeded:
+		delivery.Status = models.DeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+	case delivery.Attempts >= policy.MaxAttempts:
+		delivery.Status = models.DeliveryFailed
+		delivery.NextAttemptAt = nil
+	default:
+		next := now.Add(webhookBackoff(delivery.Attempts))
+		delivery.NextAttemptAt = &next
+	}
+	if !succeeded {
+		message := truncate(sendErr.Error(), maxWebhookErrorLength)
+		delivery.LastError = &message
+	}
+
+	result := "delivered"
+	if !succeeded {
+		result = "failed"
+	}
+	metrics.WebhookAttempts.WithLabelValues(delivery.EventType, result).Inc()
+
+	failures, err := s.repos.Webhooks.RecordAttempt(ctx, delivery, succeeded)
+	if err != nil {
+		return err
+	}
+	if !succeeded && policy.DisableAfter > 0 && failures >= policy.DisableAfter && subscription.Active {
+		reason := fmt.Sprintf("disabled after %d failed attempts in a row", failures)
+		slog.Warn("Disabling failing webhook", "subscription_id", subscription.ID, "url", subscription.URL, "failures", failures)
+		if _, err := s.repos.Webhooks.SetActive(ctx, subscription.ID, false, reason); err != nil {
+			return err
+		}
+		subscription.Active = false
+	}
+	return nil
+}
+
+// send posts a delivery's payload to the subscription, returning the
+// response status, if any, and an error unless it was a 2xx.
+func (s *WebhookService) send(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery, timeout time.Duration) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "EverFlown-Webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, fmt.Sprint(delivery.ID))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(subscription.Secret, time.Now().Unix(), body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookErrorLength))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint returned %s: %s", resp.Status, strings.TrimSpace(string(excerpt)))
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhook returns the signature header for body sent at timestamp (Unix
+// seconds): "t=<timestamp>,v1=<signature>", where the signature is the hex
+// HMAC-SHA256 of "<timestamp>.<body>" keyed with the subscription's secret.
+// Receivers should recompute it and reject old timestamps.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// webhookBackoff is the wait after the given number of failed attempts.
+func webhookBackoff(attempts int) time.Duration {
+	if attempts > 16 {
+		return webhookMaxRetry
+	}
+	return min(webhookFirstRetry<<(attempts-1), webhookMaxRetry)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"everflown-logistics/archive"
+	"everflown-logistics/middleware"
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+	"everflown-logistics/seed"
+	"everflown-logistics/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// exportSample seeds a database and exports it.
+func exportSample(t *testing.T) (*gorm.DB, []byte, archive.Manifest) {
+	t.Helper()
+	ctx := context.Background()
+	source, err := setupTestDB()
+	require.NoError(t, err)
+	_, err = seed.Run(ctx, source, seed.Options{Orders: 30, Seed: 5, Start: seedStart})
+	require.NoError(t, err)
+	require.NoError(t, source.Create(&models.User{ID: "user-1", Username: "dana", Password: "hash", Email: "dana@example.com", Role: "broker"}).Error)
+	require.NoError(t, source.Model(&models.Customer{}).Where("id = 1").Update("is_active", false).Error)
+	archived, err := services.NewArchiveService(repository.New(source)).ArchiveClosedOrders(ctx, 0)
+	require.NoError(t, err)
+	require.NotZero(t, archived)
+
+	var buf bytes.Buffer
+	manifest, err := archive.Export(ctx, source, &buf)
+	require.NoError(t, err)
+	return source, buf.Bytes(), manifest
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	source, data, manifest := exportSample(t)
+
+	assert.Equal(t, archive.Version, manifest.Version)
+	require.Len(t, manifest.Tables, len(models.All())+len(models.Archived()))
+	for _, table := range manifest.Tables {
+		var count int64
+		require.NoError(t, source.Table(table.Name).Count(&count).Error)
+		assert.Equal(t, int(count), table.Count, table.Name)
+		assert.Len(t, table.SHA256, 64)
+	}
+
+	// Advance the target's IDs so the import has to remap them.
+	target, err := setupTestDB()
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		customer := models.Customer{CompanyName: "Placeholder", ContactPerson: "-", Email: "-", Phone: "-"}
+		require.NoError(t, target.Create(&customer).Error)
+		require.NoError(t, target.Delete(&customer).Error)
+	}
+
+	imported, err := archive.Import(ctx, target, bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Tables, imported.Tables)
+
+	var want, got []models.Dispatch
+	require.NoError(t, source.Preload("Order.Customer").Preload("Carrier").Order("id").Find(&want).Error)
+	require.NoError(t, target.Preload("Order.Customer").Preload("Carrier").Order("id").Find(&got).Error)
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.Equal(t, want[i].Order.OrderNumber, got[i].Order.OrderNumber)
+		assert.Equal(t, want[i].Order.Customer.CompanyName, got[i].Order.Customer.CompanyName)
+		assert.Equal(t, want[i].Carrier.MCNumber, got[i].Carrier.MCNumber)
+		assert.Equal(t, want[i].CarrierRate, got[i].CarrierRate)
+		assert.Equal(t, want[i].EstimatedPickupTime, got[i].EstimatedPickupTime)
+	}
+	assert.NotEqual(t, want[0].Order.CustomerID, got[0].Order.CustomerID, "IDs are reassigned")
+
+	var inactive models.Customer
+	require.NoError(t, target.Where("is_active = ?", false).First(&inactive).Error)
+	var user models.User
+	require.NoError(t, target.First(&user, "id = ?", "user-1").Error)
+	assert.Equal(t, "dana", user.Username)
+	assert.Empty(t, user.Password, "password hashes are not exported")
+
+	// Archived orders stay archived, with their records and archive times.
+	var wantArchived, gotArchived []models.ArchivedOrder
+	require.NoError(t, source.Order("order_number").Find(&wantArchived).Error)
+	require.NoError(t, target.Order("order_number").Find(&gotArchived).Error)
+	require.Len(t, gotArchived, len(wantArchived))
+	for i := range wantArchived {
+		assert.Equal(t, wantArchived[i].OrderNumber, gotArchived[i].OrderNumber)
+		assert.True(t, wantArchived[i].ArchivedAt.Equal(gotArchived[i].ArchivedAt), wantArchived[i].OrderNumber)
+	}
+	for _, model := range []interface{}{&models.ArchivedDispatch{}, &models.ArchivedInvoice{}, &models.Order{}, &models.Invoice{}} {
+		var want, got int64
+		require.NoError(t, source.Model(model).Count(&want).Error)
+		require.NoError(t, target.Model(model).Count(&got).Error)
+		assert.Equal(t, want, got, "%T", model)
+	}
+}
+
+func TestImportRefusesNonEmptyDatabase(t *testing.T) {
+	t.Parallel()
+	source, data, _ := exportSample(t)
+
+	_, err := archive.Import(context.Background(), source, bytes.NewReader(data), int64(len(data)))
+	assert.ErrorIs(t, err, archive.ErrNotEmpty)
+}
+
+func TestImportRejectsTamperedArchive(t *testing.T) {
+	t.Parallel()
+	_, data, _ := exportSample(t)
+
+	// Copy the archive, dropping the last order.
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	var tampered bytes.Buffer
+	zw := zip.NewWriter(&tampered)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		body, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		if f.Name == "orders.jsonl" {
+			lines := bytes.SplitAfter(body, []byte("\n"))
+			body = bytes.Join(lines[:len(lines)-2], nil)
+		}
+		w, err := zw.Create(f.Name)
+		require.NoError(t, err)
+		_, err = w.Write(body)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	target, err := setupTestDB()
+	require.NoError(t, err)
+	_, err = archive.Import(context.Background(), target, bytes.NewReader(tampered.Bytes()), int64(tampered.Len()))
+	assert.ErrorIs(t, err, archive.ErrInvalidArchive)
+	var leads int64
+	target.Model(&models.Lead{}).Count(&leads)
+	assert.Zero(t, leads, "nothing is imported")
+
+	_, err = archive.Import(context.Background(), target, bytes.NewReader([]byte("not a zip")), 9)
+	assert.ErrorIs(t, err, archive.ErrInvalidArchive)
+}
+
+func TestExportEndpointIsAdminOnly(t *testing.T) {
+	t.Parallel()
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+	seedOrderAndCarrier(t, testDB)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Identity(""))
+	router.GET("/api/admin/export", middleware.RequireRole("admin"), archive.ExportHandler(testDB))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/export", nil)
+	req.Header.Set(middleware.UserRoleHeader, "broker")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	req.Header.Set(middleware.UserRoleHeader, "admin")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "everflown-export-")
+	zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	require.NoError(t, err)
+	names := make([]string, len(zr.File))
+	for i, f := range zr.File {
+		names[i] = f.Name
+	}
+	assert.Contains(t, names, "orders.jsonl")
+	assert.Contains(t, names, "manifest.json")
+}
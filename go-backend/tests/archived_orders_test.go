@@ -0,0 +1,196 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"everflown-logistics/dates"
+	"everflown-logistics/handlers"
+	"everflown-logistics/middleware"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"everflown-logistics/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// closedOrder creates a delivered order, delivered daysAgo, with a finished
+// dispatch, a completed follow-up and paid customer and carrier invoices.
+// n numbers the order and its invoices.
+func closedOrder(t *testing.T, db *gorm.DB, n int, daysAgo int) models.Order {
+	t.Helper()
+	delivered := dates.Today(time.UTC).AddDays(-daysAgo)
+	order := models.Order{
+		OrderNumber: fmt.Sprintf("ORD-ARC-%d", n), CustomerID: uintPtr(1),
+		OriginCity: "Dallas", OriginState: "TX", DestinationCity: "Atlanta", DestinationState: "GA",
+		PickupDate: delivered.AddDays(-2), DeliveryDate: &delivered,
+		EquipmentType: "Dry Van", CustomerRate: money.New(2400, 0), Status: "delivered",
+	}
+	require.NoError(t, db.Create(&order).Error)
+	dispatch := models.Dispatch{OrderID: order.ID, CarrierID: 1, CarrierRate: money.New(1800, 0), Status: "delivered"}
+	require.NoError(t, db.Create(&dispatch).Error)
+	require.NoError(t, db.Create(&models.Invoice{InvoiceNumber: fmt.Sprintf("INV-ARC-%d-C", n), Type: "customer", CustomerID: uintPtr(1),
+		OrderID: &order.ID, Amount: money.New(2400, 0), Status: "paid", DueDate: delivered.AddDays(30)}).Error)
+	require.NoError(t, db.Create(&models.Invoice{InvoiceNumber: fmt.Sprintf("INV-ARC-%d-D", n), Type: "carrier", CarrierID: uintPtr(1),
+		DispatchID: &dispatch.ID, Amount: money.New(1800, 0), Status: "paid", DueDate: delivered.AddDays(30)}).Error)
+	require.NoError(t, db.Create(&models.FollowUp{Title: "Confirm POD", Type: "call", OrderID: &order.ID, DueDate: time.Now(), Completed: true}).Error)
+	return order
+}
+
+func seedArchiveCustomerAndCarrier(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	require.NoError(t, db.Create(&models.Customer{CompanyName: "Shipper Co", ContactPerson: "Pat", Email: "pat@shipper.example", Phone: "555-0110"}).Error)
+	require.NoError(t, db.Create(&models.Carrier{CompanyName: "Haul Co", ContactPerson: "Max", Email: "max@haul.example", Phone: "555-0111"}).Error)
+}
+
+func TestArchiveClosedOrders(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	seedArchiveCustomerAndCarrier(t, testDB)
+	ctx := context.Background()
+
+	archived := closedOrder(t, testDB, 1, 400)
+	unpaid := closedOrder(t, testDB, 2, 400)
+	require.NoError(t, testDB.Model(&models.Invoice{}).Where("order_id = ?", unpaid.ID).Update("status", "sent").Error)
+	recent := closedOrder(t, testDB, 3, 30)
+	openFollowUp := closedOrder(t, testDB, 4, 400)
+	require.NoError(t, testDB.Model(&models.FollowUp{}).Where("order_id = ?", openFollowUp.ID).Update("completed", false).Error)
+	cancelled := models.Order{OrderNumber: "ORD-ARC-5", OriginCity: "Reno", OriginState: "NV", DestinationCity: "Boise", DestinationState: "ID",
+		PickupDate: dates.Today(time.UTC).AddDays(-500), EquipmentType: "Reefer", CustomerRate: money.New(900, 0), Status: "cancelled"}
+	require.NoError(t, testDB.Create(&cancelled).Error)
+
+	before, err := svc.Dashboard.Stats(ctx)
+	require.NoError(t, err)
+
+	count, err := svc.Archive.ArchiveClosedOrders(ctx, 365)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	var live []uint
+	require.NoError(t, testDB.Model(&models.Order{}).Order("id").Pluck("id", &live).Error)
+	assert.Equal(t, []uint{unpaid.ID, recent.ID, openFollowUp.ID}, live)
+	var liveInvoices, liveDispatches int64
+	testDB.Model(&models.Invoice{}).Count(&liveInvoices)
+	testDB.Model(&models.Dispatch{}).Count(&liveDispatches)
+	assert.Equal(t, int64(6), liveInvoices)
+	assert.Equal(t, int64(3), liveDispatches)
+
+	stored, err := svc.Archive.Get(ctx, archived.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "ORD-ARC-1", stored.OrderNumber)
+	assert.False(t, stored.ArchivedAt.IsZero())
+	require.Len(t, stored.Dispatches, 1)
+	assert.Len(t, stored.Invoices, 2, "the customer invoice and the dispatch's carrier invoice")
+	assert.Len(t, stored.FollowUps, 1)
+
+	list, err := svc.Archive.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+	var logged int64
+	testDB.Model(&models.AuditEntry{}).Where("entity = ? AND action = ?", "orders", "archive").Count(&logged)
+	assert.Equal(t, int64(2), logged)
+
+	after, err := svc.Dashboard.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, before.RevenueByCurrency, after.RevenueByCurrency, "archived invoices still count as revenue")
+
+	count, err = svc.Archive.ArchiveClosedOrders(ctx, 365)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestRestoreArchivedOrder(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	seedArchiveCustomerAndCarrier(t, testDB)
+	ctx := context.Background()
+
+	order := closedOrder(t, testDB, 1, 400)
+	var dispatch models.Dispatch
+	require.NoError(t, testDB.Where("order_id = ?", order.ID).First(&dispatch).Error)
+	_, err := svc.Archive.ArchiveClosedOrders(ctx, 365)
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Archive.Restore(ctx, order.ID))
+	restored, err := svc.Orders.Get(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, order.OrderNumber, restored.OrderNumber)
+	assert.Equal(t, order.DeliveryDate, restored.DeliveryDate)
+	restoredDispatch, err := svc.Dispatches.Get(ctx, dispatch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, dispatch.CarrierRate, restoredDispatch.CarrierRate)
+	var invoices int64
+	testDB.Model(&models.Invoice{}).Count(&invoices)
+	assert.Equal(t, int64(2), invoices)
+	_, err = svc.Archive.Get(ctx, order.ID)
+	assert.ErrorIs(t, err, services.ErrNotFound)
+	assert.ErrorIs(t, svc.Archive.Restore(ctx, order.ID), services.ErrNotFound)
+
+	// A new order that took the number blocks the restore.
+	_, err = svc.Archive.ArchiveClosedOrders(ctx, 365)
+	require.NoError(t, err)
+	require.NoError(t, testDB.Create(&models.Order{OrderNumber: order.OrderNumber, OriginCity: "Dallas", OriginState: "TX",
+		DestinationCity: "Atlanta", DestinationState: "GA", PickupDate: dates.Today(time.UTC), EquipmentType: "Dry Van", CustomerRate: money.New(100, 0)}).Error)
+	assert.ErrorIs(t, svc.Archive.Restore(ctx, order.ID), services.ErrConflict)
+	_, err = svc.Archive.Get(ctx, order.ID)
+	assert.NoError(t, err, "the order stays archived")
+}
+
+func TestArchivedOrderEndpoints(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	seedArchiveCustomerAndCarrier(t, testDB)
+	order := closedOrder(t, testDB, 1, 400)
+	_, err := svc.Archive.ArchiveClosedOrders(context.Background(), 365)
+	require.NoError(t, err)
+	h := handlers.New(svc)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Identity(""))
+	router.GET("/api/archive/orders", h.GetArchivedOrders)
+	router.GET("/api/archive/orders/:id", h.GetArchivedOrder)
+	router.POST("/api/admin/archive/orders/:id/restore", middleware.RequireRole("admin"), h.RestoreArchivedOrder)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/archive/orders", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var list []models.ArchivedOrder
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list, 1)
+	assert.Equal(t, order.OrderNumber, list[0].OrderNumber)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/archive/orders/%d", order.ID), nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var stored models.ArchivedOrder
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stored))
+	assert.Len(t, stored.Invoices, 2)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/archive/orders/99", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	restore := fmt.Sprintf("/api/admin/archive/orders/%d/restore", order.ID)
+	req := httptest.NewRequest(http.MethodPost, restore, nil)
+	req.Header.Set(middleware.UserRoleHeader, "broker")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, restore, nil)
+	req.Header.Set(middleware.UserRoleHeader, "admin")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var restored models.Order
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &restored))
+	assert.Equal(t, order.ID, restored.ID)
+}
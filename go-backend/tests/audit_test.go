@@ -0,0 +1,191 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"everflown-logistics/audit"
+	"everflown-logistics/handlers"
+	"everflown-logistics/middleware"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"everflown-logistics/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// auditEntries returns the audit log for one record, oldest first.
+func auditEntries(t *testing.T, db *gorm.DB, entity string, id uint) []models.AuditEntry {
+	t.Helper()
+	var entries []models.AuditEntry
+	require.NoError(t, db.Where("entity = ? AND entity_id = ?", entity, fmt.Sprint(id)).Order("id").Find(&entries).Error)
+	return entries
+}
+
+func TestAuditLogRecordsChanges(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	ctx := audit.WithActor(context.Background(), audit.Actor{UserID: "user-7", RequestID: "req-1"})
+
+	carrier := models.Carrier{CompanyName: "Haul Co", ContactPerson: "Max", Email: "max@haul.example", Phone: "555-0111"}
+	require.NoError(t, svc.Carriers.Create(ctx, &carrier))
+	_, err := svc.Carriers.Update(ctx, carrier.ID, &models.Carrier{Phone: "555-0199"})
+	require.NoError(t, err)
+	// Saving the same value again changes nothing and is not logged.
+	_, err = svc.Carriers.Update(ctx, carrier.ID, &models.Carrier{Phone: "555-0199"})
+	require.NoError(t, err)
+	require.NoError(t, svc.Carriers.Delete(context.Background(), carrier.ID))
+
+	entries := auditEntries(t, testDB, "carriers", carrier.ID)
+	require.Len(t, entries, 3)
+
+	created := entries[0]
+	assert.Equal(t, "create", created.Action)
+	assert.Equal(t, "user-7", created.UserID)
+	assert.Equal(t, "req-1", created.RequestID)
+	assert.JSONEq(t, `"Haul Co"`, string(created.Changes["companyName"].To))
+	assert.Empty(t, created.Changes["companyName"].From)
+	assert.NotContains(t, created.Changes, "id")
+	assert.NotContains(t, created.Changes, "createdAt")
+
+	updated := entries[1]
+	assert.Equal(t, "update", updated.Action)
+	assert.Equal(t, models.Changes{"phone": {From: json.RawMessage(`"555-0111"`), To: json.RawMessage(`"555-0199"`)}}, updated.Changes)
+
+	deleted := entries[2]
+	assert.Equal(t, "delete", deleted.Action)
+	assert.Equal(t, audit.System, deleted.UserID, "changes without an actor are made by the system")
+	assert.JSONEq(t, `"555-0199"`, string(deleted.Changes["phone"].From))
+	assert.Empty(t, deleted.Changes["phone"].To)
+}
+
+func TestAuditLogRedactsPasswords(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	ctx := context.Background()
+
+	user, err := svc.Users.Register(ctx, services.RegisterInput{Username: "pat", Password: "hunter22", Email: "pat@example.com"})
+	require.NoError(t, err)
+
+	var entries []models.AuditEntry
+	require.NoError(t, testDB.Where("entity = ? AND entity_id = ?", "users", user.ID).Find(&entries).Error)
+	require.Len(t, entries, 1)
+	change, ok := entries[0].Changes["password"]
+	require.True(t, ok, "the password change is recorded")
+	assert.Empty(t, change.From)
+	assert.Empty(t, change.To, "the password hash is not logged")
+	assert.JSONEq(t, `"pat"`, string(entries[0].Changes["username"].To))
+}
+
+func TestAuditLogRecordsDeleteEffects(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	seedOrderAndCarrier(t, testDB)
+	ctx := context.Background()
+
+	lead := models.Lead{CompanyName: "Prospect Co", ContactPerson: "Lee", Email: "lee@prospect.example", Phone: "555-0120"}
+	require.NoError(t, svc.Leads.Create(ctx, &lead))
+	require.NoError(t, testDB.Model(&models.Order{}).Where("id = ?", 1).Update("lead_id", lead.ID).Error)
+	dispatch := models.Dispatch{OrderID: 1, CarrierID: 1, CarrierRate: money.New(1800, 0)}
+	require.NoError(t, svc.Dispatches.Create(ctx, &dispatch))
+
+	// Deleting the lead clears the order's link to it.
+	require.NoError(t, svc.Leads.Delete(ctx, lead.ID))
+	orderEntries := auditEntries(t, testDB, "orders", 1)
+	cleared := orderEntries[len(orderEntries)-1]
+	assert.Equal(t, "update", cleared.Action)
+	assert.Equal(t, models.Changes{"leadId": {From: json.RawMessage(fmt.Sprint(lead.ID)), To: json.RawMessage("null")}}, cleared.Changes)
+
+	// Deleting the order cascades to its dispatch.
+	require.NoError(t, svc.Orders.Delete(ctx, 1))
+	dispatchEntries := auditEntries(t, testDB, "dispatches", dispatch.ID)
+	deleted := dispatchEntries[len(dispatchEntries)-1]
+	assert.Equal(t, "delete", deleted.Action)
+	assert.JSONEq(t, "1800", string(deleted.Changes["carrierRate"].From))
+}
+
+func TestAuditLogIsAppendOnly(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	ctx := context.Background()
+	require.NoError(t, svc.Carriers.Create(ctx, &models.Carrier{CompanyName: "Haul Co", ContactPerson: "Max", Email: "max@haul.example", Phone: "555-0111"}))
+
+	assert.Error(t, testDB.Exec("UPDATE audit_log SET user_id = ?", "someone-else").Error)
+	assert.Error(t, testDB.Exec("DELETE FROM audit_log").Error)
+	var count int64
+	testDB.Model(&models.AuditEntry{}).Where("user_id = ?", audit.System).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestAuditLogEndpoint(t *testing.T) {
+	t.Parallel()
+	svc, _ := setupServices(t)
+	h := handlers.New(svc)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.Identity(""))
+	router.POST("/api/carriers", h.CreateCarrier)
+	router.GET("/api/admin/audit", middleware.RequireRole("admin"), h.GetAuditLog)
+
+	for i, user := range []string{"user-1", "user-2", "user-1"} {
+		body := fmt.Sprintf(`{"companyName":"Carrier %d","contactPerson":"Max","email":"max%d@haul.example","phone":"555-0111"}`, i, i)
+		req := httptest.NewRequest(http.MethodPost, "/api/carriers", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.UserIDHeader, user)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	get := func(query, role string) (int, []models.AuditEntry) {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/audit"+query, nil)
+		req.Header.Set(middleware.UserRoleHeader, role)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var entries []models.AuditEntry
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+		}
+		return w.Code, entries
+	}
+
+	code, _ := get("", "broker")
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, entries := get("?entity=carriers&userId=user-1", "admin")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "3", entries[0].EntityID, "newest first")
+	assert.Equal(t, "1", entries[1].EntityID)
+	assert.NotEmpty(t, entries[0].RequestID)
+
+	code, entries = get(fmt.Sprintf("?limit=1&beforeId=%d", entries[0].ID), "admin")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "user-2", entries[0].UserID)
+
+	code, entries = get("?from=2000-01-01&to=2000-12-31", "admin")
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, entries)
+
+	today := time.Now().UTC().Format("2006-01-02")
+	code, entries = get("?from="+today+"&to="+today, "admin")
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, entries, 3, "to includes the whole day")
+
+	code, _ = get("?from=yesterday", "admin")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = get("?from=2026-02-01&to=2026-01-01", "admin")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = get("?limit=1000", "admin")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"everflown-logistics/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignedIdentity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const secret = "proxy-secret"
+	router := gin.New()
+	router.Use(middleware.Identity(secret))
+	router.GET("/api/admin/export", middleware.RequireRole("admin"), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(middleware.UserIDKey))
+	})
+
+	do := func(role, signature string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/api/admin/export", nil)
+		if role != "" {
+			req.Header.Set(middleware.UserIDHeader, "user-1")
+			req.Header.Set(middleware.UserRoleHeader, role)
+		}
+		if signature != "" {
+			req.Header.Set(middleware.IdentitySignatureHeader, signature)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	now := time.Now()
+	w := do("admin", middleware.SignIdentity(secret, now, middleware.Claims{UserID: "user-1", Role: "admin"}))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "user-1", w.Body.String())
+
+	assert.Equal(t, http.StatusUnauthorized, do("admin", "").Code, "unsigned identities are refused")
+	assert.Equal(t, http.StatusUnauthorized, do("admin", middleware.SignIdentity(secret, now, middleware.Claims{UserID: "user-1", Role: "broker"})).Code,
+		"the signature covers the role")
+	assert.Equal(t, http.StatusUnauthorized, do("admin", middleware.SignIdentity("guess", now, middleware.Claims{UserID: "user-1", Role: "admin"})).Code)
+	assert.Equal(t, http.StatusUnauthorized, do("admin", middleware.SignIdentity(secret, now.Add(-time.Hour), middleware.Claims{UserID: "user-1", Role: "admin"})).Code,
+		"old signatures can't be replayed")
+	assert.Equal(t, http.StatusForbidden, do("broker", middleware.SignIdentity(secret, now, middleware.Claims{UserID: "user-1", Role: "broker"})).Code)
+	assert.Equal(t, http.StatusForbidden, do("", "").Code, "anonymous requests have no role")
+}
@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"everflown-logistics/database"
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var fastRetry = database.RetryPolicy{Attempts: 3, Backoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+// failQueries makes SELECTs on db fail with err while *n is positive,
+// counting it down.
+func failQueries(t *testing.T, db *gorm.DB, n *int, err error) {
+	require.NoError(t, db.Callback().Query().Before("gorm:query").Register("test:fail", func(tx *gorm.DB) {
+		if *n > 0 {
+			*n--
+			tx.AddError(err)
+		}
+	}))
+}
+
+func TestIsTransient(t *testing.T) {
+	t.Parallel()
+	cases := map[error]bool{
+		driver.ErrBadConn:                              true,
+		fmt.Errorf("query: %w", driver.ErrBadConn):     true,
+		&net.OpError{Op: "dial", Err: errors.New("x")}: true,
+		&pgconn.PgError{Code: "57P03"}:                 true,
+		&pgconn.PgError{Code: "08006"}:                 true,
+		&pgconn.PgError{Code: "40001"}:                 true,
+		&pgconn.PgError{Code: "28P01"}:                 false,
+		&pgconn.PgError{Code: "23505"}:                 false,
+		context.Canceled:                               false,
+		gorm.ErrRecordNotFound:                         false,
+		errors.New("syntax error"):                     false,
+	}
+	for err, want := range cases {
+		assert.Equal(t, want, database.IsTransient(err), "%v", err)
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	calls := 0
+	err := fastRetry.Do(ctx, func() error {
+		calls++
+		if calls < 3 {
+			return driver.ErrBadConn
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	err = fastRetry.Do(ctx, func() error {
+		calls++
+		return driver.ErrBadConn
+	})
+	assert.ErrorIs(t, err, driver.ErrBadConn)
+	assert.Equal(t, 3, calls, "gives up after Attempts tries")
+
+	calls = 0
+	err = fastRetry.Do(ctx, func() error {
+		calls++
+		return gorm.ErrRecordNotFound
+	})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Equal(t, 1, calls, "permanent errors are not retried")
+}
+
+func TestRepositoryReadsRetryTransientErrors(t *testing.T) {
+	t.Parallel()
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+	require.NoError(t, testDB.Create(&models.Carrier{CompanyName: "Reliable Transport", ContactPerson: "Mike Wilson", Email: "dispatch@reliable.com", Phone: "(555) 333-4444"}).Error)
+	repos := repository.New(testDB).WithReadRetry(fastRetry)
+	ctx := context.Background()
+
+	failures := 2
+	failQueries(t, testDB, &failures, driver.ErrBadConn)
+	carriers, err := repos.Carriers.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, carriers, 1)
+	assert.Zero(t, failures)
+
+	failures = 1
+	err = repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		_, err := tx.Carriers.Get(ctx, carriers[0].ID)
+		return err
+	})
+	assert.ErrorIs(t, err, driver.ErrBadConn, "reads inside a transaction are not retried")
+}
+
+func TestConnectGivesUpAfterTimeout(t *testing.T) {
+	t.Parallel()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	lis.Close() // nothing listens here any more, so connections are refused
+
+	dsn := "postgres://everflown@" + addr + "/everflown?sslmode=disable&connect_timeout=1"
+	begin := time.Now()
+	_, err = database.Connect(context.Background(), dsn, database.Options{})
+	require.Error(t, err)
+	assert.Less(t, time.Since(begin), 500*time.Millisecond, "a zero timeout makes a single attempt")
+
+	begin = time.Now()
+	_, err = database.Connect(context.Background(), dsn, database.Options{ConnectTimeout: 1500 * time.Millisecond})
+	require.Error(t, err)
+	assert.True(t, database.IsTransient(err))
+	assert.GreaterOrEqual(t, time.Since(begin), 250*time.Millisecond, "retried with backoff before giving up")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = database.Connect(ctx, dsn, database.Options{ConnectTimeout: time.Minute})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
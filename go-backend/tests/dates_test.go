@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"everflown-logistics/dates"
+	"everflown-logistics/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDateIsLenient(t *testing.T) {
+	t.Parallel()
+	want := dates.Date{Year: 2025, Month: time.July, Day: 1}
+	for _, in := range []string{
+		"2025-07-01",
+		" 2025-07-01 ",
+		"2025-07-01 08:00",
+		"2025-07-01T23:30:00-05:00", // the day as written, not in UTC
+		"07/01/2025",
+		"7/1/2025",
+		"Jul 1, 2025",
+		"July 1, 2025",
+		"2025/07/01",
+	} {
+		got, err := dates.ParseDate(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, err := dates.ParseDate("next Tuesday")
+	assert.Error(t, err)
+
+	assert.True(t, want.Before(want.AddDays(1)))
+	assert.Equal(t, "2025-06-30", want.AddDays(-1).String())
+	assert.Equal(t, "July 1, 2025", want.Format("January 2, 2006"))
+}
+
+func TestDateJSON(t *testing.T) {
+	t.Parallel()
+	var invoice models.Invoice
+	require.NoError(t, json.Unmarshal([]byte(`{"dueDate": "08/15/2025", "paidDate": ""}`), &invoice))
+	assert.Equal(t, "2025-08-15", invoice.DueDate.String())
+	require.NotNil(t, invoice.PaidDate)
+	assert.True(t, invoice.PaidDate.IsZero())
+
+	body, err := json.Marshal(models.Invoice{DueDate: dates.MustParseDate("2025-08-15")})
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"dueDate":"2025-08-15"`)
+	assert.Contains(t, string(body), `"paidDate":null`)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"dueDate": "soon"}`), &invoice))
+}
+
+func TestAppointmentsUseTheLocationTimeZone(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	seedOrderAndCarrier(t, testDB)
+	ctx := context.Background()
+
+	var dispatch models.Dispatch
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"orderId": 1, "carrierId": 1, "carrierRate": 1800,
+		"pickupTimeZone": "America/Chicago",
+		"estimatedPickupTime": "2025-07-01T08:00",
+		"estimatedDeliveryTime": "2025-07-02T14:00:00-04:00"
+	}`), &dispatch))
+	require.NoError(t, svc.Dispatches.Create(ctx, &dispatch))
+
+	stored, err := svc.Dispatches.Get(ctx, dispatch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 7, 1, 13, 0, 0, 0, time.UTC), stored.EstimatedPickupTime.Time(), "08:00 in Chicago")
+	assert.Equal(t, time.Date(2025, 7, 2, 18, 0, 0, 0, time.UTC), stored.EstimatedDeliveryTime.Time(), "explicit offsets are kept")
+
+	// Later changes are read in the stored zone.
+	late := dates.MustParseTimestamp("2025-07-01 09:45")
+	updated, err := svc.Dispatches.Update(ctx, dispatch.ID, &models.Dispatch{ActualPickupTime: &late})
+	require.NoError(t, err)
+	assert.Equal(t, 105*time.Minute, updated.ActualPickupTime.Sub(*updated.EstimatedPickupTime))
+
+	zone := "Mars/Olympus_Mons"
+	_, err = svc.Dispatches.Update(ctx, dispatch.ID, &models.Dispatch{DeliveryTimeZone: &zone})
+	assert.ErrorContains(t, err, "deliveryTimeZone")
+}
+
+func TestLegacyTextDatesAreReadLeniently(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	seedOrderAndCarrier(t, testDB)
+	require.NoError(t, testDB.Exec("UPDATE orders SET pickup_date = ?, delivery_date = ? WHERE id = 1", "07/01/2025", "Jul 3, 2025").Error)
+
+	order, err := svc.Orders.Get(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-07-01", order.PickupDate.String())
+	require.NotNil(t, order.DeliveryDate)
+	assert.Equal(t, "2025-07-03", order.DeliveryDate.String())
+}
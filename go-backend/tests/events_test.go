@@ -0,0 +1,284 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"everflown-logistics/dates"
+	"everflown-logistics/events"
+	"everflown-logistics/handlers"
+	"everflown-logistics/middleware"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"everflown-logistics/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func outboxTypes(t *testing.T, db *gorm.DB) []string {
+	t.Helper()
+	var types []string
+	require.NoError(t, db.Model(&models.OutboxEvent{}).Order("id").Pluck("type", &types).Error)
+	return types
+}
+
+// allowRetry makes a subscriber's failed delivery due for retry now.
+func allowRetry(t *testing.T, db *gorm.DB, subscriber string) {
+	t.Helper()
+	require.NoError(t, db.Model(&models.EventCursor{}).Where("subscriber = ?", subscriber).Update("locked_until", nil).Error)
+}
+
+func TestServicesRecordDomainEvents(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	seedOrderAndCarrier(t, testDB)
+	ctx := context.Background()
+
+	order := models.Order{OrderNumber: "ORD-EVT-1", OriginCity: "Dallas", OriginState: "TX", DestinationCity: "Atlanta",
+		DestinationState: "GA", PickupDate: dates.MustParseDate("2026-03-02"), EquipmentType: "Dry Van", CustomerRate: money.New(2400, 0)}
+	require.NoError(t, svc.Orders.Create(ctx, &order))
+
+	dispatch := models.Dispatch{OrderID: order.ID, CarrierID: 1, CarrierRate: money.New(1800, 0)}
+	require.NoError(t, svc.Dispatches.Create(ctx, &dispatch))
+	_, err := svc.Dispatches.Update(ctx, dispatch.ID, &models.Dispatch{DriverName: stringPtr("Sam")})
+	require.NoError(t, err, "a change without a new status raises nothing")
+	_, err = svc.Dispatches.Update(ctx, dispatch.ID, &models.Dispatch{Status: "picked_up"})
+	require.NoError(t, err)
+
+	customer := models.Customer{CompanyName: "Shipper Co", ContactPerson: "Pat", Email: "pat@shipper.example", Phone: "555-0110"}
+	require.NoError(t, svc.Customers.Create(ctx, &customer))
+	quote := models.Quote{QuoteNumber: "Q-EVT-1", CustomerID: &customer.ID, OriginCity: "Dallas", OriginState: "TX",
+		DestinationCity: "Atlanta", DestinationState: "GA", EquipmentType: "Dry Van", QuotedRate: money.New(2400, 0),
+		ValidUntil: dates.MustParseDate("2026-04-01"), Status: "sent"}
+	require.NoError(t, svc.Quotes.Create(ctx, &quote))
+	_, err = svc.Quotes.Update(ctx, quote.ID, &models.Quote{Status: "accepted"})
+	require.NoError(t, err)
+
+	invoice := models.Invoice{InvoiceNumber: "INV-EVT-1", Type: "customer", CustomerID: &customer.ID, OrderID: &order.ID,
+		Amount: money.New(2400, 0), Status: "sent", DueDate: dates.MustParseDate("2026-04-01")}
+	require.NoError(t, svc.Invoices.Create(ctx, &invoice))
+	_, err = svc.Invoices.Update(ctx, invoice.ID, &models.Invoice{Status: "paid"})
+	require.NoError(t, err)
+	_, err = svc.Invoices.Update(ctx, invoice.ID, &models.Invoice{Notes: stringPtr("Thanks")})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{events.OrderCreated, events.DispatchStatusChanged, events.QuoteAccepted, events.InvoicePaid},
+		outboxTypes(t, testDB))
+
+	var stored models.OutboxEvent
+	require.NoError(t, testDB.Where("type = ?", events.DispatchStatusChanged).First(&stored).Error)
+	var data events.DispatchStatusChangedData
+	require.NoError(t, events.Event{Data: []byte(stored.Payload)}.Decode(&data))
+	assert.Equal(t, "assigned", data.From)
+	assert.Equal(t, "picked_up", data.To)
+	assert.Equal(t, dispatch.ID, data.Dispatch.ID)
+}
+
+func TestEventsCommitWithTheChange(t *testing.T) {
+	t.Parallel()
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+	repos := repository.New(testDB)
+	ctx := context.Background()
+
+	failed := errors.New("rolled back")
+	err = repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		require.NoError(t, events.Record(ctx, tx.Outbox, events.OrderCreated, events.OrderCreatedData{}))
+		return failed
+	})
+	require.ErrorIs(t, err, failed)
+	assert.Empty(t, outboxTypes(t, testDB))
+}
+
+func TestDispatcherDeliversAtLeastOnce(t *testing.T) {
+	t.Parallel()
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+	repos := repository.New(testDB)
+	ctx := context.Background()
+
+	// Events from before a subscriber's first run are delivered to it too.
+	require.NoError(t, events.Record(ctx, repos.Outbox, events.OrderCreated, events.OrderCreatedData{}))
+
+	var all, paid []uint
+	failNext := 0
+	dispatcher := events.NewDispatcher(repos.Outbox, events.Options{PollInterval: time.Hour, MaxAttempts: 3})
+	dispatcher.Subscribe("all", func(ctx context.Context, e events.Event) error {
+		if failNext > 0 {
+			failNext--
+			return errors.New("subscriber unavailable")
+		}
+		all = append(all, e.ID)
+		return nil
+	})
+	dispatcher.Subscribe("paid", func(ctx context.Context, e events.Event) error {
+		paid = append(paid, e.ID)
+		return nil
+	}, events.InvoicePaid)
+	require.NoError(t, dispatcher.Register(ctx))
+
+	for _, eventType := range []string{events.OrderCreated, events.InvoicePaid, events.QuoteAccepted} {
+		require.NoError(t, events.Record(ctx, repos.Outbox, eventType, struct{}{}))
+	}
+	require.NoError(t, dispatcher.Deliver(ctx))
+	assert.Equal(t, []uint{1, 2, 3, 4}, all)
+	assert.Equal(t, []uint{3}, paid, "subscribers only get the types they asked for")
+	require.NoError(t, dispatcher.Deliver(ctx))
+	assert.Equal(t, []uint{1, 2, 3, 4}, all, "handled events are not delivered again")
+
+	// A failure is retried, after a delay, without holding up other subscribers.
+	require.NoError(t, events.Record(ctx, repos.Outbox, events.InvoicePaid, struct{}{}))
+	failNext = 1
+	require.NoError(t, dispatcher.Deliver(ctx))
+	assert.Equal(t, []uint{3, 5}, paid)
+	assert.Equal(t, []uint{1, 2, 3, 4}, all)
+	require.NoError(t, dispatcher.Deliver(ctx))
+	assert.Equal(t, []uint{1, 2, 3, 4}, all, "the retry waits for its backoff")
+	allowRetry(t, testDB, "all")
+	require.NoError(t, dispatcher.Deliver(ctx))
+	assert.Equal(t, []uint{1, 2, 3, 4, 5}, all)
+
+	// An event whose transaction commits after a later event's is still
+	// delivered once it is visible.
+	require.NoError(t, repos.Outbox.Append(ctx, models.OutboxEvent{ID: 8, Type: events.OrderCreated, OccurredAt: time.Now(), Payload: "{}"}))
+	require.NoError(t, dispatcher.Deliver(ctx))
+	require.NoError(t, repos.Outbox.Append(ctx, models.OutboxEvent{ID: 7, Type: events.OrderCreated, OccurredAt: time.Now(), Payload: "{}"}))
+	require.NoError(t, dispatcher.Deliver(ctx))
+	assert.Equal(t, []uint{1, 2, 3, 4, 5, 8, 7}, all)
+
+	// After MaxAttempts the event becomes a dead letter and the subscriber
+	// moves on; retrying the dead letter delivers it again.
+	require.NoError(t, events.Record(ctx, repos.Outbox, events.OrderCreated, struct{}{}))
+	require.NoError(t, events.Record(ctx, repos.Outbox, events.OrderCreated, struct{}{}))
+	failNext = 3
+	for i := 0; i < 3; i++ {
+		allowRetry(t, testDB, "all")
+		require.NoError(t, dispatcher.Deliver(ctx))
+	}
+	assert.Equal(t, []uint{1, 2, 3, 4, 5, 8, 7, 10}, all)
+
+	letters, err := repos.Outbox.DeadLetters(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	assert.Equal(t, "all", letters[0].Subscriber)
+	assert.Equal(t, uint(9), letters[0].EventID)
+	assert.Equal(t, 3, letters[0].Attempts)
+	assert.Equal(t, "subscriber unavailable", letters[0].LastError)
+	require.NotNil(t, letters[0].Event)
+	assert.Equal(t, events.OrderCreated, letters[0].Event.Type)
+
+	_, err = repos.Outbox.RetryDeadLetter(ctx, letters[0].ID)
+	require.NoError(t, err)
+	require.NoError(t, dispatcher.Deliver(ctx))
+	assert.Equal(t, []uint{1, 2, 3, 4, 5, 8, 7, 10, 9}, all)
+	letters, err = repos.Outbox.DeadLetters(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, letters)
+	_, err = repos.Outbox.RetryDeadLetter(ctx, 99)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestDispatcherLeasesSubscribers(t *testing.T) {
+	t.Parallel()
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+	repos := repository.New(testDB)
+	ctx := context.Background()
+	require.NoError(t, repos.Outbox.Register(ctx, "webhooks"))
+
+	now := time.Now()
+	ok, err := repos.Outbox.Claim(ctx, "webhooks", "instance-a", now, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = repos.Outbox.Claim(ctx, "webhooks", "instance-b", now, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, ok, "another instance holds the lease")
+
+	// Once the lease expires another instance takes over, and the first can
+	// no longer release it.
+	later := now.Add(2 * time.Minute)
+	ok, err = repos.Outbox.Claim(ctx, "webhooks", "instance-b", later, later.Add(time.Minute))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.ErrorIs(t, repos.Outbox.Release(ctx, "webhooks", "instance-a", time.Time{}), repository.ErrConflict)
+}
+
+func TestPruneOutbox(t *testing.T) {
+	t.Parallel()
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+	repos := repository.New(testDB)
+	ctx := context.Background()
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, events.Record(ctx, repos.Outbox, events.OrderCreated, struct{}{}))
+	}
+	require.NoError(t, repos.Outbox.Register(ctx, "fast"))
+	require.NoError(t, repos.Outbox.Register(ctx, "slow"))
+	now := time.Now()
+	require.NoError(t, repos.Outbox.Handled(ctx, "fast", now, 1, 2, 3))
+	require.NoError(t, repos.Outbox.Handled(ctx, "slow", now, 1, 3))
+	require.NoError(t, repos.Outbox.GiveUp(ctx, "slow", 2, 3, "failed", now))
+
+	pruned, err := repos.Outbox.Prune(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, pruned, "recent events are kept")
+
+	pruned, err = repos.Outbox.Prune(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), pruned, "unhandled events and dead letters are kept")
+	var left []uint
+	require.NoError(t, testDB.Model(&models.OutboxEvent{}).Order("id").Pluck("id", &left).Error)
+	assert.Equal(t, []uint{2, 4}, left)
+}
+
+func TestEventDeadLetterEndpoints(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	h := handlers.New(svc)
+	repos := repository.New(testDB)
+	ctx := context.Background()
+	require.NoError(t, events.Record(ctx, repos.Outbox, events.OrderCreated, struct{}{}))
+	require.NoError(t, repos.Outbox.Register(ctx, "webhooks"))
+	require.NoError(t, repos.Outbox.GiveUp(ctx, "webhooks", 1, 10, "connection refused", time.Now()))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Identity(""))
+	admin := router.Group("/api/admin", middleware.RequireRole("admin"))
+	admin.GET("/events/dead-letters", h.GetEventDeadLetters)
+	admin.POST("/events/dead-letters/:id/retry", h.RetryEventDeadLetter)
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set(middleware.UserRoleHeader, "admin")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/api/admin/events/dead-letters?limit=0").Code)
+	w := do(http.MethodGet, "/api/admin/events/dead-letters")
+	require.Equal(t, http.StatusOK, w.Code)
+	var letters []models.EventDeadLetter
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &letters))
+	require.Len(t, letters, 1)
+	assert.Equal(t, "connection refused", letters[0].LastError)
+	assert.Equal(t, events.OrderCreated, letters[0].Event.Type)
+
+	path := "/api/admin/events/dead-letters/" + strconv.Itoa(int(letters[0].ID)) + "/retry"
+	assert.Equal(t, http.StatusAccepted, do(http.MethodPost, path).Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodPost, path).Code)
+	pending, err := repos.Outbox.Pending(ctx, "webhooks", 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1, "the event is handed to the subscriber again")
+	assert.Zero(t, pending[0].Attempts)
+}
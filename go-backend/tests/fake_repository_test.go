@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"everflown-logistics/dates"
+	"everflown-logistics/handlers"
+	"everflown-logistics/models"
+	"everflown-logistics/repository"
+	"everflown-logistics/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOrderRepository keeps orders in memory, without a database.
+type fakeOrderRepository struct {
+	mu     sync.Mutex
+	nextID uint
+	orders map[uint]models.Order
+}
+
+func newFakeOrderRepository() *fakeOrderRepository {
+	return &fakeOrderRepository{nextID: 1, orders: make(map[uint]models.Order)}
+}
+
+func (r *fakeOrderRepository) List(ctx context.Context) ([]models.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	orders := make([]models.Order, 0, len(r.orders))
+	for id := uint(1); id < r.nextID; id++ {
+		if order, ok := r.orders[id]; ok {
+			orders = append(orders, order)
+		}
+	}
+	return orders, nil
+}
+
+func (r *fakeOrderRepository) Get(ctx context.Context, id uint) (*models.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	order, ok := r.orders[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &order, nil
+}
+
+func (r *fakeOrderRepository) Create(ctx context.Context, order *models.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	order.ID = r.nextID
+	r.nextID++
+	r.orders[order.ID] = *order
+	return nil
+}
+
+func (r *fakeOrderRepository) Update(ctx context.Context, id uint, changes *models.Order) (*models.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	order, ok := r.orders[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	if changes.Status != "" {
+		order.Status = changes.Status
+	}
+	r.orders[id] = order
+	return &order, nil
+}
+
+func (r *fakeOrderRepository) Delete(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.orders[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.orders, id)
+	return nil
+}
+
+func TestOrderHandlersWithFakeRepository(t *testing.T) {
+	t.Parallel()
+
+	repos := &repository.Repositories{Orders: newFakeOrderRepository()}
+	h := handlers.New(services.New(repos, services.DefaultBranding))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/orders", h.GetOrders)
+	router.POST("/api/orders", h.CreateOrder)
+	router.PUT("/api/orders/:id", h.UpdateOrder)
+	router.DELETE("/api/orders/:id", h.DeleteOrder)
+
+	send := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		req, _ := http.NewRequest(method, path, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("POST", "/api/orders", models.Order{
+		OrderNumber:      "ORD-FAKE-1",
+		OriginCity:       "Dallas",
+		OriginState:      "TX",
+		DestinationCity:  "Atlanta",
+		DestinationState: "GA",
+		PickupDate:       dates.MustParseDate("2025-07-01"),
+		EquipmentType:    "Dry Van",
+		Status:           "needs_truck",
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = send("PUT", "/api/orders/1", map[string]string{"status": "dispatched"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = send("GET", "/api/orders", nil)
+	var orders []models.Order
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &orders))
+	require.Len(t, orders, 1)
+	assert.Equal(t, "dispatched", orders[0].Status)
+
+	assert.Equal(t, http.StatusOK, send("DELETE", "/api/orders/1", nil).Code)
+	assert.Equal(t, http.StatusNotFound, send("DELETE", "/api/orders/1", nil).Code)
+	assert.Equal(t, http.StatusNotFound, send("PUT", "/api/orders/1", map[string]string{"status": "delivered"}).Code)
+}
@@ -0,0 +1,187 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"everflown-logistics/grpcserver"
+	"everflown-logistics/middleware"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"everflown-logistics/proto/logisticspb"
+	"everflown-logistics/repository"
+	"everflown-logistics/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gorm.io/gorm"
+)
+
+func setupGRPC(t *testing.T, identitySecret string) (*grpc.ClientConn, *services.Services, *gorm.DB) {
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1 << 20)
+	svc := services.New(repository.New(testDB), services.DefaultBranding)
+	server := grpcserver.New(svc, identitySecret)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, svc, testDB
+}
+
+func TestGRPCCarrierCRUD(t *testing.T) {
+	conn, _, _ := setupGRPC(t, "")
+	client := logisticspb.NewCarrierServiceClient(conn)
+	ctx := context.Background()
+
+	created, err := client.CreateCarrier(ctx, &logisticspb.Carrier{
+		CompanyName:   "Reliable Transport",
+		ContactPerson: "Mike Wilson",
+		Email:         "dispatch@reliable.com",
+		Phone:         "(555) 333-4444",
+		IsActive:      true,
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, created.GetId())
+
+	updated, err := client.UpdateCarrier(ctx, &logisticspb.UpdateCarrierRequest{
+		Id:      created.GetId(),
+		Carrier: &logisticspb.Carrier{CompanyName: "Reliable Transport LLC"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Reliable Transport LLC", updated.GetCompanyName())
+	assert.Equal(t, "Mike Wilson", updated.GetContactPerson())
+
+	list, err := client.ListCarriers(ctx, &logisticspb.ListCarriersRequest{})
+	require.NoError(t, err)
+	assert.Len(t, list.GetCarriers(), 1)
+
+	_, err = client.DeleteCarrier(ctx, &logisticspb.IDRequest{Id: created.GetId()})
+	require.NoError(t, err)
+
+	_, err = client.GetCarrier(ctx, &logisticspb.IDRequest{Id: created.GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPCHidesInternalErrors(t *testing.T) {
+	conn, _, testDB := setupGRPC(t, "")
+	require.NoError(t, testDB.Exec("DROP TABLE carriers").Error)
+
+	_, err := logisticspb.NewCarrierServiceClient(conn).ListCarriers(context.Background(), &logisticspb.ListCarriersRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal error", status.Convert(err).Message(), "database errors aren't sent to callers")
+}
+
+func TestGRPCWatchDispatches(t *testing.T) {
+	conn, svc, testDB := setupGRPC(t, "")
+	seedOrderAndCarrier(t, testDB)
+	client := logisticspb.NewDispatchServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, svc.Stream.Poll(ctx))
+
+	denied, err := client.WatchDispatches(ctx, &logisticspb.WatchDispatchesRequest{})
+	require.NoError(t, err)
+	_, err = denied.Recv()
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "watching needs a role that may see dispatches")
+
+	watchCtx := metadata.AppendToOutgoingContext(ctx, "x-user-role", "broker")
+	stream, err := client.WatchDispatches(watchCtx, &logisticspb.WatchDispatchesRequest{})
+	require.NoError(t, err)
+
+	// The subscription is registered asynchronously on the server, and
+	// changes are read from the audit log when the stream polls, so keep
+	// creating and polling until the stream observes an event.
+	events := make(chan *logisticspb.DispatchEvent, 1)
+	go func() {
+		event, err := stream.Recv()
+		if err == nil {
+			events <- event
+		}
+	}()
+
+	ticker := time.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case event := <-events:
+			assert.Equal(t, logisticspb.DispatchEvent_TYPE_CREATED, event.GetType())
+			assert.Equal(t, "assigned", event.GetDispatch().GetStatus())
+			assert.Equal(t, uint32(1), event.GetDispatch().GetOrderId())
+			return
+		case <-ticker.C:
+			_, err := client.CreateDispatch(ctx, &logisticspb.Dispatch{OrderId: 1, CarrierId: 1, CarrierRate: 1800, Status: "assigned"})
+			require.NoError(t, err)
+			require.NoError(t, svc.Stream.Poll(ctx))
+		case <-ctx.Done():
+			t.Fatal("no dispatch event received")
+		}
+	}
+}
+
+func TestWatchDispatchesSeesOtherInstancesAndDeletes(t *testing.T) {
+	_, svc, testDB := setupGRPC(t, "")
+	seedOrderAndCarrier(t, testDB)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, svc.Stream.Poll(ctx))
+
+	watched, err := svc.Dispatches.Watch(ctx, "", nil)
+	require.NoError(t, err)
+	// Another instance shares the database but not this one's memory.
+	other := services.New(repository.New(testDB), services.DefaultBranding)
+	dispatch := models.Dispatch{OrderID: 1, CarrierID: 1, CarrierRate: money.New(1800, 0)}
+	require.NoError(t, other.Dispatches.Create(ctx, &dispatch))
+	require.NoError(t, other.Dispatches.Delete(ctx, dispatch.ID))
+	require.NoError(t, svc.Stream.Poll(ctx))
+
+	// The dispatch is gone by the time its create is read, so only the
+	// delete is sent, with the dispatch as it was.
+	event := <-watched
+	assert.Equal(t, services.DispatchDeleted, event.Type)
+	assert.Equal(t, dispatch.ID, event.Dispatch.ID)
+	assert.Equal(t, uint(1), event.Dispatch.OrderID)
+	assert.Equal(t, money.New(1800, 0), event.Dispatch.CarrierRate)
+}
+
+func TestGRPCSignedIdentity(t *testing.T) {
+	const secret = "proxy-secret"
+	conn, _, _ := setupGRPC(t, secret)
+	client := logisticspb.NewCarrierServiceClient(conn)
+	ctx := context.Background()
+
+	_, err := client.ListCarriers(ctx, &logisticspb.ListCarriersRequest{})
+	assert.NoError(t, err, "anonymous calls carry no identity to check")
+
+	claims := middleware.Claims{UserID: "user-1", Role: "broker"}
+	unsigned := metadata.AppendToOutgoingContext(ctx, "x-user-id", claims.UserID, "x-user-role", claims.Role)
+	_, err = client.ListCarriers(unsigned, &logisticspb.ListCarriersRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	signed := metadata.AppendToOutgoingContext(unsigned, "x-identity-signature",
+		middleware.SignIdentity(secret, time.Now(), claims))
+	_, err = client.ListCarriers(signed, &logisticspb.ListCarriersRequest{})
+	assert.NoError(t, err)
+
+	watch, err := logisticspb.NewDispatchServiceClient(conn).WatchDispatches(unsigned, &logisticspb.WatchDispatchesRequest{})
+	require.NoError(t, err)
+	_, err = watch.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "streams are checked too")
+}
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -9,79 +10,114 @@ import (
 	"time"
 
 	"everflown-logistics/database"
+	"everflown-logistics/dates"
 	"everflown-logistics/handlers"
+	"everflown-logistics/migrations"
 	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"everflown-logistics/repository"
+	"everflown-logistics/services"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
-	"gorm.io/driver/sqlite"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
-// MockDB is a mock database for testing
-type MockDB struct {
-	mock.Mock
-}
+func stringPtr(s string) *string { return &s }
+
+func uintPtr(u uint) *uint { return &u }
+
+func float64Ptr(f float64) *float64 { return &f }
+
+func amountPtr(a money.Amount) *money.Amount { return &a }
 
+func datePtr(d dates.Date) *dates.Date { return &d }
+
+// setupTestDB returns a fresh in-memory SQLite database, private to the
+// caller, with the embedded SQLite migrations applied.
 func setupTestDB() (*gorm.DB, error) {
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db, err := database.Connect(context.Background(), "sqlite::memory:", database.Options{})
 	if err != nil {
 		return nil, err
 	}
 
-	// Auto-migrate the schema
-	err = db.AutoMigrate(
-		&models.User{},
-		&models.Lead{},
-		&models.Customer{},
-		&models.Carrier{},
-		&models.Order{},
-		&models.Dispatch{},
-		&models.Quote{},
-		&models.Invoice{},
-		&models.FollowUp{},
-	)
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	migrator, err := migrations.New(sqlDB, migrations.SQLite)
 	if err != nil {
 		return nil, err
 	}
+	if _, err := migrator.Up(context.Background(), 0); err != nil {
+		return nil, err
+	}
 
 	return db, nil
 }
 
-func setupTestRouter() *gin.Engine {
+// seedOrderAndCarrier creates order 1 and carrier 1 for tests that dispatch.
+func seedOrderAndCarrier(t *testing.T, db *gorm.DB) {
+	require.NoError(t, db.Create(&models.Order{
+		OrderNumber:      "ORD-SEED-001",
+		OriginCity:       "Dallas",
+		OriginState:      "TX",
+		DestinationCity:  "Atlanta",
+		DestinationState: "GA",
+		PickupDate:       dates.MustParseDate("2025-07-01"),
+		EquipmentType:    "Dry Van",
+		CustomerRate:     money.New(2400, 0),
+		Status:           "needs_truck",
+	}).Error)
+	require.NoError(t, db.Create(&models.Carrier{
+		CompanyName:   "Seed Carrier",
+		ContactPerson: "Sam Seed",
+		Email:         "dispatch@seed.com",
+		Phone:         "(555) 000-0000",
+	}).Error)
+}
+
+// newTestHandler wires handlers to GORM repositories on db.
+func newTestHandler(db *gorm.DB) *handlers.Handler {
+	return handlers.New(services.New(repository.New(db), services.DefaultBranding))
+}
+
+func setupTestRouter(h *handlers.Handler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 	
 	api := router.Group("/api")
 	{
 		// Test routes
-		api.GET("/leads", handlers.GetLeads)
-		api.POST("/leads", handlers.CreateLead)
-		api.PUT("/leads/:id", handlers.UpdateLead)
-		api.DELETE("/leads/:id", handlers.DeleteLead)
+		api.GET("/leads", h.GetLeads)
+		api.POST("/leads", h.CreateLead)
+		api.PUT("/leads/:id", h.UpdateLead)
+		api.DELETE("/leads/:id", h.DeleteLead)
 		
-		api.GET("/customers", handlers.GetCustomers)
-		api.POST("/customers", handlers.CreateCustomer)
+		api.GET("/customers", h.GetCustomers)
+		api.POST("/customers", h.CreateCustomer)
+		api.DELETE("/customers/:id", h.DeleteCustomer)
 		
-		api.GET("/quotes", handlers.GetQuotes)
-		api.POST("/quotes", handlers.CreateQuote)
-		api.PUT("/quotes/:id", handlers.UpdateQuote)
-		api.DELETE("/quotes/:id", handlers.DeleteQuote)
-		api.GET("/quotes/:id/pdf", handlers.GenerateQuotePDF)
+		api.GET("/quotes", h.GetQuotes)
+		api.POST("/quotes", h.CreateQuote)
+		api.PUT("/quotes/:id", h.UpdateQuote)
+		api.DELETE("/quotes/:id", h.DeleteQuote)
+		api.GET("/quotes/:id/pdf", h.GenerateQuotePDF)
 		
-		api.GET("/invoices/:id/pdf", handlers.GenerateInvoicePDF)
+		api.GET("/invoices/:id/pdf", h.GenerateInvoicePDF)
 		
-		api.GET("/dashboard/stats", handlers.GetDashboardStats)
+		api.GET("/dashboard/stats", h.GetDashboardStats)
 	}
 	
 	return router
 }
 
 func TestGetLeads(t *testing.T) {
+	t.Parallel()
+
 	// Setup test database
 	testDB, err := setupTestDB()
 	assert.NoError(t, err)
-	database.DB = testDB
 
 	// Create test data
 	lead := models.Lead{
@@ -89,18 +125,18 @@ func TestGetLeads(t *testing.T) {
 		ContactPerson:    "John Doe",
 		Email:            "john@test.com",
 		Phone:            "(555) 123-4567",
-		OriginCity:       "Los Angeles",
-		OriginState:      "CA",
-		DestinationCity:  "Chicago",
-		DestinationState: "IL",
-		PickupDate:       time.Now(),
-		EquipmentType:    "Dry Van",
+		OriginCity:       stringPtr("Los Angeles"),
+		OriginState:      stringPtr("CA"),
+		DestinationCity:  stringPtr("Chicago"),
+		DestinationState: stringPtr("IL"),
+		PickupDate:       datePtr(dates.Today(time.Local)),
+		EquipmentType:    stringPtr("Dry Van"),
 		Status:           "new",
 	}
 	testDB.Create(&lead)
 
 	// Setup router
-	router := setupTestRouter()
+	router := setupTestRouter(newTestHandler(testDB))
 
 	// Create request
 	req, _ := http.NewRequest("GET", "/api/leads", nil)
@@ -120,13 +156,14 @@ func TestGetLeads(t *testing.T) {
 }
 
 func TestCreateLead(t *testing.T) {
+	t.Parallel()
+
 	// Setup test database
 	testDB, err := setupTestDB()
 	assert.NoError(t, err)
-	database.DB = testDB
 
 	// Setup router
-	router := setupTestRouter()
+	router := setupTestRouter(newTestHandler(testDB))
 
 	// Create test lead data
 	leadData := models.Lead{
@@ -134,14 +171,14 @@ func TestCreateLead(t *testing.T) {
 		ContactPerson:    "Jane Smith",
 		Email:            "jane@newtest.com",
 		Phone:            "(555) 987-6543",
-		OriginCity:       "Houston",
-		OriginState:      "TX",
-		DestinationCity:  "Denver",
-		DestinationState: "CO",
-		PickupDate:       time.Now().AddDate(0, 0, 7),
-		EquipmentType:    "Refrigerated",
+		OriginCity:       stringPtr("Houston"),
+		OriginState:      stringPtr("TX"),
+		DestinationCity:  stringPtr("Denver"),
+		DestinationState: stringPtr("CO"),
+		PickupDate:       datePtr(dates.Today(time.Local).AddDays(7)),
+		EquipmentType:    stringPtr("Refrigerated"),
 		Status:           "new",
-		Notes:            "Temperature sensitive",
+		Notes:            stringPtr("Temperature sensitive"),
 	}
 
 	jsonData, _ := json.Marshal(leadData)
@@ -163,10 +200,11 @@ func TestCreateLead(t *testing.T) {
 }
 
 func TestUpdateLead(t *testing.T) {
+	t.Parallel()
+
 	// Setup test database
 	testDB, err := setupTestDB()
 	assert.NoError(t, err)
-	database.DB = testDB
 
 	// Create initial lead
 	lead := models.Lead{
@@ -178,7 +216,7 @@ func TestUpdateLead(t *testing.T) {
 	testDB.Create(&lead)
 
 	// Setup router
-	router := setupTestRouter()
+	router := setupTestRouter(newTestHandler(testDB))
 
 	// Update data
 	updateData := map[string]interface{}{
@@ -206,10 +244,11 @@ func TestUpdateLead(t *testing.T) {
 }
 
 func TestDeleteLead(t *testing.T) {
+	t.Parallel()
+
 	// Setup test database
 	testDB, err := setupTestDB()
 	assert.NoError(t, err)
-	database.DB = testDB
 
 	// Create lead to delete
 	lead := models.Lead{
@@ -220,7 +259,7 @@ func TestDeleteLead(t *testing.T) {
 	testDB.Create(&lead)
 
 	// Setup router
-	router := setupTestRouter()
+	router := setupTestRouter(newTestHandler(testDB))
 
 	// Delete request
 	req, _ := http.NewRequest("DELETE", "/api/leads/1", nil)
@@ -239,13 +278,14 @@ func TestDeleteLead(t *testing.T) {
 }
 
 func TestCreateCustomer(t *testing.T) {
+	t.Parallel()
+
 	// Setup test database
 	testDB, err := setupTestDB()
 	assert.NoError(t, err)
-	database.DB = testDB
 
 	// Setup router
-	router := setupTestRouter()
+	router := setupTestRouter(newTestHandler(testDB))
 
 	// Create test customer data
 	customerData := models.Customer{
@@ -253,17 +293,17 @@ func TestCreateCustomer(t *testing.T) {
 		ContactPerson:       "Customer Contact",
 		Email:               "customer@test.com",
 		Phone:               "(555) 111-2222",
-		Address:             "123 Customer St",
-		City:                "Customer City",
-		State:               "CA",
-		ZipCode:             "90210",
-		BillingAddress:      "123 Billing St",
-		BillingCity:         "Billing City",
-		BillingState:        "CA",
-		BillingZipCode:      "90211",
-		CreditLimit:         50000.00,
+		Address:             stringPtr("123 Customer St"),
+		City:                stringPtr("Customer City"),
+		State:               stringPtr("CA"),
+		ZipCode:             stringPtr("90210"),
+		BillingAddress:      stringPtr("123 Billing St"),
+		BillingCity:         stringPtr("Billing City"),
+		BillingState:        stringPtr("CA"),
+		BillingZipCode:      stringPtr("90211"),
+		CreditLimit:         amountPtr(money.New(50000, 0)),
 		PaymentTerms:        "Net 30",
-		SpecialInstructions: "Test instructions",
+		SpecialInstructions: stringPtr("Test instructions"),
 		IsActive:            true,
 	}
 
@@ -282,14 +322,17 @@ func TestCreateCustomer(t *testing.T) {
 	err = json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.Equal(t, "Test Customer Inc", response.CompanyName)
-	assert.Equal(t, 50000.00, response.CreditLimit)
+	if assert.NotNil(t, response.CreditLimit) {
+		assert.Equal(t, money.New(50000, 0), *response.CreditLimit)
+	}
 }
 
 func TestCreateQuote(t *testing.T) {
+	t.Parallel()
+
 	// Setup test database
 	testDB, err := setupTestDB()
 	assert.NoError(t, err)
-	database.DB = testDB
 
 	// Create required lead and customer
 	lead := models.Lead{
@@ -308,24 +351,24 @@ func TestCreateQuote(t *testing.T) {
 	testDB.Create(&customer)
 
 	// Setup router
-	router := setupTestRouter()
+	router := setupTestRouter(newTestHandler(testDB))
 
 	// Create quote data
 	quoteData := models.Quote{
 		QuoteNumber:      "QTE-TEST-001",
-		LeadID:           lead.ID,
-		CustomerID:       customer.ID,
+		LeadID:           uintPtr(lead.ID),
+		CustomerID:       uintPtr(customer.ID),
 		OriginCity:       "Los Angeles",
 		OriginState:      "CA",
 		DestinationCity:  "Chicago",
 		DestinationState: "IL",
 		EquipmentType:    "Dry Van",
-		Weight:           25000,
-		Commodity:        "Electronics",
-		QuotedRate:       2500.00,
-		ValidUntil:       time.Now().AddDate(0, 0, 30),
+		Weight:           float64Ptr(25000),
+		Commodity:        stringPtr("Electronics"),
+		QuotedRate:       money.New(2500, 0),
+		ValidUntil:       dates.Today(time.Local).AddDays(30),
 		Status:           "pending",
-		Notes:            "Test quote",
+		Notes:            stringPtr("Test quote"),
 	}
 
 	jsonData, _ := json.Marshal(quoteData)
@@ -343,14 +386,15 @@ func TestCreateQuote(t *testing.T) {
 	err = json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.Equal(t, "QTE-TEST-001", response.QuoteNumber)
-	assert.Equal(t, 2500.00, response.QuotedRate)
+	assert.Equal(t, money.New(2500, 0), response.QuotedRate)
 }
 
 func TestDashboardStats(t *testing.T) {
+	t.Parallel()
+
 	// Setup test database
 	testDB, err := setupTestDB()
 	assert.NoError(t, err)
-	database.DB = testDB
 
 	// Create test data for dashboard stats
 	customer := models.Customer{
@@ -368,8 +412,8 @@ func TestDashboardStats(t *testing.T) {
 
 	order := models.Order{
 		OrderNumber:         "ORD-STATS-001",
-		CustomerID:          customer.ID,
-		LeadID:              lead.ID,
+		CustomerID:          uintPtr(customer.ID),
+		LeadID:              uintPtr(lead.ID),
 		OriginCity:          "Test Origin",
 		OriginState:         "CA",
 		OriginAddress:       "123 Origin St",
@@ -378,30 +422,30 @@ func TestDashboardStats(t *testing.T) {
 		DestinationState:    "NY",
 		DestinationAddress:  "456 Dest St",
 		DestinationZipCode:  "10001",
-		PickupDate:          time.Now(),
+		PickupDate:          dates.Today(time.Local),
 		EquipmentType:       "Dry Van",
-		CustomerRate:        2500.00,
+		CustomerRate:        money.New(2500, 0),
 		Status:              "in_transit",
 	}
 	testDB.Create(&order)
 
 	quote := models.Quote{
 		QuoteNumber:     "QTE-STATS-001",
-		LeadID:          lead.ID,
-		CustomerID:      customer.ID,
+		LeadID:          uintPtr(lead.ID),
+		CustomerID:      uintPtr(customer.ID),
 		OriginCity:      "Test Origin",
 		OriginState:     "CA",
 		DestinationCity: "Test Destination",
 		DestinationState: "NY",
 		EquipmentType:   "Dry Van",
-		QuotedRate:      2500.00,
-		ValidUntil:      time.Now().AddDate(0, 0, 30),
+		QuotedRate:      money.New(2500, 0),
+		ValidUntil:      dates.Today(time.Local).AddDays(30),
 		Status:          "pending",
 	}
 	testDB.Create(&quote)
 
 	// Setup router
-	router := setupTestRouter()
+	router := setupTestRouter(newTestHandler(testDB))
 
 	// Create request
 	req, _ := http.NewRequest("GET", "/api/dashboard/stats", nil)
@@ -422,13 +466,14 @@ func TestDashboardStats(t *testing.T) {
 }
 
 func TestInvalidIDHandling(t *testing.T) {
+	t.Parallel()
+
 	// Setup test database
 	testDB, err := setupTestDB()
 	assert.NoError(t, err)
-	database.DB = testDB
 
 	// Setup router
-	router := setupTestRouter()
+	router := setupTestRouter(newTestHandler(testDB))
 
 	// Test invalid ID format
 	req, _ := http.NewRequest("PUT", "/api/leads/invalid", nil)
@@ -445,13 +490,14 @@ func TestInvalidIDHandling(t *testing.T) {
 }
 
 func TestNotFoundHandling(t *testing.T) {
+	t.Parallel()
+
 	// Setup test database
 	testDB, err := setupTestDB()
 	assert.NoError(t, err)
-	database.DB = testDB
 
 	// Setup router
-	router := setupTestRouter()
+	router := setupTestRouter(newTestHandler(testDB))
 
 	// Test non-existent resource
 	req, _ := http.NewRequest("PUT", "/api/leads/999", bytes.NewBuffer([]byte(`{"companyName": "Test"}`)))
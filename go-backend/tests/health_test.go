@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"everflown-logistics/health"
+	"everflown-logistics/migrations"
+	"everflown-logistics/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readiness(t *testing.T, checker *health.Checker) (int, health.Report) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/health/ready", checker.ReadyHandler)
+
+	req, _ := http.NewRequest("GET", "/health/ready", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var report health.Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	return w.Code, report
+}
+
+func TestReadinessWithDatabase(t *testing.T) {
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+
+	checker := health.NewChecker(0)
+	checker.Register("database", health.DatabaseCheck(testDB))
+	checker.Register("schema", health.SchemaCheck(testDB, models.Tables()...))
+	checker.SetStatus("grpc_server", nil)
+
+	code, report := readiness(t, checker)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, health.StatusUp, report.Status)
+	assert.Equal(t, health.StatusUp, report.Checks["database"].Status)
+	assert.Equal(t, health.StatusUp, report.Checks["schema"].Status)
+	assert.Equal(t, health.StatusUp, report.Checks["grpc_server"].Status)
+
+	require.NoError(t, testDB.Migrator().DropTable(&models.FollowUp{}))
+	code, report = readiness(t, checker)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "missing tables: follow_ups", report.Checks["schema"].Error)
+
+	sqlDB, err := testDB.DB()
+	require.NoError(t, err)
+	sqlDB.Close()
+	_, report = readiness(t, checker)
+	assert.Equal(t, health.StatusDown, report.Checks["database"].Status)
+}
+
+func TestReadinessMigrationStatus(t *testing.T) {
+	ctx := context.Background()
+	db := emptySQLite(t)
+	migrator, err := migrations.New(db, testMigrations)
+	require.NoError(t, err)
+	checker := health.NewChecker(0)
+	checker.Register("migrations", health.MigrationCheck(migrator))
+
+	code, report := readiness(t, checker)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, report.Checks["migrations"].Error, migrations.ErrPending.Error())
+
+	_, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	code, _ = readiness(t, checker)
+	assert.Equal(t, http.StatusOK, code)
+
+	// A schema rolled back behind the running code is not ready either.
+	_, err = migrator.Down(ctx, 1)
+	require.NoError(t, err)
+	code, _ = readiness(t, checker)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+func TestReadinessWorkerStatus(t *testing.T) {
+	checker := health.NewChecker(0)
+	checker.SetStatus("grpc_server", errors.New("starting"))
+
+	code, report := readiness(t, checker)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "starting", report.Checks["grpc_server"].Error)
+
+	checker.SetStatus("grpc_server", nil)
+	code, _ = readiness(t, checker)
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestReadinessIsCached(t *testing.T) {
+	calls := 0
+	checker := health.NewChecker(time.Minute)
+	checker.Register("database", func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		checker.Check(context.Background())
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestLiveness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/health/live", health.LiveHandler)
+
+	req, _ := http.NewRequest("GET", "/health/live", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"up"}`, w.Body.String())
+}
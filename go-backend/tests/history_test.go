@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"everflown-logistics/audit"
+	"everflown-logistics/dates"
+	"everflown-logistics/handlers"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"everflown-logistics/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordHistory(t *testing.T) {
+	t.Parallel()
+	svc, _ := setupServices(t)
+	h := handlers.New(svc)
+	ctx := context.Background()
+
+	user, err := svc.Users.Register(ctx, services.RegisterInput{Username: "dana", Password: "secret1", Email: "dana@example.com",
+		FirstName: stringPtr("Dana"), LastName: stringPtr("Reyes")})
+	require.NoError(t, err)
+	asDana := audit.WithActor(ctx, audit.Actor{UserID: user.ID})
+	asAdmin := audit.WithActor(ctx, audit.Actor{UserID: services.DefaultAdmin().ID})
+	asGone := audit.WithActor(ctx, audit.Actor{UserID: "user-deleted"})
+
+	order := models.Order{OrderNumber: "ORD-HIST-1", OriginCity: "Dallas", OriginState: "TX", DestinationCity: "Atlanta",
+		DestinationState: "GA", PickupDate: dates.MustParseDate("2026-03-02"), EquipmentType: "Dry Van", CustomerRate: money.New(2400, 0)}
+	require.NoError(t, svc.Orders.Create(asDana, &order))
+	_, err = svc.Orders.Update(asAdmin, order.ID, &models.Order{PickupDate: dates.MustParseDate("2026-03-04"), CustomerRate: money.New(2550, 0)})
+	require.NoError(t, err)
+	_, err = svc.Orders.Update(asGone, order.ID, &models.Order{Status: "cancelled"})
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/orders/:id/history", h.GetOrderHistory)
+	router.GET("/api/quotes/:id/history", h.GetQuoteHistory)
+
+	get := func(path string) (int, []services.HistoryEntry) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var history []services.HistoryEntry
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+		}
+		return w.Code, history
+	}
+
+	code, history := get(fmt.Sprintf("/api/orders/%d/history", order.ID))
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, history, 3)
+
+	assert.Equal(t, "create", history[0].Action)
+	assert.Equal(t, "Dana Reyes", history[0].UserName)
+	assert.Equal(t, "orderNumber", history[0].Changes[0].Field, "changes follow the field order")
+	assert.Equal(t, "Order number", history[0].Changes[0].Label)
+
+	assert.Equal(t, "update", history[1].Action)
+	assert.Equal(t, "System Administrator", history[1].UserName)
+	assert.Equal(t, []services.FieldHistory{
+		{Field: "pickupDate", Label: "Pickup date", From: json.RawMessage(`"2026-03-02"`), To: json.RawMessage(`"2026-03-04"`)},
+		{Field: "customerRate", Label: "Customer rate", From: json.RawMessage(`2400.00`), To: json.RawMessage(`2550.00`)},
+	}, history[1].Changes)
+	assert.True(t, !history[1].OccurredAt.Before(history[0].OccurredAt), "oldest first")
+
+	assert.Equal(t, "user-deleted", history[2].UserName, "unknown users are shown by ID")
+	require.Len(t, history[2].Changes, 1)
+	assert.Equal(t, "Status", history[2].Changes[0].Label)
+
+	// A deleted record keeps its history.
+	require.NoError(t, svc.Orders.Delete(ctx, order.ID))
+	code, history = get(fmt.Sprintf("/api/orders/%d/history", order.ID))
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, history, 4)
+	assert.Equal(t, "delete", history[3].Action)
+	assert.Equal(t, "System", history[3].UserName)
+
+	code, _ = get("/api/orders/999/history")
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = get("/api/quotes/abc/history")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestFieldLabels(t *testing.T) {
+	t.Parallel()
+	labels := map[string]string{}
+	for _, f := range models.FieldLabels(&models.Carrier{}) {
+		labels[f.Name] = f.Label
+	}
+	assert.Equal(t, "Company name", labels["companyName"])
+	assert.Equal(t, "MC number", labels["mcNumber"])
+	assert.Equal(t, "DOT number", labels["dotNumber"])
+	assert.Equal(t, "W9 on file", labels["w9OnFile"])
+
+	labels = map[string]string{}
+	for _, f := range models.FieldLabels(&models.ArchivedInvoice{}) {
+		labels[f.Name] = f.Label
+	}
+	assert.Equal(t, "Dispatch", labels["dispatchId"], "ID suffixes are dropped")
+	assert.Equal(t, "Archived at", labels["archivedAt"], "embedded fields are included")
+}
@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"everflown-logistics/health"
+	"everflown-logistics/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerDrainsJobsOnShutdown(t *testing.T) {
+	runner := jobs.NewRunner(nil)
+
+	var finished atomic.Bool
+	started := make(chan struct{})
+	runner.Go("export", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		// Simulate finishing in-flight work after cancellation.
+		time.Sleep(20 * time.Millisecond)
+		finished.Store(true)
+		return nil
+	})
+	<-started
+
+	require.NoError(t, runner.Shutdown(context.Background()))
+	assert.True(t, finished.Load())
+}
+
+func TestRunnerLetsRunsFinishUntilTheDeadline(t *testing.T) {
+	runner := jobs.NewRunner(nil)
+
+	started := make(chan struct{})
+	var finished atomic.Bool
+	var runErr atomic.Value
+	runner.Every("archive", time.Millisecond, func(ctx context.Context) error {
+		if finished.Load() {
+			return nil
+		}
+		close(started)
+		select {
+		case <-ctx.Done():
+			runErr.Store(ctx.Err())
+		case <-time.After(20 * time.Millisecond):
+			finished.Store(true)
+		}
+		return nil
+	})
+	<-started
+
+	require.NoError(t, runner.Shutdown(context.Background()))
+	assert.True(t, finished.Load(), "shutting down doesn't cancel a run in progress")
+	assert.Nil(t, runErr.Load())
+
+	runner = jobs.NewRunner(nil)
+	release := make(chan struct{})
+	runner.Every("stuck", time.Millisecond, func(ctx context.Context) error {
+		close(release)
+		<-ctx.Done()
+		return nil
+	})
+	<-release
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, runner.Shutdown(ctx), context.DeadlineExceeded)
+}
+
+func TestRunnerShutdownTimeout(t *testing.T) {
+	runner := jobs.NewRunner(nil)
+	release := make(chan struct{})
+	defer close(release)
+	runner.Go("stuck", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, runner.Shutdown(ctx), context.DeadlineExceeded)
+}
+
+func TestRunnerReportsJobStatus(t *testing.T) {
+	checker := health.NewChecker(0)
+	runner := jobs.NewRunner(checker)
+
+	var runs atomic.Int32
+	runner.Every("pruner", 5*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return errors.New("table locked")
+	})
+
+	assert.Eventually(t, func() bool {
+		return checker.Check(context.Background()).Checks["pruner"].Error == "table locked"
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, runner.Shutdown(context.Background()))
+	assert.Positive(t, runs.Load())
+}
@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"everflown-logistics/logging"
+	"everflown-logistics/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupLoggingRouter(buf *bytes.Buffer) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logging.Setup(buf, "info")
+
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.Identity(""), middleware.Logger(), middleware.Recovery())
+	router.GET("/api/dispatches/:id", func(c *gin.Context) {
+		logging.FromContext(c.Request.Context()).Error("Failed to update dispatch", "error", errors.New("connection reset"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update dispatch"})
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	return router
+}
+
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var lines []map[string]interface{}
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal(line, &entry))
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestRequestLoggingAttribution(t *testing.T) {
+	var buf bytes.Buffer
+	router := setupLoggingRouter(&buf)
+
+	req, _ := http.NewRequest("GET", "/api/dispatches/7", nil)
+	req.Header.Set(middleware.UserIDHeader, "admin-1750604677654")
+	req.Header.Set(middleware.TenantIDHeader, "everflown")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	requestID := w.Header().Get(middleware.RequestIDHeader)
+	assert.Len(t, requestID, 32)
+
+	lines := decodeLogLines(t, &buf)
+	require.Len(t, lines, 2)
+	for _, entry := range lines {
+		assert.Equal(t, requestID, entry["request_id"])
+		assert.Equal(t, "admin-1750604677654", entry["user_id"])
+		assert.Equal(t, "everflown", entry["tenant_id"])
+	}
+
+	assert.Equal(t, "Failed to update dispatch", lines[0]["msg"])
+	assert.Equal(t, "connection reset", lines[0]["error"])
+
+	assert.Equal(t, "request completed", lines[1]["msg"])
+	assert.Equal(t, "ERROR", lines[1]["level"])
+	assert.Equal(t, "/api/dispatches/:id", lines[1]["route"])
+	assert.Equal(t, float64(http.StatusInternalServerError), lines[1]["status"])
+	assert.Contains(t, lines[1], "latency")
+}
+
+func TestRequestIDPropagation(t *testing.T) {
+	var buf bytes.Buffer
+	router := setupLoggingRouter(&buf)
+
+	req, _ := http.NewRequest("GET", "/panic", nil)
+	req.Header.Set(middleware.RequestIDHeader, "upstream-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "upstream-123", w.Header().Get(middleware.RequestIDHeader))
+
+	lines := decodeLogLines(t, &buf)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "panic recovered", lines[0]["msg"])
+	assert.Equal(t, "upstream-123", lines[0]["request_id"])
+	assert.Equal(t, "upstream-123", lines[1]["request_id"])
+}
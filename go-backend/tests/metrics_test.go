@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"everflown-logistics/metrics"
+	"everflown-logistics/middleware"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"everflown-logistics/repository"
+	"everflown-logistics/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrapeMetrics(t *testing.T, router *gin.Engine) string {
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+	require.NoError(t, testDB.Use(&metrics.GormPlugin{}))
+	seedOrderAndCarrier(t, testDB)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Metrics())
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+	router.GET("/api/orders/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req, _ := http.NewRequest("GET", "/api/orders/42", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	svc := services.New(repository.New(testDB), services.DefaultBranding)
+	ctx := context.Background()
+	require.NoError(t, svc.Dispatches.Create(ctx, &models.Dispatch{OrderID: 1, CarrierID: 1, CarrierRate: money.New(1500, 0), Status: "assigned"}))
+	_, err = svc.Dispatches.Update(ctx, 1, &models.Dispatch{Status: "in_transit"})
+	require.NoError(t, err)
+
+	body := scrapeMetrics(t, router)
+	assert.Contains(t, body, `everflown_http_request_duration_seconds_count{method="GET",route="/api/orders/:id",status="200"} 1`)
+	assert.Contains(t, body, `everflown_db_query_duration_seconds_count{operation="create",table="dispatches"}`)
+	assert.Contains(t, body, `everflown_dispatch_status_changes_total{status="in_transit"}`)
+	assert.Contains(t, body, `go_sql_open_connections{db_name="sqlite"}`)
+}
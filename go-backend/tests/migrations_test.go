@@ -0,0 +1,166 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"everflown-logistics/database"
+	"everflown-logistics/middleware"
+	"everflown-logistics/migrations"
+	"everflown-logistics/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var testMigrations = fstest.MapFS{
+	"0001_create_lanes.up.sql":     {Data: []byte("CREATE TABLE lanes (id INTEGER PRIMARY KEY, origin TEXT NOT NULL);")},
+	"0001_create_lanes.down.sql":   {Data: []byte("DROP TABLE lanes;")},
+	"0002_add_lane_miles.up.sql":   {Data: []byte("ALTER TABLE lanes ADD COLUMN miles INTEGER;")},
+	"0002_add_lane_miles.down.sql": {Data: []byte("ALTER TABLE lanes DROP COLUMN miles;")},
+	"README.md":                    {Data: []byte("ignored")},
+}
+
+func emptySQLite(t *testing.T) *sql.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	return sqlDB
+}
+
+func TestMigrateUpDownAndStatus(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := emptySQLite(t)
+	migrator, err := migrations.New(db, testMigrations)
+	require.NoError(t, err)
+
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Len(t, status.Pending, 2)
+	assert.ErrorIs(t, status.Check(), migrations.ErrPending)
+
+	applied, err := migrator.Up(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+
+	applied, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+	_, err = db.Exec("INSERT INTO lanes (origin, miles) VALUES ('Dallas', 780)")
+	require.NoError(t, err)
+
+	status, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), status.Version)
+	assert.NoError(t, status.Check())
+
+	reverted, err := migrator.Down(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reverted)
+
+	status, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint(0), status.Version)
+	assert.Len(t, status.Pending, 2)
+}
+
+func TestFailedMigrationLeavesSchemaDirty(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := emptySQLite(t)
+	broken := fstest.MapFS{
+		"0001_create_lanes.up.sql": testMigrations["0001_create_lanes.up.sql"],
+		"0002_broken.up.sql":       {Data: []byte("ALTER TABLE missing ADD COLUMN miles INTEGER;")},
+	}
+	migrator, err := migrations.New(db, broken)
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx, 0)
+	require.Error(t, err)
+
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, status.Dirty)
+	assert.Equal(t, uint(2), status.Version)
+	assert.ErrorIs(t, status.Check(), migrations.ErrDirty)
+
+	_, err = migrator.Up(ctx, 0)
+	assert.ErrorIs(t, err, migrations.ErrDirty, "dirty schemas are not migrated further")
+
+	require.NoError(t, migrator.Force(ctx, 1))
+	status, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.False(t, status.Dirty)
+	assert.Equal(t, uint(1), status.Version)
+
+	assert.Error(t, migrator.Force(ctx, 7), "unknown versions cannot be forced")
+}
+
+func TestLoadEmbeddedMigrations(t *testing.T) {
+	t.Parallel()
+	loaded, err := migrations.Load(migrations.Postgres)
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded)
+	assert.Equal(t, "0001_initial_schema", loaded[0].String())
+	for _, m := range loaded {
+		assert.NotEmpty(t, m.Down, "%s has a down migration", m)
+	}
+
+	_, err = migrations.Load(fstest.MapFS{"0001_only_down.down.sql": {Data: []byte("SELECT 1;")}})
+	assert.Error(t, err)
+}
+
+func TestSQLiteMigrationsMatchModels(t *testing.T) {
+	t.Parallel()
+	db, err := setupTestDB()
+	require.NoError(t, err)
+
+	migrator := db.Migrator()
+	for _, model := range append(models.Tables(), &middleware.RateLimitBucket{}) {
+		stmt := &gorm.Statement{DB: db}
+		require.NoError(t, stmt.Parse(model))
+		require.True(t, migrator.HasTable(stmt.Schema.Table), "table %s", stmt.Schema.Table)
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" {
+				assert.True(t, migrator.HasColumn(model, field.DBName), "column %s.%s", stmt.Schema.Table, field.DBName)
+			}
+		}
+	}
+}
+
+func TestDatabaseDialect(t *testing.T) {
+	t.Parallel()
+	for dsn, want := range map[string]string{
+		"postgres://everflown@localhost/everflown":   database.Postgres,
+		"postgresql://everflown@localhost/everflown": database.Postgres,
+		"host=localhost user=everflown":              database.Postgres,
+		"sqlite:everflown.db":                        database.SQLite,
+		"sqlite::memory:":                            database.SQLite,
+	} {
+		got, err := database.Dialect(dsn)
+		require.NoError(t, err, dsn)
+		assert.Equal(t, want, got, dsn)
+	}
+
+	_, err := database.Dialect("mysql://localhost/everflown")
+	assert.Error(t, err)
+}
+
+func TestConnectSQLiteFile(t *testing.T) {
+	t.Parallel()
+	db, err := database.Connect(context.Background(), "sqlite:"+filepath.Join(t.TempDir(), "everflown.db"), database.Options{})
+	require.NoError(t, err)
+	defer database.Close(db)
+	assert.Equal(t, database.SQLite, db.Dialector.Name())
+
+	var foreignKeys int
+	require.NoError(t, db.Raw("PRAGMA foreign_keys").Scan(&foreignKeys).Error)
+	assert.Equal(t, 1, foreignKeys)
+}
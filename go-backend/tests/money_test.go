@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"everflown-logistics/dates"
+	"everflown-logistics/migrations"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestAmountParseAndFormat(t *testing.T) {
+	t.Parallel()
+	for in, cents := range map[string]int64{
+		"1850":       185000,
+		"1850.5":     185050,
+		"0.10":       10,
+		"-12.34":     -1234,
+		"9999999.99": 999999999,
+	} {
+		a, err := money.Parse(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, cents, a.Cents(), in)
+	}
+	for _, in := range []string{"12.345", "abc", "", "1e30", "1e3", "1E3", "1.5e3", "1/4", "-3/2", "+5", ".5", "5.", "0x10"} {
+		_, err := money.Parse(in)
+		assert.Error(t, err, in)
+	}
+
+	assert.Equal(t, "1850.50", money.New(1850, 50).String())
+	assert.Equal(t, "-0.05", money.FromCents(-5).String())
+	assert.Equal(t, "$1,234,567.89", money.FromCents(123456789).Format("USD"))
+	assert.Equal(t, "-$950.00", money.New(-950, 0).Format(""))
+	assert.Equal(t, "EUR 1,850.00", money.New(1850, 0).Format("EUR"))
+}
+
+func TestAmountJSON(t *testing.T) {
+	t.Parallel()
+	var quote models.Quote
+	require.NoError(t, json.Unmarshal([]byte(`{"quotedRate": 0.1, "currency": "CAD"}`), &quote))
+	assert.Equal(t, money.FromCents(10), quote.QuotedRate)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"quotedRate": "2100.25"}`), &quote))
+	assert.Equal(t, money.New(2100, 25), quote.QuotedRate)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"quotedRate": 2100.255}`), &quote), "fractions of a cent are rejected, not rounded")
+	assert.Error(t, json.Unmarshal([]byte(`{"quotedRate": 1e3}`), &quote), "amounts are plain decimals, without exponents")
+
+	body, err := json.Marshal(models.Invoice{Amount: money.New(2400, 0)})
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"amount":2400.00`)
+}
+
+func TestDashboardRevenueIsExact(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	ctx := context.Background()
+
+	customer := models.Customer{CompanyName: "Cents Co", ContactPerson: "Pat", Email: "pat@cents.example", Phone: "555-0100"}
+	require.NoError(t, testDB.Create(&customer).Error)
+	for i, invoice := range []models.Invoice{
+		{Amount: money.FromCents(10)},
+		{Amount: money.FromCents(20)},
+		{Amount: money.New(1850, 55)},
+		{Amount: money.New(4200, 0), Currency: "CAD"},
+	} {
+		invoice.InvoiceNumber = "INV-CENTS-" + string(rune('A'+i))
+		invoice.Type = "customer"
+		invoice.CustomerID = &customer.ID
+		invoice.Status = "paid"
+		invoice.DueDate = dates.MustParseDate("2025-08-01")
+		require.NoError(t, svc.Invoices.Create(ctx, &invoice))
+	}
+
+	stats, err := svc.Dashboard.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, money.New(1850, 85), stats.TotalRevenue)
+	assert.Equal(t, map[string]money.Amount{"USD": money.New(1850, 85), "CAD": money.New(4200, 0)}, stats.RevenueByCurrency)
+
+	err = svc.Invoices.Create(ctx, &models.Invoice{InvoiceNumber: "INV-CENTS-X", Type: "customer", CustomerID: &customer.ID, DueDate: dates.MustParseDate("2025-08-01"), Currency: "dollars"})
+	assert.ErrorContains(t, err, "currency")
+}
+
+func TestMoneyMigrationRoundsToTheCent(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	sqlDB := emptySQLite(t)
+	migrator, err := migrations.New(sqlDB, migrations.SQLite)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx, 1)
+	require.NoError(t, err)
+
+	_, err = sqlDB.Exec(`INSERT INTO orders (order_number, origin_address, origin_city, origin_state, origin_zip_code,
+		destination_address, destination_city, destination_state, destination_zip_code, pickup_date, equipment_type, customer_rate)
+		VALUES ('ORD-LEGACY-1', '1 Main St', 'Dallas', 'TX', '75207', '2 Elm St', 'Atlanta', 'GA', '30318', '2025-07-01', 'Dry Van', 1999.999)`)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+
+	db, err := gorm.Open(sqlite.New(sqlite.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+	var order models.Order
+	require.NoError(t, db.Where("order_number = ?", "ORD-LEGACY-1").First(&order).Error)
+	assert.Equal(t, money.New(2000, 0), order.CustomerRate)
+	assert.Equal(t, money.DefaultCurrency, order.Currency)
+}
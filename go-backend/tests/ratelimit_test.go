@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"everflown-logistics/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimit(t *testing.T) {
+	limit, err := middleware.ParseLimit("100/1m")
+	require.NoError(t, err)
+	assert.Equal(t, middleware.Limit{Requests: 100, Window: time.Minute}, limit)
+
+	limit, err = middleware.ParseLimit("5/s")
+	require.NoError(t, err)
+	assert.Equal(t, middleware.Limit{Requests: 5, Window: time.Second}, limit)
+
+	for _, bad := range []string{"", "100", "0/1m", "x/1m", "10/forever"} {
+		_, err := middleware.ParseLimit(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := middleware.NewRateLimiter(middleware.NewMemoryRateLimitStore(), map[string]middleware.Limit{
+		"api": {Requests: 2, Window: time.Minute},
+	})
+	router := gin.New()
+	require.NoError(t, router.SetTrustedProxies(nil))
+	router.Use(middleware.Identity(""))
+	router.GET("/api/leads", limiter.Middleware("api"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	do := func(userID string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/api/leads", nil)
+		req.RemoteAddr = "192.0.2.10:41000"
+		if userID != "" {
+			req.Header.Set(middleware.UserIDHeader, userID)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("shipper-a")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "2;w=60", w.Header().Get("RateLimit-Policy"))
+
+	assert.Equal(t, http.StatusOK, do("shipper-a").Code)
+
+	w = do("shipper-a")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+
+	// Other clients have their own bucket.
+	assert.Equal(t, http.StatusOK, do("shipper-b").Code)
+	assert.Equal(t, http.StatusOK, do("").Code)
+
+	// Anonymous clients are known by their IP, which an untrusted caller
+	// can't change with X-Forwarded-For.
+	assert.Equal(t, http.StatusOK, do("").Code)
+	req, _ := http.NewRequest("GET", "/api/leads", nil)
+	req.RemoteAddr = "192.0.2.10:41001"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
+
+func TestRateLimitUnconfiguredGroup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := middleware.NewRateLimiter(middleware.NewMemoryRateLimitStore(), nil)
+	router := gin.New()
+	router.GET("/health", limiter.Middleware("health"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest("GET", "/health", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestMemoryRateLimitStoreRefills(t *testing.T) {
+	store := middleware.NewMemoryRateLimitStore()
+	limit := middleware.Limit{Requests: 1, Window: time.Second}
+	now := time.Now()
+	ctx := context.Background()
+
+	result, err := store.Take(ctx, "k", limit, now)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, _ = store.Take(ctx, "k", limit, now.Add(500*time.Millisecond))
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+
+	result, _ = store.Take(ctx, "k", limit, now.Add(time.Second))
+	assert.True(t, result.Allowed)
+}
+
+func TestDatabaseRateLimitStore(t *testing.T) {
+	db, err := setupTestDB()
+	require.NoError(t, err)
+	store := middleware.NewPostgresRateLimitStore(db)
+
+	limit := middleware.Limit{Requests: 2, Window: time.Minute}
+	now := time.Now()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		result, err := store.Take(ctx, "api:ip:10.0.0.1", limit, now)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	}
+	result, err := store.Take(ctx, "api:ip:10.0.0.1", limit, now)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+}
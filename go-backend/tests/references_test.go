@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"everflown-logistics/dates"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"everflown-logistics/repository"
+	"everflown-logistics/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteCustomerWithOrdersConflicts(t *testing.T) {
+	t.Parallel()
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+	seedOrderAndCarrier(t, testDB)
+	customer := models.Customer{CompanyName: "Busy Co", ContactPerson: "Kim", Email: "kim@busy.example", Phone: "555-0101"}
+	require.NoError(t, testDB.Create(&customer).Error)
+	require.NoError(t, testDB.Model(&models.Order{}).Where("id = 1").Update("customer_id", customer.ID).Error)
+
+	router := setupTestRouter(newTestHandler(testDB))
+	req, _ := http.NewRequest(http.MethodDelete, "/api/customers/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var body struct {
+		Error      string                 `json:"error"`
+		Dependents []repository.Dependent `json:"dependents"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, []repository.Dependent{{Table: "orders", Count: 1, IDs: []uint{1}}}, body.Dependents)
+	assert.Contains(t, body.Error, "customers 1")
+
+	var count int64
+	testDB.Model(&models.Customer{}).Count(&count)
+	assert.Equal(t, int64(1), count, "nothing is deleted")
+}
+
+func TestDeleteRulesPerRelation(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	seedOrderAndCarrier(t, testDB)
+	ctx := context.Background()
+
+	dispatch := models.Dispatch{OrderID: 1, CarrierID: 1, CarrierRate: money.New(1800, 0)}
+	require.NoError(t, svc.Dispatches.Create(ctx, &dispatch))
+	require.NoError(t, svc.FollowUps.Create(ctx, &models.FollowUp{Title: "Check in", Type: "call", OrderID: uintPtr(1), DueDate: time.Now()}))
+	invoice := models.Invoice{InvoiceNumber: "INV-FK-1", Type: "carrier", CarrierID: uintPtr(1), DispatchID: &dispatch.ID, Amount: money.New(1800, 0), DueDate: dates.MustParseDate("2025-08-01")}
+	require.NoError(t, svc.Invoices.Create(ctx, &invoice))
+
+	// The invoice blocks the order through the dispatch the delete would cascade to.
+	err := svc.Orders.Delete(ctx, 1)
+	var dependentsErr *services.DependentsError
+	require.ErrorAs(t, err, &dependentsErr)
+	assert.Equal(t, []repository.Dependent{{Table: "invoices", Count: 1, IDs: []uint{invoice.ID}}}, dependentsErr.Dependents)
+
+	require.NoError(t, svc.Invoices.Delete(ctx, invoice.ID))
+	require.NoError(t, svc.Orders.Delete(ctx, 1))
+	_, err = svc.Dispatches.Get(ctx, dispatch.ID)
+	assert.ErrorIs(t, err, services.ErrNotFound, "dispatches are deleted with their order")
+	var followUps int64
+	testDB.Model(&models.FollowUp{}).Count(&followUps)
+	assert.Zero(t, followUps, "follow-ups are deleted with their order")
+
+	lead := models.Lead{CompanyName: "Prospect", ContactPerson: "Lee", Email: "lee@prospect.example", Phone: "555-0102"}
+	require.NoError(t, svc.Leads.Create(ctx, &lead))
+	quote := models.Quote{QuoteNumber: "QT-FK-1", LeadID: &lead.ID, OriginCity: "Dallas", OriginState: "TX", DestinationCity: "Atlanta", DestinationState: "GA",
+		EquipmentType: "Dry Van", QuotedRate: money.New(2100, 0), ValidUntil: dates.MustParseDate("2025-08-01")}
+	require.NoError(t, svc.Quotes.Create(ctx, &quote))
+	require.NoError(t, svc.Leads.Delete(ctx, lead.ID))
+	stored, err := svc.Quotes.Get(ctx, quote.ID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.LeadID, "quotes outlive their lead")
+}
+
+func TestWritesToMissingRecordsAreRejected(t *testing.T) {
+	t.Parallel()
+	svc, _ := setupServices(t)
+
+	err := svc.FollowUps.Create(context.Background(), &models.FollowUp{Title: "Call", Type: "call", CustomerID: uintPtr(42), DueDate: time.Now()})
+	assert.ErrorIs(t, err, services.ErrInvalidReference)
+}
+
+func TestMigrationsMatchModelReferences(t *testing.T) {
+	t.Parallel()
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+
+	for _, ref := range repository.References() {
+		var keys []struct {
+			Table    string
+			From     string
+			To       string
+			OnDelete string `gorm:"column:on_delete"`
+		}
+		require.NoError(t, testDB.Raw("SELECT * FROM pragma_foreign_key_list(?)", ref.Table).Scan(&keys).Error)
+		found := false
+		for _, key := range keys {
+			if key.From == ref.Column {
+				found = true
+				assert.Equal(t, ref.Parent, key.Table, "%s.%s", ref.Table, ref.Column)
+				assert.Equal(t, ref.OnDelete, key.OnDelete, "%s.%s", ref.Table, ref.Column)
+			}
+		}
+		assert.True(t, found, "no foreign key on %s.%s", ref.Table, ref.Column)
+	}
+	assert.Len(t, repository.References(), 26)
+}
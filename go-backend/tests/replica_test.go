@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"context"
+	"database/sql/driver"
+	"path/filepath"
+	"testing"
+
+	"everflown-logistics/database"
+	"everflown-logistics/dates"
+	"everflown-logistics/migrations"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"everflown-logistics/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setupReplicatedDB returns a primary and a "replica" as two separate SQLite
+// files, so tests can tell which one a query ran on.
+func setupReplicatedDB(t *testing.T) (primary, replica *gorm.DB) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	open := func(name string) *gorm.DB {
+		db, err := database.Connect(ctx, "sqlite:"+filepath.Join(dir, name), database.Options{})
+		require.NoError(t, err)
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		migrator, err := migrations.New(sqlDB, migrations.SQLite)
+		require.NoError(t, err)
+		_, err = migrator.Up(ctx, 0)
+		require.NoError(t, err)
+		return db
+	}
+	primary, replica = open("primary.db"), open("replica.db")
+	t.Cleanup(func() {
+		database.Close(primary)
+		database.Close(replica)
+	})
+
+	require.NoError(t, database.UseReplica(ctx, primary, "sqlite:"+filepath.Join(dir, "replica.db"), database.Options{}))
+	assert.True(t, database.HasReplica(primary))
+	return primary, replica
+}
+
+func pendingQuote(number string) *models.Quote {
+	return &models.Quote{QuoteNumber: number, OriginCity: "Dallas", OriginState: "TX", DestinationCity: "Atlanta", DestinationState: "GA", EquipmentType: "Dry Van", QuotedRate: money.New(1850, 0), ValidUntil: dates.MustParseDate("2025-08-01"), Status: "pending"}
+}
+
+func TestReportingReadsUseReplica(t *testing.T) {
+	t.Parallel()
+	primary, replica := setupReplicatedDB(t)
+	ctx := context.Background()
+	repos := repository.New(primary)
+
+	require.NoError(t, repos.Quotes.Create(ctx, pendingQuote("QTE-PRIMARY-1")))
+	require.NoError(t, replica.Create(pendingQuote("QTE-REPLICA-1")).Error)
+	require.NoError(t, replica.Create(pendingQuote("QTE-REPLICA-2")).Error)
+
+	stats, err := repos.Dashboard.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stats.PendingQuotes, "dashboard stats come from the replica")
+
+	quotes, err := repos.Quotes.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, quotes, 1, "ordinary reads stay on the primary")
+	assert.Equal(t, "QTE-PRIMARY-1", quotes[0].QuoteNumber)
+
+	err = repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		stats, err := tx.Dashboard.Stats(ctx)
+		assert.Equal(t, int64(1), stats.PendingQuotes, "reads inside a transaction stay on the primary")
+		return err
+	})
+	require.NoError(t, err)
+}
+
+func TestReportingFallsBackToPrimary(t *testing.T) {
+	t.Parallel()
+	primary, _ := setupReplicatedDB(t)
+	ctx := context.Background()
+	repos := repository.New(primary).WithReadRetry(fastRetry)
+	require.NoError(t, repos.Quotes.Create(ctx, pendingQuote("QTE-PRIMARY-1")))
+
+	primaryPool, err := primary.DB()
+	require.NoError(t, err)
+	replicaQueries := 0
+	require.NoError(t, primary.Callback().Query().Before("gorm:query").Register("test:replica_down", func(tx *gorm.DB) {
+		if tx.Statement.ConnPool != primaryPool {
+			replicaQueries++
+			tx.AddError(driver.ErrBadConn)
+		}
+	}))
+
+	stats, err := repos.Dashboard.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stats.PendingQuotes)
+	assert.NotZero(t, replicaQueries, "the replica was tried first")
+}
@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"everflown-logistics/models"
+	"everflown-logistics/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var seedStart = time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+func seededOrders(t *testing.T, db *gorm.DB) []models.Order {
+	var orders []models.Order
+	require.NoError(t, db.Order("id").Find(&orders).Error)
+	return orders
+}
+
+func TestSeedCreatesLinkedData(t *testing.T) {
+	t.Parallel()
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+
+	counts, err := seed.Run(context.Background(), testDB, seed.Options{Orders: 200, Seed: 7, Start: seedStart})
+	require.NoError(t, err)
+	assert.Equal(t, 200, counts.Orders)
+	assert.NotZero(t, counts.Dispatches)
+	assert.NotZero(t, counts.Invoices)
+	assert.NotZero(t, counts.Quotes)
+
+	var orphans int64
+	for _, query := range []string{
+		"SELECT COUNT(*) FROM orders WHERE customer_id NOT IN (SELECT id FROM customers)",
+		"SELECT COUNT(*) FROM dispatches WHERE order_id NOT IN (SELECT id FROM orders) OR carrier_id NOT IN (SELECT id FROM carriers)",
+		"SELECT COUNT(*) FROM invoices WHERE order_id NOT IN (SELECT id FROM orders) OR dispatch_id NOT IN (SELECT id FROM dispatches)",
+		"SELECT COUNT(*) FROM quotes WHERE lead_id IS NULL AND customer_id IS NULL",
+	} {
+		require.NoError(t, testDB.Raw(query).Scan(&orphans).Error)
+		assert.Zero(t, orphans, query)
+	}
+
+	// Statuses follow the dates: nothing picked up after the start date.
+	var early int64
+	require.NoError(t, testDB.Model(&models.Order{}).
+		Where("status IN ? AND pickup_date > ?", []string{"picked_up", "in_transit", "delivered"}, "2025-07-01").
+		Count(&early).Error)
+	assert.Zero(t, early)
+}
+
+func TestSeedIsIdempotentAndReproducible(t *testing.T) {
+	t.Parallel()
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+	ctx := context.Background()
+	opts := seed.Options{Orders: 50, Seed: 3, Start: seedStart}
+
+	first, err := seed.Run(ctx, testDB, opts)
+	require.NoError(t, err)
+	orders := seededOrders(t, testDB)
+
+	_, err = seed.Run(ctx, testDB, opts)
+	assert.ErrorIs(t, err, seed.ErrAlreadySeeded)
+	assert.Len(t, seededOrders(t, testDB), 50)
+
+	opts.Reset = true
+	again, err := seed.Run(ctx, testDB, opts)
+	require.NoError(t, err)
+	assert.Equal(t, first, again)
+	assert.Equal(t, orders, seededOrders(t, testDB), "the same seed reproduces the same records and IDs")
+
+	opts.Seed = 4
+	_, err = seed.Run(ctx, testDB, opts)
+	require.NoError(t, err)
+	assert.NotEqual(t, orders, seededOrders(t, testDB))
+}
@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"everflown-logistics/dates"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"everflown-logistics/repository"
+	"everflown-logistics/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupServices(t *testing.T) (*services.Services, *gorm.DB) {
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+	return services.New(repository.New(testDB), services.DefaultBranding), testDB
+}
+
+func TestDispatchWorkflowUpdatesOrder(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	seedOrderAndCarrier(t, testDB)
+	ctx := context.Background()
+
+	dispatch := models.Dispatch{OrderID: 1, CarrierID: 1, CarrierRate: money.New(1800, 0)}
+	require.NoError(t, svc.Dispatches.Create(ctx, &dispatch))
+
+	order, err := svc.Orders.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "dispatched", order.Status)
+
+	_, err = svc.Dispatches.Update(ctx, dispatch.ID, &models.Dispatch{Status: "delivered"})
+	require.NoError(t, err)
+
+	order, err = svc.Orders.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "delivered", order.Status)
+}
+
+func TestDispatchRequiresExistingCarrier(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	seedOrderAndCarrier(t, testDB)
+	ctx := context.Background()
+
+	err := svc.Dispatches.Create(ctx, &models.Dispatch{OrderID: 1, CarrierID: 99, CarrierRate: money.New(1800, 0)})
+	var validationErr *services.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "carrierId", validationErr.Field)
+
+	var count int64
+	testDB.Model(&models.Dispatch{}).Count(&count)
+	assert.Zero(t, count)
+	order, err := svc.Orders.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "needs_truck", order.Status, "rejected dispatch leaves the order untouched")
+}
+
+func TestQuoteAcceptanceConvertsLead(t *testing.T) {
+	t.Parallel()
+	svc, _ := setupServices(t)
+	ctx := context.Background()
+
+	lead := models.Lead{CompanyName: "Prospect Co", Email: "ops@prospect.com"}
+	require.NoError(t, svc.Leads.Create(ctx, &lead))
+	quote := models.Quote{
+		QuoteNumber:      "QTE-ACCEPT-1",
+		LeadID:           &lead.ID,
+		OriginCity:       "Phoenix",
+		OriginState:      "AZ",
+		DestinationCity:  "Reno",
+		DestinationState: "NV",
+		EquipmentType:    "Reefer",
+		QuotedRate:       money.New(2100, 0),
+		ValidUntil:       dates.MustParseDate("2025-08-01"),
+	}
+	require.NoError(t, svc.Quotes.Create(ctx, &quote))
+
+	_, err := svc.Quotes.Update(ctx, quote.ID, &models.Quote{Status: "accepted"})
+	require.NoError(t, err)
+
+	stored, err := svc.Leads.Get(ctx, lead.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "converted", stored.Status)
+}
+
+func TestInvoicePaymentRecordsPaidDate(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	seedOrderAndCarrier(t, testDB)
+	ctx := context.Background()
+
+	customer := models.Customer{CompanyName: "Billing Co", Email: "ap@billing.com"}
+	require.NoError(t, svc.Customers.Create(ctx, &customer))
+	invoice := models.Invoice{InvoiceNumber: "INV-PAY-1", Type: "customer", CustomerID: &customer.ID, Amount: money.New(2400, 0), DueDate: dates.MustParseDate("2025-08-01")}
+	require.NoError(t, svc.Invoices.Create(ctx, &invoice))
+	assert.Nil(t, invoice.PaidDate)
+
+	paid, err := svc.Invoices.Update(ctx, invoice.ID, &models.Invoice{Status: "paid"})
+	require.NoError(t, err)
+	require.NotNil(t, paid.PaidDate)
+	assert.NotEmpty(t, *paid.PaidDate)
+}
+
+func TestValidationErrorsReturnBadRequest(t *testing.T) {
+	t.Parallel()
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	h := newTestHandler(testDB)
+	router.POST("/api/leads", h.CreateLead)
+	router.POST("/api/invoices", h.CreateInvoice)
+
+	for _, tc := range []struct{ path, body, field string }{
+		{"/api/leads", `{"companyName": "No Email Co"}`, "email"},
+		{"/api/leads", `{"companyName": "Bad Status Co", "email": "a@b.com", "status": "maybe"}`, "status"},
+		{"/api/invoices", `{"invoiceNumber": "INV-1", "type": "vendor", "dueDate": "2025-08-01"}`, "type"},
+	} {
+		req, _ := http.NewRequest("POST", tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, tc.body)
+		assert.Contains(t, w.Body.String(), `"field":"`+tc.field+`"`, tc.body)
+	}
+}
+
+func TestRegisterAndLogin(t *testing.T) {
+	t.Parallel()
+	svc, _ := setupServices(t)
+	ctx := context.Background()
+
+	_, err := svc.Users.Register(ctx, services.RegisterInput{Username: "dana", Password: "s3cret", Email: "dana@everflown.com"})
+	require.NoError(t, err)
+
+	_, err = svc.Users.Register(ctx, services.RegisterInput{Username: "dana", Password: "other", Email: "dana2@everflown.com"})
+	assert.ErrorIs(t, err, services.ErrUsernameTaken)
+
+	user, err := svc.Users.Login(ctx, "dana", "s3cret")
+	require.NoError(t, err)
+	assert.Equal(t, "user", user.Role)
+
+	_, err = svc.Users.Login(ctx, "dana", "wrong")
+	assert.ErrorIs(t, err, services.ErrInvalidCredentials)
+}
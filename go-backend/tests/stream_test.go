@@ -0,0 +1,265 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"everflown-logistics/audit"
+	"everflown-logistics/dates"
+	"everflown-logistics/handlers"
+	"everflown-logistics/middleware"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"everflown-logistics/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// received drains the events delivered to sub so far.
+func received(sub *services.StreamSubscription) []services.StreamEvent {
+	var events []services.StreamEvent
+	for {
+		select {
+		case event, ok := <-sub.Events:
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func streamOrder(number string) *models.Order {
+	return &models.Order{OrderNumber: number, OriginCity: "Dallas", OriginState: "TX", DestinationCity: "Atlanta",
+		DestinationState: "GA", PickupDate: dates.MustParseDate("2026-03-02"), EquipmentType: "Dry Van",
+		CustomerRate: money.New(2400, 0)}
+}
+
+func TestStreamFiltersByRoleTenantAndCustomer(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	acme := models.Customer{CompanyName: "Acme Mills", ContactPerson: "Ann", Email: "ann@acme.example", Phone: "555-0101"}
+	globex := models.Customer{CompanyName: "Globex", ContactPerson: "Hank", Email: "hank@globex.example", Phone: "555-0102"}
+	require.NoError(t, testDB.Create(&acme).Error)
+	require.NoError(t, testDB.Create(&globex).Error)
+	// Who made a change doesn't decide who sees it.
+	ctx := audit.WithActor(context.Background(), audit.Actor{UserID: "user-1"})
+	require.NoError(t, svc.Orders.Create(ctx, streamOrder("ORD-STR-0")))
+	require.NoError(t, svc.Stream.Poll(ctx))
+
+	broker, err := svc.Stream.Subscribe(ctx, services.StreamFilterFor("broker", "", nil))
+	require.NoError(t, err)
+	acmeUser, err := svc.Stream.Subscribe(ctx, services.StreamFilterFor("user", "", &acme.ID))
+	require.NoError(t, err)
+	globexBroker, err := svc.Stream.Subscribe(ctx, services.StreamFilterFor("broker", "", &globex.ID))
+	require.NoError(t, err)
+	tenantBroker, err := svc.Stream.Subscribe(ctx, services.StreamFilterFor("broker", "initech", nil))
+	require.NoError(t, err)
+	assert.Empty(t, services.StreamFilterFor("guest", "", nil).Entities, "unknown roles see nothing")
+
+	order := streamOrder("ORD-STR-1")
+	order.CustomerID = &acme.ID
+	require.NoError(t, svc.Orders.Create(ctx, order))
+	require.NoError(t, testDB.Create(&models.Carrier{CompanyName: "Haul Co", ContactPerson: "Max",
+		Email: "max@haul.example", Phone: "555-0111"}).Error)
+	dispatch := models.Dispatch{OrderID: order.ID, CarrierID: 1, CarrierRate: money.New(1800, 0)}
+	require.NoError(t, svc.Dispatches.Create(ctx, &dispatch))
+	followUp := models.FollowUp{Title: "Call shipper", Type: "call", DueDate: time.Now(), OrderID: &order.ID}
+	require.NoError(t, svc.FollowUps.Create(ctx, &followUp))
+	other := streamOrder("ORD-STR-2")
+	other.CustomerID = &globex.ID
+	require.NoError(t, svc.Orders.Create(ctx, other))
+	tenantCtx := audit.WithActor(context.Background(), audit.Actor{UserID: "user-2", TenantID: "initech"})
+	require.NoError(t, svc.Orders.Create(tenantCtx, streamOrder("ORD-STR-3")))
+	require.NoError(t, svc.Stream.Poll(ctx))
+
+	entities := func(events []services.StreamEvent) []string {
+		var names []string
+		for _, e := range events {
+			names = append(names, e.Entity+":"+e.EntityID)
+		}
+		return names
+	}
+	// Dispatching the order also updates its status.
+	assert.Equal(t, []string{"orders:2", "dispatches:1", "orders:2", "follow_ups:1", "orders:3"}, entities(received(broker)),
+		"staff see every order, dispatch and follow-up without a tenant, but not older changes")
+	assert.Equal(t, []string{"orders:4"}, entities(received(tenantBroker)), "a tenant only sees changes made for it")
+	assert.Equal(t, []string{"orders:2", "dispatches:1", "orders:2"}, entities(received(acmeUser)),
+		"the customer's own order and its dispatch; follow-ups are for brokers")
+	globexEvents := received(globexBroker)
+	require.Len(t, globexEvents, 1)
+	assert.Equal(t, "orders", globexEvents[0].Entity)
+	assert.Equal(t, "user-1", globexEvents[0].UserID)
+	assert.Contains(t, globexEvents[0].Changes, "orderNumber")
+
+	// Closing for shutdown ends every stream.
+	svc.Stream.Close()
+	_, open := <-broker.Events
+	assert.False(t, open)
+	_, err = svc.Stream.Subscribe(ctx, services.StreamFilterFor("broker", "", nil))
+	assert.ErrorIs(t, err, services.ErrStreamClosed)
+}
+
+func TestStreamDeliversLateCommits(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	ctx := context.Background()
+	require.NoError(t, svc.Stream.Poll(ctx))
+	sub, err := svc.Stream.Subscribe(ctx, services.StreamFilterFor("admin", "", nil))
+	require.NoError(t, err)
+
+	// A transaction took ID 1 but commits after the one that took ID 2.
+	entry := func(id uint) *models.AuditEntry {
+		return &models.AuditEntry{ID: id, OccurredAt: time.Now().UTC(), UserID: "system", Entity: "orders",
+			EntityID: strconv.Itoa(int(id)), Action: "create"}
+	}
+	require.NoError(t, testDB.Create(entry(2)).Error)
+	require.NoError(t, svc.Stream.Poll(ctx))
+	require.NoError(t, testDB.Create(entry(1)).Error)
+	require.NoError(t, testDB.Create(entry(3)).Error)
+	require.NoError(t, svc.Stream.Poll(ctx))
+
+	events := received(sub)
+	require.Len(t, events, 3)
+	var ids, positions []uint
+	for _, event := range events {
+		ids, positions = append(ids, event.ID), append(positions, event.Position)
+	}
+	assert.Equal(t, []uint{2, 1, 3}, ids)
+	assert.Equal(t, []uint{0, 2, 3}, positions, "the position stays below the gap until it fills")
+
+	// Resuming from before the gap filled replays both sides of it.
+	resumed, err := svc.Stream.Resume(ctx, services.StreamFilterFor("admin", "", nil), positions[0])
+	require.NoError(t, err)
+	require.Len(t, resumed.Backlog, 3)
+	assert.Equal(t, uint(1), resumed.Backlog[0].ID)
+	assert.Equal(t, uint(3), resumed.Head)
+}
+
+func TestStreamResumesAfterLastEventID(t *testing.T) {
+	t.Parallel()
+	svc, _ := setupServices(t)
+	ctx := context.Background()
+	filter := services.StreamFilterFor("admin", "", nil)
+
+	first, err := svc.Stream.Subscribe(ctx, filter)
+	require.NoError(t, err)
+	require.NoError(t, svc.Orders.Create(ctx, streamOrder("ORD-STR-1")))
+	require.NoError(t, svc.Stream.Poll(ctx))
+	seen := received(first)
+	require.Len(t, seen, 1)
+	svc.Stream.Unsubscribe(first)
+
+	// Changes made while disconnected are replayed on resuming.
+	require.NoError(t, svc.Orders.Create(ctx, streamOrder("ORD-STR-2")))
+	_, err = svc.Orders.Update(ctx, 2, &models.Order{OriginCompany: stringPtr("Acme Mills")})
+	require.NoError(t, err)
+	require.NoError(t, svc.Stream.Poll(ctx))
+
+	resumed, err := svc.Stream.Resume(ctx, filter, seen[0].Position)
+	require.NoError(t, err)
+	assert.False(t, resumed.Reset)
+	require.Len(t, resumed.Backlog, 2)
+	assert.Equal(t, "create", resumed.Backlog[0].Action)
+	assert.Equal(t, "update", resumed.Backlog[1].Action)
+	assert.Equal(t, resumed.Backlog[1].ID, resumed.Head)
+	assert.Empty(t, received(resumed), "replayed changes are not delivered again")
+}
+
+func TestStreamEndpoint(t *testing.T) {
+	t.Parallel()
+	svc, _ := setupServices(t)
+	h := handlers.New(svc)
+	ctx := context.Background()
+	require.NoError(t, svc.Stream.Poll(ctx))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.Identity(""))
+	router.GET("/api/stream", h.Stream(20*time.Millisecond))
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	open := func(t *testing.T, role, lastEventID string) (*http.Response, func() (string, string, string)) {
+		reqCtx, cancel := context.WithCancel(ctx)
+		t.Cleanup(cancel)
+		req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, server.URL+"/api/stream", nil)
+		require.NoError(t, err)
+		req.Header.Set(middleware.UserRoleHeader, role)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		lines := bufio.NewScanner(resp.Body)
+		// next returns the id, event and data of the next event, skipping
+		// heartbeats unless they are all there is.
+		next := func() (id, event, data string) {
+			for lines.Scan() {
+				line := lines.Text()
+				switch {
+				case line == "" && (event != "" || id != ""):
+					return id, event, data
+				case strings.HasPrefix(line, "id: "):
+					id = strings.TrimPrefix(line, "id: ")
+				case strings.HasPrefix(line, "event: "):
+					event = strings.TrimPrefix(line, "event: ")
+				case strings.HasPrefix(line, "data: "):
+					data = strings.TrimPrefix(line, "data: ")
+				case strings.HasPrefix(line, ": "):
+					return "", "heartbeat", ""
+				}
+			}
+			return "", "", ""
+		}
+		return resp, next
+	}
+
+	resp, _ := open(t, "", "")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp, _ = open(t, "broker", "latest")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, next := open(t, "broker", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	readyID, event, _ := next()
+	require.Equal(t, "ready", event)
+
+	require.NoError(t, svc.Orders.Create(ctx, streamOrder("ORD-STR-1")))
+	require.NoError(t, svc.Stream.Poll(ctx))
+	id, event, data := next()
+	for event == "heartbeat" {
+		id, event, data = next()
+	}
+	require.Equal(t, "change", event)
+	var change services.StreamEvent
+	require.NoError(t, json.Unmarshal([]byte(data), &change))
+	assert.Equal(t, strconv.Itoa(int(change.ID)), id, "with nothing in flight, the position is the change")
+	assert.Equal(t, "orders", change.Entity)
+	assert.Equal(t, "create", change.Action)
+
+	_, event, _ = next()
+	assert.Equal(t, "heartbeat", event, "an idle stream gets heartbeats")
+
+	// Reconnecting from the ready event replays the change.
+	resp, next = open(t, "broker", readyID)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	replayedID, event, _ := next()
+	assert.Equal(t, "change", event)
+	assert.Equal(t, id, replayedID)
+	resumeID, event, _ := next()
+	assert.Equal(t, "ready", event)
+	assert.Equal(t, id, resumeID)
+}
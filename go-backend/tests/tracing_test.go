@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"everflown-logistics/services"
+	"everflown-logistics/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setupTracing(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return recorder
+}
+
+func spanNamed(spans []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+	for _, span := range spans {
+		if span.Name() == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func TestTracingRequestAndQuerySpans(t *testing.T) {
+	recorder := setupTracing(t)
+
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+	require.NoError(t, testDB.Use(&tracing.GormPlugin{}))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(otelgin.Middleware(tracing.ServiceName, otelgin.WithFilter(tracing.SkipProbes)))
+	router.GET("/api/leads", newTestHandler(testDB).GetLeads)
+	router.GET("/health/live", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req, _ := http.NewRequest("GET", "/api/leads", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	req, _ = http.NewRequest("GET", "/health/live", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	httpSpan := spanNamed(spans, "/api/leads")
+	require.NotNil(t, httpSpan)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", httpSpan.SpanContext().TraceID().String())
+
+	querySpan := spanNamed(spans, "gorm.query leads")
+	require.NotNil(t, querySpan)
+	assert.Equal(t, httpSpan.SpanContext().SpanID(), querySpan.Parent().SpanID())
+}
+
+func TestTracingPDFSpan(t *testing.T) {
+	recorder := setupTracing(t)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+	quote := models.Quote{QuoteNumber: "QTE-TRACE-001", OriginCity: "Dallas", OriginState: "TX", DestinationCity: "Atlanta", DestinationState: "GA", EquipmentType: "Dry Van", QuotedRate: money.New(1850, 0)}
+	pdf, err := services.NewPDFService(services.DefaultBranding).GenerateQuotePDF(ctx, quote, models.Lead{CompanyName: "Trace Co"})
+	parent.End()
+	require.NoError(t, err)
+	assert.NotEmpty(t, pdf)
+
+	span := spanNamed(recorder.Ended(), "PDFService.GenerateQuotePDF")
+	require.NotNil(t, span)
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+}
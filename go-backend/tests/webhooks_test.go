@@ -0,0 +1,402 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"everflown-logistics/dates"
+	"everflown-logistics/events"
+	"everflown-logistics/handlers"
+	"everflown-logistics/middleware"
+	"everflown-logistics/models"
+	"everflown-logistics/money"
+	"everflown-logistics/repository"
+	"everflown-logistics/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// webhookReceiver is an endpoint that records the webhooks it receives and
+// answers with status.
+type webhookReceiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	status   int
+	received []receivedWebhook
+}
+
+type receivedWebhook struct {
+	path   string
+	header http.Header
+	body   []byte
+}
+
+func newWebhookReceiver(t *testing.T) *webhookReceiver {
+	r := &webhookReceiver{status: http.StatusNoContent}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.received = append(r.received, receivedWebhook{path: req.URL.Path, header: req.Header, body: body})
+		w.WriteHeader(r.status)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *webhookReceiver) respondWith(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+func (r *webhookReceiver) take() []receivedWebhook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	received := r.received
+	r.received = nil
+	return received
+}
+
+// makeDeliveriesDue makes every pending delivery due for another attempt now.
+func makeDeliveriesDue(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	require.NoError(t, db.Model(&models.WebhookDelivery{}).Where("status = ?", models.DeliveryPending).
+		Update("next_attempt_at", time.Now().UTC().Add(-time.Second)).Error)
+}
+
+func TestWebhooksAreSignedAndScopedToTheCustomer(t *testing.T) {
+	t.Parallel()
+	testDB, err := setupTestDB()
+	require.NoError(t, err)
+	seedOrderAndCarrier(t, testDB)
+	repos := repository.New(testDB)
+	svc := services.New(repos, services.DefaultBranding)
+	// The receiver listens on loopback.
+	svc.Webhooks.AllowPrivateNetworks = true
+	ctx := context.Background()
+	receiver := newWebhookReceiver(t)
+
+	var customers [2]models.Customer
+	for i := range customers {
+		customers[i] = models.Customer{CompanyName: "Shipper " + strconv.Itoa(i), ContactPerson: "Pat",
+			Email: "pat" + strconv.Itoa(i) + "@shipper.example", Phone: "555-0110"}
+		require.NoError(t, svc.Customers.Create(ctx, &customers[i]))
+	}
+	own := models.WebhookSubscription{CustomerID: &customers[0].ID, URL: receiver.URL + "/own"}
+	other := models.WebhookSubscription{CustomerID: &customers[1].ID, URL: receiver.URL + "/other"}
+	client := models.WebhookSubscription{ClientName: stringPtr("tms"), URL: receiver.URL + "/client",
+		EventTypes: models.StringList{events.DispatchStatusChanged}}
+	for _, s := range []*models.WebhookSubscription{&own, &other, &client} {
+		require.NoError(t, svc.Webhooks.Create(ctx, s))
+		assert.True(t, strings.HasPrefix(s.Secret, "whsec_"), "the secret is returned on create")
+	}
+	listed, err := svc.Webhooks.List(ctx)
+	require.NoError(t, err)
+	for _, s := range listed {
+		assert.Empty(t, s.Secret, "the secret is not returned again")
+	}
+
+	dispatcher := events.NewDispatcher(repos.Outbox, events.Options{PollInterval: time.Hour, MaxAttempts: 3})
+	dispatcher.Subscribe("webhooks", svc.Webhooks.QueueEvent)
+	require.NoError(t, dispatcher.Register(ctx))
+
+	order := models.Order{OrderNumber: "ORD-WH-1", CustomerID: &customers[0].ID, OriginCity: "Dallas", OriginState: "TX",
+		DestinationCity: "Atlanta", DestinationState: "GA", PickupDate: dates.MustParseDate("2026-03-02"),
+		EquipmentType: "Dry Van", CustomerRate: money.New(2400, 0)}
+	require.NoError(t, svc.Orders.Create(ctx, &order))
+	dispatch := models.Dispatch{OrderID: order.ID, CarrierID: 1, CarrierRate: money.New(1800, 0)}
+	require.NoError(t, svc.Dispatches.Create(ctx, &dispatch))
+	_, err = svc.Dispatches.Update(ctx, dispatch.ID, &models.Dispatch{Status: "picked_up"})
+	require.NoError(t, err)
+	require.NoError(t, dispatcher.Deliver(ctx))
+
+	policy := services.WebhookPolicy{Timeout: 5 * time.Second, MaxAttempts: 3, DisableAfter: 10}
+	sent, err := svc.Webhooks.SendDue(ctx, policy)
+	require.NoError(t, err)
+	assert.Equal(t, 3, sent)
+
+	byPath := map[string][]string{}
+	for _, r := range receiver.take() {
+		byPath[r.path] = append(byPath[r.path], r.header.Get(services.WebhookEventHeader))
+
+		secret := own.Secret
+		if r.path == "/client" {
+			secret = client.Secret
+		}
+		signature := r.header.Get(services.WebhookSignatureHeader)
+		timestamp, err := strconv.ParseInt(strings.TrimPrefix(strings.Split(signature, ",")[0], "t="), 10, 64)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), time.Unix(timestamp, 0), time.Minute)
+		assert.Equal(t, services.SignWebhook(secret, timestamp, r.body), signature)
+		assert.Equal(t, "application/json", r.header.Get("Content-Type"))
+
+		var body struct {
+			ID   uint            `json:"id"`
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(r.body, &body))
+		assert.NotZero(t, body.ID)
+		assert.Equal(t, r.header.Get(services.WebhookEventHeader), body.Type)
+		if body.Type == events.DispatchStatusChanged {
+			var data struct {
+				Dispatch map[string]json.RawMessage `json:"dispatch"`
+			}
+			require.NoError(t, json.Unmarshal(body.Data, &data))
+			assert.JSONEq(t, `"picked_up"`, string(data.Dispatch["status"]))
+			for field := range data.Dispatch {
+				assert.NotContains(t, strings.ToLower(field), "carrier", "shippers don't see the carrier or its rate")
+			}
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"/own":    {events.OrderCreated, events.DispatchStatusChanged},
+		"/client": {events.DispatchStatusChanged},
+	}, byPath, "customers only get events about their own records, and subscribers only the types they asked for")
+
+	// An event seen again is not queued twice.
+	var stored models.OutboxEvent
+	require.NoError(t, testDB.Where("type = ?", events.OrderCreated).First(&stored).Error)
+	require.NoError(t, svc.Webhooks.QueueEvent(ctx, events.Event{ID: stored.ID, Type: stored.Type, Data: []byte(stored.Payload)}))
+	sent, err = svc.Webhooks.SendDue(ctx, policy)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+
+	deliveries, err := svc.Webhooks.Deliveries(ctx, own.ID, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, events.DispatchStatusChanged, deliveries[0].EventType, "newest first")
+	assert.Equal(t, models.DeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	require.NotNil(t, deliveries[0].ResponseStatus)
+	assert.Equal(t, http.StatusNoContent, *deliveries[0].ResponseStatus)
+
+	_, err = svc.Webhooks.Deliveries(ctx, own.ID, 0, 501)
+	assert.EqualError(t, err, "limit must be between 1 and 500")
+}
+
+func TestWebhookRetriesAndDisablesFailingEndpoints(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	svc.Webhooks.AllowPrivateNetworks = true
+	ctx := context.Background()
+	receiver := newWebhookReceiver(t)
+	receiver.respondWith(http.StatusInternalServerError)
+
+	subscription := models.WebhookSubscription{ClientName: stringPtr("tms"), URL: receiver.URL}
+	require.NoError(t, svc.Webhooks.Create(ctx, &subscription))
+	queue := func(id uint) {
+		t.Helper()
+		event := events.Event{ID: id, Type: events.OrderCreated, OccurredAt: time.Now(), Data: []byte(`{"order":{}}`)}
+		require.NoError(t, svc.Webhooks.QueueEvent(ctx, event))
+	}
+	send := func() int {
+		t.Helper()
+		sent, err := svc.Webhooks.SendDue(ctx, services.WebhookPolicy{Timeout: 5 * time.Second, MaxAttempts: 3, DisableAfter: 4})
+		require.NoError(t, err)
+		return sent
+	}
+	delivery := func(eventID uint) models.WebhookDelivery {
+		t.Helper()
+		var d models.WebhookDelivery
+		require.NoError(t, testDB.Where("event_id = ?", eventID).First(&d).Error)
+		return d
+	}
+
+	queue(1)
+	require.Equal(t, 1, send())
+	first := delivery(1)
+	assert.Equal(t, models.DeliveryPending, first.Status)
+	assert.Equal(t, 1, first.Attempts)
+	require.NotNil(t, first.LastError)
+	assert.Contains(t, *first.LastError, "500")
+	require.NotNil(t, first.NextAttemptAt)
+	assert.WithinDuration(t, time.Now().Add(30*time.Second), *first.NextAttemptAt, 5*time.Second)
+	assert.Zero(t, send(), "the retry waits for its backoff")
+
+	makeDeliveriesDue(t, testDB)
+	require.Equal(t, 1, send())
+	assert.WithinDuration(t, time.Now().Add(time.Minute), *delivery(1).NextAttemptAt, 5*time.Second, "the backoff doubles")
+
+	makeDeliveriesDue(t, testDB)
+	require.Equal(t, 1, send())
+	first = delivery(1)
+	assert.Equal(t, models.DeliveryFailed, first.Status, "it fails after MaxAttempts")
+	assert.Nil(t, first.NextAttemptAt)
+
+	// The failures in a row add up across deliveries until the endpoint is disabled.
+	queue(2)
+	require.Equal(t, 1, send())
+	disabled, err := svc.Webhooks.Get(ctx, subscription.ID)
+	require.NoError(t, err)
+	assert.False(t, disabled.Active)
+	assert.Equal(t, 4, disabled.ConsecutiveFailures)
+	require.NotNil(t, disabled.DisabledReason)
+	assert.NotNil(t, disabled.DisabledAt)
+
+	queue(3)
+	var count int64
+	require.NoError(t, testDB.Model(&models.WebhookDelivery{}).Where("event_id = ?", 3).Count(&count).Error)
+	assert.Zero(t, count, "a disabled subscription gets no new events")
+	makeDeliveriesDue(t, testDB)
+	assert.Zero(t, send(), "nor are its pending deliveries sent")
+
+	// Once the endpoint is fixed it is enabled again and a failed delivery sent again.
+	receiver.respondWith(http.StatusOK)
+	enabled, err := svc.Webhooks.Enable(ctx, subscription.ID)
+	require.NoError(t, err)
+	assert.True(t, enabled.Active)
+	assert.Zero(t, enabled.ConsecutiveFailures)
+	assert.Nil(t, enabled.DisabledReason)
+
+	_, err = svc.Webhooks.Redeliver(ctx, subscription.ID, first.ID)
+	require.NoError(t, err)
+	receiver.take()
+	require.Equal(t, 2, send(), "the redelivery and the pending delivery")
+	assert.Len(t, receiver.take(), 2)
+	first = delivery(1)
+	assert.Equal(t, models.DeliveryDelivered, first.Status)
+	assert.Equal(t, 1, first.Attempts, "a redelivery starts a fresh count")
+
+	_, err = svc.Webhooks.Redeliver(ctx, subscription.ID+1, first.ID)
+	assert.ErrorIs(t, err, services.ErrNotFound)
+}
+
+func TestWebhooksOnlyGoToPublicAddresses(t *testing.T) {
+	t.Parallel()
+	svc, testDB := setupServices(t)
+	ctx := context.Background()
+	receiver := newWebhookReceiver(t)
+
+	for _, internal := range []string{receiver.URL, "http://localhost/hook", "http://169.254.169.254/latest/meta-data",
+		"http://10.0.0.5/hook", "http://[::1]:8080/hook", "http://100.100.100.200/"} {
+		subscription := models.WebhookSubscription{ClientName: stringPtr("tms"), URL: internal}
+		var validationErr *services.ValidationError
+		require.ErrorAs(t, svc.Webhooks.Create(ctx, &subscription), &validationErr, internal)
+		assert.Equal(t, "url", validationErr.Field)
+	}
+	public := models.WebhookSubscription{ClientName: stringPtr("tms"), URL: "https://tms.example/hook"}
+	require.NoError(t, svc.Webhooks.Create(ctx, &public))
+	_, err := svc.Webhooks.Update(ctx, public.ID, &models.WebhookSubscription{URL: "http://127.0.0.1/hook"})
+	assert.Error(t, err)
+
+	// A host that resolves to an internal address once saved is still refused
+	// when sending.
+	require.NoError(t, testDB.Model(&public).Update("url", receiver.URL).Error)
+	event := events.Event{ID: 1, Type: events.OrderCreated, OccurredAt: time.Now(), Data: []byte(`{"order":{}}`)}
+	require.NoError(t, svc.Webhooks.QueueEvent(ctx, event))
+	policy := services.WebhookPolicy{Timeout: 5 * time.Second, MaxAttempts: 3}
+	_, err = svc.Webhooks.SendDue(ctx, policy)
+	require.NoError(t, err)
+	assert.Empty(t, receiver.take())
+	var delivery models.WebhookDelivery
+	require.NoError(t, testDB.Where("event_id = ?", 1).First(&delivery).Error)
+	require.NotNil(t, delivery.LastError)
+	assert.Contains(t, *delivery.LastError, "not public")
+
+	// Redirects aren't followed, so they can't lead anywhere else either.
+	svc.Webhooks.AllowPrivateNetworks = true
+	redirect := httptest.NewServer(http.RedirectHandler(receiver.URL, http.StatusFound))
+	t.Cleanup(redirect.Close)
+	require.NoError(t, testDB.Model(&public).Update("url", redirect.URL).Error)
+	makeDeliveriesDue(t, testDB)
+	_, err = svc.Webhooks.SendDue(ctx, policy)
+	require.NoError(t, err)
+	assert.Empty(t, receiver.take())
+	require.NoError(t, testDB.Where("event_id = ?", 1).First(&delivery).Error)
+	require.NotNil(t, delivery.ResponseStatus)
+	assert.Equal(t, http.StatusFound, *delivery.ResponseStatus)
+	assert.Equal(t, models.DeliveryPending, delivery.Status, "a redirect is a failed attempt")
+}
+
+func TestWebhookEndpoints(t *testing.T) {
+	t.Parallel()
+	svc, _ := setupServices(t)
+	h := handlers.New(svc)
+	ctx := context.Background()
+	customer := models.Customer{CompanyName: "Shipper Co", ContactPerson: "Pat", Email: "pat@shipper.example", Phone: "555-0110"}
+	require.NoError(t, svc.Customers.Create(ctx, &customer))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Identity(""))
+	admin := router.Group("/api/admin", middleware.RequireRole("admin"))
+	admin.GET("/webhooks", h.GetWebhooks)
+	admin.POST("/webhooks", h.CreateWebhook)
+	admin.GET("/webhooks/:id", h.GetWebhook)
+	admin.PUT("/webhooks/:id", h.UpdateWebhook)
+	admin.DELETE("/webhooks/:id", h.DeleteWebhook)
+	admin.POST("/webhooks/:id/disable", h.DisableWebhook)
+	admin.GET("/webhooks/:id/deliveries", h.GetWebhookDeliveries)
+	admin.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", h.RedeliverWebhook)
+
+	do := func(method, path, body, role string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.UserRoleHeader, role)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/api/admin/webhooks", "", "broker").Code)
+
+	for _, invalid := range []struct{ body, field string }{
+		{`{"clientName":"tms","url":"ftp://tms.example/hook"}`, "url"},
+		{`{"clientName":"tms","url":"http://169.254.169.254/latest/meta-data"}`, "url"},
+		{`{"clientName":"tms"}`, "url"},
+		{`{"url":"https://tms.example/hook"}`, "customerId"},
+		{`{"customerId":` + strconv.Itoa(int(customer.ID)) + `,"clientName":"tms","url":"https://tms.example/hook"}`, "customerId"},
+		{`{"customerId":999,"url":"https://tms.example/hook"}`, "customerId"},
+		{`{"clientName":"tms","url":"https://tms.example/hook","eventTypes":["order.deleted"]}`, "eventTypes"},
+	} {
+		w := do(http.MethodPost, "/api/admin/webhooks", invalid.body, "admin")
+		require.Equal(t, http.StatusBadRequest, w.Code, invalid.body)
+		assert.Contains(t, w.Body.String(), `"field":"`+invalid.field+`"`, invalid.body)
+	}
+
+	w := do(http.MethodPost, "/api/admin/webhooks",
+		`{"customerId":`+strconv.Itoa(int(customer.ID))+`,"url":"https://shipper.example/hook","eventTypes":["dispatch.status_changed"]}`, "admin")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created models.WebhookSubscription
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.NotEmpty(t, created.Secret)
+	assert.True(t, created.Active)
+	path := "/api/admin/webhooks/" + strconv.Itoa(int(created.ID))
+
+	w = do(http.MethodGet, path, "", "admin")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), created.Secret)
+
+	w = do(http.MethodPut, path, `{"url":"https://shipper.example/v2/hook","secret":"mine","active":false}`, "admin")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var updated models.WebhookSubscription
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.Equal(t, "https://shipper.example/v2/hook", updated.URL)
+	assert.True(t, updated.Active, "only the URL, description and event types can be updated")
+	assert.Equal(t, models.StringList{events.DispatchStatusChanged}, updated.EventTypes)
+
+	w = do(http.MethodPost, path+"/disable", "", "admin")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"active":false`)
+
+	w = do(http.MethodGet, path+"/deliveries", "", "admin")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/api/admin/webhooks/999/deliveries", "", "admin").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodPost, path+"/deliveries/1/redeliver", "", "admin").Code)
+
+	assert.Equal(t, http.StatusOK, do(http.MethodDelete, path, "", "admin").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, path, "", "admin").Code)
+}
@@ -1,4 +1,4 @@
 #!/bin/bash
 cd go-backend
-export PORT=5000
-go run main.go
\ No newline at end of file
+export PORT=8080
+go run .
\ No newline at end of file